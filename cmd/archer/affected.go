@@ -0,0 +1,66 @@
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/pescuma/archer/lib/affected"
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/storages"
+)
+
+type AffectedCmd struct {
+	From      string `help:"Git revision to compare from."`
+	To        string `default:"HEAD" help:"Git revision to compare to."`
+	Patch     string `help:"Diff file with the changes, instead of using git revisions." type:"existingfile"`
+	Dir       string `short:"d" default:"." help:"Directory inside the git repository. Paths in the diff are relative to its root." type:"existingdir"`
+	MaxDepth  int    `help:"Max depth of dependents to consider. 0 means no limit."`
+	MaxPeople int    `default:"3" help:"Number of people to list for each project."`
+	Format    string `short:"f" default:"text" enum:"text,json,names" help:"Output format: text, json or names (only project names, one per line)."`
+	Output    string `short:"o" help:"Output file to write. Default is stdout." type:"path"`
+}
+
+func (c *AffectedCmd) Run(ctx *context) error {
+	if (c.From == "") == (c.Patch == "") {
+		return errors.New("one of --from or --patch must be used")
+	}
+
+	return ctx.ws.Execute(func(console consoles.Console, storage storages.Storage) error {
+		r, err := affected.NewFinder(console, storage).Find(&affected.Options{
+			Dir:       c.Dir,
+			From:      c.From,
+			To:        c.To,
+			Patch:     c.Patch,
+			MaxDepth:  c.MaxDepth,
+			MaxPeople: c.MaxPeople,
+		})
+		if err != nil {
+			return err
+		}
+
+		var w io.Writer = os.Stdout
+		if c.Output != "" {
+			f, err := os.Create(c.Output)
+			if err != nil {
+				return err
+			}
+
+			defer func() {
+				_ = f.Close()
+			}()
+
+			w = f
+		}
+
+		switch c.Format {
+		case "json":
+			return r.WriteJSON(w)
+		case "names":
+			return r.WriteNames(w)
+		default:
+			return r.WriteText(w)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/rules"
+)
+
+type CheckCmd struct {
+	Rules  string `arg:"" default:"archer-rules.yaml" help:"YAML file with the rules to check." type:"existingfile"`
+	Format string `short:"f" default:"text" enum:"text,json,junit" help:"Output format: text, json or junit."`
+	Output string `short:"o" help:"Output file to write. Default is stdout." type:"path"`
+}
+
+func (c *CheckCmd) Run(ctx *context) error {
+	rs, err := rules.Load(c.Rules)
+	if err != nil {
+		return err
+	}
+
+	projects, err := ctx.ws.LoadProjects()
+	if err != nil {
+		return err
+	}
+
+	results, err := rs.Check(projects)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if c.Output != "" {
+		f, err := os.Create(c.Output)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			_ = f.Close()
+		}()
+
+		w = f
+	}
+
+	switch c.Format {
+	case "json":
+		err = rs.WriteJSON(w, results)
+	case "junit":
+		err = rs.WriteJUnit(w, results)
+	default:
+		err = rs.WriteText(w, results)
+	}
+	if err != nil {
+		return err
+	}
+
+	violations := lo.SumBy(lo.Values(results), func(vs []*rules.Violation) int { return len(vs) })
+	if violations > 0 {
+		return errors.Errorf("%v architecture rule violations found", violations)
+	}
+
+	return nil
+}
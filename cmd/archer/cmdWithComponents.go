@@ -0,0 +1,38 @@
+package main
+
+import (
+	"github.com/pescuma/archer/lib/components"
+	"github.com/pescuma/archer/lib/model"
+)
+
+type cmdWithComponents struct {
+	Components string `help:"File with logical components and layers to group projects, one '<name> = <project query>' per line." type:"existingfile"`
+}
+
+// createGrouping returns the functions to group projects in roots and projects. By default, they are grouped by
+// their groups.
+func (c *cmdWithComponents) createGrouping(projs *model.Projects, projGrouping func(*model.Project) string,
+) (func(*model.Project) string, func(*model.Project) string, error) {
+	if c.Components == "" {
+		return func(p *model.Project) string { return p.FullGroup() }, projGrouping, nil
+	}
+
+	cfg, err := components.Load(c.Components, projs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = cfg.CheckLayers(projs.ListProjects(model.FilterExcludeExternal))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rootGrouping := func(p *model.Project) string {
+		if l := cfg.GetLayer(p); l != "" {
+			return l
+		}
+		return "components"
+	}
+
+	return rootGrouping, cfg.GetComponent, nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/branches"
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/filters"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages"
+)
+
+type CompareBranchesCmd struct {
+	A      string `arg:"" help:"First branch."`
+	B      string `arg:"" help:"Second branch."`
+	Repo   string `short:"r" help:"Only compare repositories with this name. Accepts * and re:<regex>."`
+	Format string `short:"f" default:"text" enum:"text,json" help:"Output format: text or json."`
+	Output string `short:"o" help:"Output file to write. Default is stdout." type:"path"`
+}
+
+func (c *CompareBranchesCmd) Run(ctx *context) error {
+	return ctx.ws.Execute(func(console consoles.Console, storage storages.Storage) error {
+		projects, err := storage.LoadProjects()
+		if err != nil {
+			return err
+		}
+
+		files, err := storage.LoadFiles()
+		if err != nil {
+			return err
+		}
+
+		people, err := storage.LoadPeople()
+		if err != nil {
+			return err
+		}
+
+		repos, err := storage.LoadRepositories()
+		if err != nil {
+			return err
+		}
+
+		filter, err := filters.ParseStringFilter(c.Repo)
+		if err != nil {
+			return err
+		}
+
+		rs := lo.Filter(repos.List(), func(r *model.Repository, _ int) bool { return filter(r.Name) })
+
+		cs := branches.Compare(rs, c.A, c.B, files, projects, people)
+
+		var w io.Writer = os.Stdout
+		if c.Output != "" {
+			f, err := os.Create(c.Output)
+			if err != nil {
+				return err
+			}
+
+			defer func() {
+				_ = f.Close()
+			}()
+
+			w = f
+		}
+
+		switch c.Format {
+		case "json":
+			return branches.WriteJSON(w, cs, people)
+		default:
+			return branches.WriteText(w, cs, people)
+		}
+	})
+}
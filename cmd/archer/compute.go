@@ -1,5 +1,9 @@
 package main
 
+import (
+	"github.com/pescuma/archer/lib/importers/cycles"
+)
+
 type ComputeAllCmd struct {
 }
 
@@ -37,6 +41,30 @@ func (c *ComputeAllCmd) Run(ctx *context) error {
 		return err
 	}
 
+	ws.Console().PopPrefix()
+	ws.Console().PushPrefix("imports: ")
+
+	err = ws.ComputeImports()
+	if err != nil {
+		return err
+	}
+
+	ws.Console().PopPrefix()
+	ws.Console().PushPrefix("cycles: ")
+
+	err = ws.ComputeCycles(&cycles.Options{Levels: []int{0}})
+	if err != nil {
+		return err
+	}
+
+	ws.Console().PopPrefix()
+	ws.Console().PushPrefix("levels: ")
+
+	err = ws.ComputeLevels()
+	if err != nil {
+		return err
+	}
+
 	ws.Console().PopPrefix()
 
 	return nil
@@ -69,3 +97,27 @@ type ComputeBlameCmd struct {
 func (c *ComputeBlameCmd) Run(ctx *context) error {
 	return ctx.ws.ComputeBlame()
 }
+
+type ComputeImportsCmd struct {
+}
+
+func (c *ComputeImportsCmd) Run(ctx *context) error {
+	return ctx.ws.ComputeImports()
+}
+
+type ComputeCyclesCmd struct {
+	Levels []int `short:"l" default:"0" help:"Levels of groups to check for cycles. 0 means projects."`
+}
+
+func (c *ComputeCyclesCmd) Run(ctx *context) error {
+	return ctx.ws.ComputeCycles(&cycles.Options{
+		Levels: c.Levels,
+	})
+}
+
+type ComputeLevelsCmd struct {
+}
+
+func (c *ComputeLevelsCmd) Run(ctx *context) error {
+	return ctx.ws.ComputeLevels()
+}
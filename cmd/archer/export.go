@@ -0,0 +1,214 @@
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/filters"
+	"github.com/pescuma/archer/lib/importers/metrics"
+	"github.com/pescuma/archer/lib/importers/sbom"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages"
+	"github.com/pescuma/archer/lib/utils"
+)
+
+type ExportCouplingCmd struct {
+	cmdWithFilters
+
+	Levels int    `short:"l" help:"How many levels of subprojects should be considered."`
+	Output string `short:"o" help:"Output file to write. Default is stdout." type:"path"`
+}
+
+func (c *ExportCouplingCmd) Run(ctx *context) error {
+	projects, err := ctx.ws.LoadProjects()
+	if err != nil {
+		return err
+	}
+
+	filter, err := c.createFilter(projects)
+	if err != nil {
+		return err
+	}
+
+	ps := projects.ListProjects(model.FilterExcludeExternal)
+
+	grouping := func(p *model.Project) string {
+		return p.LevelSimpleName(c.Levels)
+	}
+
+	level := min(c.Levels, metrics.MaxGroupLevel(ps))
+
+	show := computeNodesShow(ps, filter, false)
+
+	names := map[string]bool{}
+	for _, p := range ps {
+		if show[p.Name] {
+			names[grouping(p)] = true
+		}
+	}
+
+	sorted := make([]string, 0, len(names))
+	for n := range names {
+		sorted = append(sorted, n)
+	}
+	sort.Strings(sorted)
+
+	var w io.Writer = os.Stdout
+	if c.Output != "" {
+		f, err := os.Create(c.Output)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			_ = f.Close()
+		}()
+
+		w = f
+	}
+
+	ratio := func(v float64) string {
+		if v < 0 {
+			return ""
+		}
+		return strconv.FormatFloat(v, 'f', 3, 64)
+	}
+
+	count := func(v int) string {
+		if v < 0 {
+			return ""
+		}
+		return strconv.Itoa(v)
+	}
+
+	cw := csv.NewWriter(w)
+
+	err = cw.Write([]string{"name", "ca", "ce", "instability", "abstractness", "distance"})
+	if err != nil {
+		return err
+	}
+
+	for _, n := range sorted {
+		cp := projects.GetGroupCoupling(level, n)
+		if cp == nil {
+			cp = model.NewCoupling()
+		}
+
+		err = cw.Write([]string{
+			n,
+			count(cp.Afferent),
+			count(cp.Efferent),
+			ratio(cp.Instability),
+			ratio(cp.Abstractness),
+			ratio(cp.Distance),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+type ExportSbomCmd struct {
+	Filter string `arg:"" optional:"" help:"Filter with the code projects to export. Default is all."`
+	By     string `default:"root" enum:"root,project" help:"Create one SBOM per root or per project."`
+	Format string `short:"f" default:"cyclonedx" enum:"cyclonedx,spdx" help:"Output format: cyclonedx or spdx."`
+	Output string `short:"o" default:"." help:"Directory to write the SBOM files." type:"path"`
+}
+
+func (c *ExportSbomCmd) Run(ctx *context) error {
+	return ctx.ws.Execute(func(console consoles.Console, storage storages.Storage) error {
+		projects, err := storage.LoadProjects()
+		if err != nil {
+			return err
+		}
+
+		repos, err := storage.LoadRepositories()
+		if err != nil {
+			return err
+		}
+
+		filter := func(*model.Project) bool { return true }
+		if c.Filter != "" {
+			filter, err = filters.ParseOnlyProjsFilter(c.Filter)
+			if err != nil {
+				return err
+			}
+		}
+
+		units := map[string][]*model.Project{}
+		for _, p := range projects.ListProjects(model.FilterExcludeExternal) {
+			if !p.IsCode() || !filter(p) {
+				continue
+			}
+
+			name := p.Name
+			if c.By == "root" && p.FullGroup() != "" {
+				name = p.FullGroup()
+			}
+
+			units[name] = append(units[name], p)
+		}
+
+		err = os.MkdirAll(c.Output, 0o755)
+		if err != nil {
+			return err
+		}
+
+		names := lo.Keys(units)
+		sort.Strings(names)
+
+		for _, name := range names {
+			ps := units[name]
+
+			var repo *model.Repository
+			if ids := lo.Uniq(lo.Map(ps, func(p *model.Project, _ int) *model.ID { return p.RepositoryID })); len(ids) == 1 && ids[0] != nil {
+				repo = repos.GetByID(*ids[0])
+			}
+
+			doc := sbom.Build(name, ps, repo)
+
+			ext := utils.IIf(c.Format == "spdx", ".spdx.json", ".cdx.json")
+			file := filepath.Join(c.Output, fileNameRE.ReplaceAllString(name, "_")+ext)
+
+			console.Printf("Writing %v...\n", file)
+
+			err = writeSbom(file, doc, c.Format)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+var fileNameRE = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+func writeSbom(file string, doc *sbom.Document, format string) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	switch format {
+	case "spdx":
+		return sbom.WriteSPDX(f, doc)
+	default:
+		return sbom.WriteCycloneDX(f, doc)
+	}
+}
@@ -10,18 +10,24 @@ import (
 	"strings"
 
 	"github.com/dustin/go-humanize"
+	"github.com/samber/lo"
 
 	"github.com/pescuma/archer/lib/filters"
+	"github.com/pescuma/archer/lib/importers/cycles"
+	"github.com/pescuma/archer/lib/importers/levels"
 	"github.com/pescuma/archer/lib/model"
 	"github.com/pescuma/archer/lib/utils"
 )
 
 type GraphCmd struct {
 	cmdWithFilters
+	cmdWithComponents
 
 	Output string `short:"o" default:"deps.png" help:"Output file to write." type:"path"`
 	Levels int    `short:"l" help:"How many levels of subprojects should be considered."`
 	Lines  bool   `default:"true" negatable:"" help:"Scale nodes by the number of lines."`
+	Cycles bool   `help:"Highlight edges that are part of dependency cycles in red."`
+	Rank   bool   `help:"Place nodes with the same level in the same rank. Requires compute levels."`
 }
 
 func (c *GraphCmd) Run(ctx *context) error {
@@ -35,7 +41,16 @@ func (c *GraphCmd) Run(ctx *context) error {
 		return err
 	}
 
-	dot := c.generateDot(projects, filter)
+	rootGrouping, getProjectName, err := c.createGrouping(projects, func(p *model.Project) string {
+		result := p.LevelSimpleName(c.Levels)
+		result = strings.TrimSuffix(result, "-api")
+		return result
+	})
+	if err != nil {
+		return err
+	}
+
+	dot := c.generateDot(projects, filter, rootGrouping, getProjectName)
 
 	gv := c.Output + ".gv"
 
@@ -70,20 +85,17 @@ func (c *GraphCmd) Run(ctx *context) error {
 	return nil
 }
 
-func (c *GraphCmd) generateDot(projects *model.Projects, filter filters.ProjectFilter) string {
+func (c *GraphCmd) generateDot(projects *model.Projects, filter filters.ProjectFilter,
+	rootGrouping func(*model.Project) string, getProjectName func(*model.Project) string,
+) string {
 	ps := projects.ListProjects(model.FilterExcludeExternal)
 
-	getProjectName := func(p *model.Project) string {
-		result := p.LevelSimpleName(c.Levels)
-		result = strings.TrimSuffix(result, "-api")
-		return result
-	}
-
-	tg := groupByGroups(ps, filter, true, getProjectName)
+	tg := groupByGroups(ps, filter, true, rootGrouping, getProjectName)
 
 	nodes := map[string]*node{}
 	colors := c.computeColors(ps, getProjectName)
 	showSizes, computeGraphSize := c.computeSizesConfig(tg)
+	cycleEdges := c.computeCycleEdges(ps, getProjectName)
 
 	o := newOutput()
 	o.addLine(`digraph G {`)
@@ -133,12 +145,21 @@ func (c *GraphCmd) generateDot(projects *model.Projects, filter filters.ProjectF
 				e.attribs["color"] = colors[dg.fullName]
 				e.attribs["style"] = dg.dep.GetData("style")
 
+				if cycleEdges[pg.fullName+"\n"+dg.fullName] {
+					e.attribs["color"] = "red"
+					e.attribs["penwidth"] = "2"
+				}
+
 				o.addLineDistinct(e)
 			}
 		}
 	}
 	o.addLine("")
 
+	if c.Rank {
+		c.addRanks(o, ps, getProjectName, nodes)
+	}
+
 	if showSizes && !tg.size.isEmpty() {
 		o.addLine("{ rank = sink; legend_Total [shape=plaintext label=<Total<br/>%v>] }", tg.size.html())
 	}
@@ -179,6 +200,52 @@ func (c *GraphCmd) computeSizesConfig(tg *group) (bool, func(int) float64) {
 	}
 }
 
+func (c *GraphCmd) addRanks(o *output, ps []*model.Project, getProjectName func(p *model.Project) string, nodes map[string]*node) {
+	byName := map[string]int{}
+	for _, p := range ps {
+		l := levels.GetLevel(p)
+		if l < 0 {
+			continue
+		}
+
+		name := getProjectName(p)
+		if _, ok := nodes[name]; ok {
+			byName[name] = max(byName[name], l)
+		}
+	}
+
+	byLevel := map[int][]string{}
+	for name, l := range byName {
+		byLevel[l] = append(byLevel[l], fmt.Sprintf(`"%v"`, name))
+	}
+
+	ls := lo.Keys(byLevel)
+	sort.Ints(ls)
+
+	for _, l := range ls {
+		names := byLevel[l]
+		sort.Strings(names)
+		o.addLine("{ rank = same; %v; }", strings.Join(names, "; "))
+	}
+	o.addLine("")
+}
+
+func (c *GraphCmd) computeCycleEdges(ps []*model.Project, getProjectName func(p *model.Project) string) map[string]bool {
+	result := map[string]bool{}
+
+	if !c.Cycles {
+		return result
+	}
+
+	for _, cycle := range cycles.FindCycles(ps, getProjectName) {
+		for _, e := range cycle.Edges {
+			result[e.Source+"\n"+e.Target] = true
+		}
+	}
+
+	return result
+}
+
 func (c *GraphCmd) computeColors(ps []*model.Project, getProjectName func(p *model.Project) string) map[string]string {
 	availableColors := []string{
 		"#1abc9c",
@@ -1,5 +1,12 @@
 package main
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pescuma/archer/lib/ignore_rules"
+)
+
 type IgnoreAddFileCmd struct {
 	Rule string `arg:"" help:"File ignore rule: a file path or a regex."`
 }
@@ -15,3 +22,39 @@ type IgnoreAddCommitCmd struct {
 func (c *IgnoreAddCommitCmd) Run(ctx *context) error {
 	return ctx.ws.IgnoreAddCommitRule(c.Rule)
 }
+
+type IgnoreAddPersonCmd struct {
+	Rule string `arg:"" help:"Person ignore rule: a name, an email, id:<id> or a regex."`
+}
+
+func (c *IgnoreAddPersonCmd) Run(ctx *context) error {
+	return ctx.ws.IgnoreAddPersonRule(c.Rule)
+}
+
+type IgnoreAddBotsCmd struct {
+	Apply bool `help:"Add an ignore rule for each of the listed bots."`
+}
+
+func (c *IgnoreAddBotsCmd) Run(ctx *context) error {
+	bots, err := ctx.ws.FindLikelyBots()
+	if err != nil {
+		return err
+	}
+
+	for _, b := range bots {
+		fmt.Printf("%v <%v>: %v\n", b.Person.Name, strings.Join(b.Person.ListEmails(), ", "), strings.Join(b.Reasons, ", "))
+
+		if c.Apply {
+			err = ctx.ws.IgnoreAddPersonRule(ignore_rules.CreateBotRule(b.Person))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if !c.Apply && len(bots) > 0 {
+		fmt.Printf("\nRun again with --apply to ignore them.\n")
+	}
+
+	return nil
+}
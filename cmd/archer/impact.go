@@ -0,0 +1,67 @@
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/pescuma/archer/lib/filters"
+	"github.com/pescuma/archer/lib/impact"
+	"github.com/pescuma/archer/lib/model"
+)
+
+type ImpactCmd struct {
+	Filter   string `arg:"" help:"Filter with the projects that will change."`
+	MaxDepth int    `short:"d" help:"Max depth of dependents to list. 0 means no limit."`
+	Format   string `short:"f" default:"text" enum:"text,json" help:"Output format: text or json."`
+	Output   string `short:"o" help:"Output file to write. Default is stdout." type:"path"`
+}
+
+func (c *ImpactCmd) Run(ctx *context) error {
+	projects, err := ctx.ws.LoadProjects()
+	if err != nil {
+		return err
+	}
+
+	filter, err := filters.ParseOnlyProjsFilter(c.Filter)
+	if err != nil {
+		return err
+	}
+
+	ps := projects.ListProjects(model.FilterAll)
+
+	var selected []*model.Project
+	for _, p := range ps {
+		if filter(p) {
+			selected = append(selected, p)
+		}
+	}
+
+	if len(selected) == 0 {
+		return errors.Errorf("no projects found matching %v", c.Filter)
+	}
+
+	ds := impact.FindDependents(ps, selected, c.MaxDepth)
+
+	var w io.Writer = os.Stdout
+	if c.Output != "" {
+		f, err := os.Create(c.Output)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			_ = f.Close()
+		}()
+
+		w = f
+	}
+
+	switch c.Format {
+	case "json":
+		return impact.WriteJSON(w, selected, ds)
+	default:
+		return impact.WriteText(w, selected, ds)
+	}
+}
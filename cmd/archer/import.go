@@ -4,13 +4,20 @@ import (
 	"strings"
 	"time"
 
+	"github.com/pescuma/archer/lib/importers/cargo"
 	"github.com/pescuma/archer/lib/importers/csproj"
 	"github.com/pescuma/archer/lib/importers/git"
 	"github.com/pescuma/archer/lib/importers/gomod"
+	"github.com/pescuma/archer/lib/importers/gradle"
 	"github.com/pescuma/archer/lib/importers/hibernate"
 	"github.com/pescuma/archer/lib/importers/loc"
+	"github.com/pescuma/archer/lib/importers/maven"
 	"github.com/pescuma/archer/lib/importers/metrics"
+	"github.com/pescuma/archer/lib/importers/migrations"
+	"github.com/pescuma/archer/lib/importers/npm"
 	"github.com/pescuma/archer/lib/importers/owners"
+	"github.com/pescuma/archer/lib/importers/python"
+	"github.com/pescuma/archer/lib/importers/sbom"
 )
 
 type ImportAllCmd struct {
@@ -47,6 +54,50 @@ func (c *ImportAllCmd) Run(ctx *context) error {
 		return err
 	}
 
+	ws.Console().PopPrefix()
+	ws.Console().PushPrefix("maven: ")
+
+	err = ws.ImportMaven(c.Paths, &maven.Options{
+		Groups:           strings.Split(c.Group, ":"),
+		RespectGitignore: c.Gitignore,
+	})
+	if err != nil {
+		return err
+	}
+
+	ws.Console().PopPrefix()
+	ws.Console().PushPrefix("npm: ")
+
+	err = ws.ImportNpm(c.Paths, &npm.Options{
+		Groups:           strings.Split(c.Group, ":"),
+		RespectGitignore: c.Gitignore,
+	})
+	if err != nil {
+		return err
+	}
+
+	ws.Console().PopPrefix()
+	ws.Console().PushPrefix("cargo: ")
+
+	err = ws.ImportCargo(c.Paths, &cargo.Options{
+		Groups:           strings.Split(c.Group, ":"),
+		RespectGitignore: c.Gitignore,
+	})
+	if err != nil {
+		return err
+	}
+
+	ws.Console().PopPrefix()
+	ws.Console().PushPrefix("python: ")
+
+	err = ws.ImportPython(c.Paths, &python.Options{
+		Groups:           strings.Split(c.Group, ":"),
+		RespectGitignore: c.Gitignore,
+	})
+	if err != nil {
+		return err
+	}
+
 	ws.Console().PopPrefix()
 
 	if c.Fetch {
@@ -119,23 +170,28 @@ func (c *ImportAllCmd) Run(ctx *context) error {
 }
 
 type ImportGradleCmd struct {
-	Path string `arg:"" help:"Path to search for gradle projects." type:"existingpath"`
+	Path   string `arg:"" help:"Path to search for gradle projects." type:"existingpath"`
+	Static bool   `help:"Parse settings and build scripts instead of running gradlew. Faster, but does not import transitive dependencies."`
 }
 
 func (c *ImportGradleCmd) Run(ctx *context) error {
-	return ctx.ws.ImportGradle(c.Path)
+	return ctx.ws.ImportGradle(c.Path, &gradle.Options{
+		Static: c.Static,
+	})
 }
 
 type ImportGoModCmd struct {
 	Paths     []string `arg:"" help:"Paths to recursively search for go.mod files." type:"existingpath"`
 	Group     string   `help:"Group to use for the projects."`
 	Gitignore bool     `default:"true" help:"Respect .gitignore file when importing files."`
+	Packages  bool     `help:"Also create one project per package, grouped under the module, with the imports between them."`
 }
 
 func (c *ImportGoModCmd) Run(ctx *context) error {
 	return ctx.ws.ImportGoMod(c.Paths, &gomod.Options{
 		Groups:           strings.Split(c.Group, ":"),
 		RespectGitignore: c.Gitignore,
+		Packages:         c.Packages,
 	})
 }
 
@@ -152,6 +208,58 @@ func (c *ImportCsprojCmd) Run(ctx *context) error {
 	})
 }
 
+type ImportMavenCmd struct {
+	Paths     []string `arg:"" help:"Paths to recursively search for pom.xml files." type:"existingpath"`
+	Group     string   `help:"Group to use for the projects."`
+	Gitignore bool     `default:"true" help:"Respect .gitignore file when importing files."`
+}
+
+func (c *ImportMavenCmd) Run(ctx *context) error {
+	return ctx.ws.ImportMaven(c.Paths, &maven.Options{
+		Groups:           strings.Split(c.Group, ":"),
+		RespectGitignore: c.Gitignore,
+	})
+}
+
+type ImportNpmCmd struct {
+	Paths     []string `arg:"" help:"Paths to recursively search for package.json files." type:"existingpath"`
+	Group     string   `help:"Group to use for the projects."`
+	Gitignore bool     `default:"true" help:"Respect .gitignore file when importing files."`
+}
+
+func (c *ImportNpmCmd) Run(ctx *context) error {
+	return ctx.ws.ImportNpm(c.Paths, &npm.Options{
+		Groups:           strings.Split(c.Group, ":"),
+		RespectGitignore: c.Gitignore,
+	})
+}
+
+type ImportCargoCmd struct {
+	Paths     []string `arg:"" help:"Paths to recursively search for Cargo.toml files." type:"existingpath"`
+	Group     string   `help:"Group to use for the projects."`
+	Gitignore bool     `default:"true" help:"Respect .gitignore file when importing files."`
+}
+
+func (c *ImportCargoCmd) Run(ctx *context) error {
+	return ctx.ws.ImportCargo(c.Paths, &cargo.Options{
+		Groups:           strings.Split(c.Group, ":"),
+		RespectGitignore: c.Gitignore,
+	})
+}
+
+type ImportPythonCmd struct {
+	Paths     []string `arg:"" help:"Paths to recursively search for python packaging files." type:"existingpath"`
+	Group     string   `help:"Group to use for the projects."`
+	Gitignore bool     `default:"true" help:"Respect .gitignore file when importing files."`
+}
+
+func (c *ImportPythonCmd) Run(ctx *context) error {
+	return ctx.ws.ImportPython(c.Paths, &python.Options{
+		Groups:           strings.Split(c.Group, ":"),
+		RespectGitignore: c.Gitignore,
+	})
+}
+
 type ImportHibernateCmd struct {
 	Path        []string `arg:"" help:"Path with root of projects to search." type:"existingpath"`
 	Group       string   `help:"Group to use for the projects."`
@@ -174,6 +282,33 @@ func (c *ImportMySqlCmd) Run(ctx *context) error {
 	return ctx.ws.ImportMySql(c.ConnectionString)
 }
 
+type ImportPostgresCmd struct {
+	ConnectionString string `arg:"" help:"PostgreSQL connection string."`
+}
+
+func (c *ImportPostgresCmd) Run(ctx *context) error {
+	return ctx.ws.ImportPostgres(c.ConnectionString)
+}
+
+type ImportSqliteCmd struct {
+	File string `arg:"" help:"SQLite database file." type:"existingfile"`
+}
+
+func (c *ImportSqliteCmd) Run(ctx *context) error {
+	return ctx.ws.ImportSqlite(c.File)
+}
+
+type ImportMigrationsCmd struct {
+	Paths []string `arg:"" help:"Paths to recursively search for SQL migration files." type:"existingpath"`
+	Group string   `help:"Group to use for the tables."`
+}
+
+func (c *ImportMigrationsCmd) Run(ctx *context) error {
+	return ctx.ws.ImportMigrations(c.Paths, &migrations.Options{
+		Groups: strings.Split(c.Group, ":"),
+	})
+}
+
 type ImportLOCCmd struct {
 	Filters     []string `default:"" help:"Filters to be applied to the projects. Empty means all."`
 	Incremental bool     `default:"true" negatable:"" help:"Don't import files already imported."`
@@ -225,6 +360,7 @@ func (c *ImportGitPeopleCmd) Run(ctx *context) error {
 type ImportGitHistoryCmd struct {
 	Paths         []string      `arg:"" help:"Paths with the roots of git repositories." type:"existingpath"`
 	Branch        string        `help:"Git branch to use to import data."`
+	Branches      []string      `help:"Other git branches to import, as globs (for ex. release/*). Remote branches are also matched."`
 	Incremental   bool          `default:"true" negatable:"" help:"Don't import commits already imported."`
 	LimitImported int           `help:"Limit the number of imported commits. Can be used to incrementally import data. Counted from the latest commit."`
 	LimitCommits  int           `help:"Limit the number of commits to be imported. Counted from the latest commit."`
@@ -236,6 +372,7 @@ type ImportGitHistoryCmd struct {
 func (c *ImportGitHistoryCmd) Run(ctx *context) error {
 	return ctx.ws.ImportGitHistory(c.Paths, &git.HistoryOptions{
 		Branch:             c.Branch,
+		Branches:           c.Branches,
 		Incremental:        c.Incremental,
 		MaxImportedCommits: toOption(c.LimitImported),
 		MaxCommits:         toOption(c.LimitCommits),
@@ -260,6 +397,25 @@ func (c *ImportGitBlameCmd) Run(ctx *context) error {
 	})
 }
 
+type ImportOsvCmd struct {
+	Path string `arg:"" help:"Directory or zip file with the OSV JSON export." type:"existingpath"`
+}
+
+func (c *ImportOsvCmd) Run(ctx *context) error {
+	return ctx.ws.ImportOsv(c.Path)
+}
+
+type ImportSbomCmd struct {
+	File  string `arg:"" help:"CycloneDX or SPDX JSON file." type:"existingfile"`
+	Group string `help:"Group to use for the projects."`
+}
+
+func (c *ImportSbomCmd) Run(ctx *context) error {
+	return ctx.ws.ImportSbom(c.File, &sbom.Options{
+		Groups: strings.Split(c.Group, ":"),
+	})
+}
+
 type ImportOwnersCmd struct {
 	Filters       []string `default:"" help:"Filters to be applied to the projects. Empty means all."`
 	Incremental   bool     `default:"true" negatable:"" help:"Don't import files already imported."`
@@ -9,29 +9,44 @@ import (
 var cli struct {
 	Workspace string `short:"w" help:"Workspace to store data. Default is ./.archer/archer.sqlite or ~/.archer/archer.sqlite if that does not exist." type:"file"`
 
-	Show  ShowCmd  `cmd:"" help:"Show the dependencies of projects inside a json file."`
-	Graph GraphCmd `cmd:"" help:"Generate dependencies graph. Requires dot in path."`
+	Show            ShowCmd            `cmd:"" help:"Show the dependencies of projects inside a json file."`
+	Graph           GraphCmd           `cmd:"" help:"Generate dependencies graph. Requires dot in path."`
+	Check           CheckCmd           `cmd:"" help:"Check architecture rules. Exits with an error if any rule is violated."`
+	Impact          ImpactCmd          `cmd:"" help:"List the projects that transitively depend on the selected ones."`
+	Affected        AffectedCmd        `cmd:"" help:"List the projects affected by changes in git or in a diff file, with their product areas and people."`
+	Versions        VersionsCmd        `cmd:"" help:"List libraries used with more than one version, and how far behind each consumer is."`
+	Releases        ReleasesCmd        `cmd:"" help:"List releases (git tags) per repository, with their size and the time between them."`
+	CompareBranches CompareBranchesCmd `cmd:"" help:"List commits, files, projects and people that are only in one of two imported branches."`
 
 	Config struct {
 		Set ConfigSetCmd `cmd:"" help:"Set configuration parameters."`
 	} `cmd:""`
 
 	Import struct {
-		All       ImportAllCmd       `cmd:"" help:"Import all information recursively."`
-		Gradle    ImportGradleCmd    `cmd:"" help:"Import information from gradle project."`
-		GoMod     ImportGoModCmd     `cmd:"" help:"Import information from go.mod files."`
-		Csproj    ImportCsprojCmd    `cmd:"" help:"Import information from csproj files."`
-		Hibernate ImportHibernateCmd `cmd:"" help:"Import information from hibernate annotation in classes."`
-		Mysql     ImportMySqlCmd     `cmd:"" help:"Import information from MySQL schema."`
-		LOC       ImportLOCCmd       `cmd:"" help:"Import counts of lines of code to existing projects."`
-		Metrics   ImportMetricsCmd   `cmd:"" help:"Import code metrics to existing projects."`
-		Git       struct {
+		All        ImportAllCmd        `cmd:"" help:"Import all information recursively."`
+		Gradle     ImportGradleCmd     `cmd:"" help:"Import information from gradle project."`
+		GoMod      ImportGoModCmd      `cmd:"" help:"Import information from go.mod files."`
+		Csproj     ImportCsprojCmd     `cmd:"" help:"Import information from csproj files."`
+		Maven      ImportMavenCmd      `cmd:"" help:"Import information from maven pom.xml files."`
+		Npm        ImportNpmCmd        `cmd:"" help:"Import information from package.json files."`
+		Cargo      ImportCargoCmd      `cmd:"" help:"Import information from Cargo.toml files."`
+		Python     ImportPythonCmd     `cmd:"" help:"Import information from pyproject.toml, setup.cfg and requirements.txt files."`
+		Hibernate  ImportHibernateCmd  `cmd:"" help:"Import information from hibernate annotation in classes."`
+		Mysql      ImportMySqlCmd      `cmd:"" help:"Import information from MySQL schema."`
+		Postgres   ImportPostgresCmd   `cmd:"" help:"Import information from PostgreSQL schema."`
+		Sqlite     ImportSqliteCmd     `cmd:"" help:"Import information from SQLite database file."`
+		Migrations ImportMigrationsCmd `cmd:"" help:"Import information from SQL migration files."`
+		LOC        ImportLOCCmd        `cmd:"" help:"Import counts of lines of code to existing projects."`
+		Metrics    ImportMetricsCmd    `cmd:"" help:"Import code metrics to existing projects."`
+		Git        struct {
 			History ImportGitHistoryCmd `cmd:"" help:"Import history information from git."`
 			Blame   ImportGitBlameCmd   `cmd:"" help:"Import blame information from git."`
 			People  ImportGitPeopleCmd  `cmd:"" help:"Import only people information from git."`
 			Repos   ImportGitReposCmd   `cmd:"" help:"Import only repository information from git."`
 		} `cmd:""`
 		Owners ImportOwnersCmd `cmd:"" help:"Import file owners."`
+		Osv    ImportOsvCmd    `cmd:"" help:"Import vulnerabilities from a local OSV export (directory or zip) and match them with library versions."`
+		Sbom   ImportSbomCmd   `cmd:"" help:"Import projects and dependencies from a CycloneDX or SPDX JSON file."`
 	} `cmd:""`
 
 	Compute struct {
@@ -40,12 +55,29 @@ var cli struct {
 		Metrics ComputeMetricsCmd `cmd:"" help:"Compute code metrics based on imported files."`
 		History ComputeHistoryCmd `cmd:"" help:"Compute history based on imported files."`
 		Blame   ComputeBlameCmd   `cmd:"" help:"Compute blame based on imported files."`
+		Imports ComputeImportsCmd `cmd:"" help:"Compute dependencies usage based on source imports."`
+		Cycles  ComputeCyclesCmd  `cmd:"" help:"Compute dependency cycles between projects or groups."`
+		Levels  ComputeLevelsCmd  `cmd:"" help:"Compute topological levels and Lakos metrics of code projects."`
+	} `cmd:""`
+
+	Export struct {
+		Coupling ExportCouplingCmd `cmd:"" help:"Export coupling metrics (Ca, Ce, I, A and D) as CSV, to plot instability x abstractness."`
+		Sbom     ExportSbomCmd     `cmd:"" help:"Export a software bill of materials per root or project, in CycloneDX or SPDX format."`
+	} `cmd:""`
+
+	People struct {
+		List   PeopleListCmd   `cmd:"" help:"List people imported from git, with their names and emails."`
+		Merge  PeopleMergeCmd  `cmd:"" help:"Merge people into one. The decision is kept in the next imports."`
+		Split  PeopleSplitCmd  `cmd:"" help:"Move emails of a person to a new person. The decision is kept in the next imports."`
+		Rename PeopleRenameCmd `cmd:"" help:"Rename a person. The decision is kept in the next imports."`
 	} `cmd:""`
 
 	Ignore struct {
 		Add struct {
 			File   IgnoreAddFileCmd   `cmd:"" help:"Add a file ignore rule."`
 			Commit IgnoreAddCommitCmd `cmd:"" help:"Add a commit ignore rule."`
+			Person IgnoreAddPersonCmd `cmd:"" help:"Add a person ignore rule. Commits by ignored people are not used in history and blame."`
+			Bots   IgnoreAddBotsCmd   `cmd:"" help:"Find likely bots and service accounts and add person ignore rules for them."`
 		} `cmd:""`
 	} `cmd:""`
 
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type PeopleListCmd struct {
+	Filter string `arg:"" optional:"" help:"Filter people by name, email or id:<id>. Accepts * and re:<regex>."`
+}
+
+func (c *PeopleListCmd) Run(ctx *context) error {
+	people, err := ctx.ws.ListPeople(c.Filter)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range people {
+		if p.Ignore {
+			fmt.Printf("id:%v %v (ignored)\n", p.ID, p.Name)
+		} else {
+			fmt.Printf("id:%v %v\n", p.ID, p.Name)
+		}
+
+		names := p.ListNames()
+		if len(names) > 1 || len(names) == 1 && names[0] != p.Name {
+			fmt.Printf("   names:  %v\n", strings.Join(names, ", "))
+		}
+		fmt.Printf("   emails: %v\n", strings.Join(p.ListEmails(), ", "))
+	}
+
+	return nil
+}
+
+type PeopleMergeCmd struct {
+	Target string   `arg:"" help:"Person that will be kept: id:<id>, email or name."`
+	Others []string `arg:"" help:"People to merge into the target: id:<id>, email or name."`
+}
+
+func (c *PeopleMergeCmd) Run(ctx *context) error {
+	return ctx.ws.MergePeople(c.Target, c.Others)
+}
+
+type PeopleSplitCmd struct {
+	Person string   `arg:"" help:"Person to split: id:<id>, email or name."`
+	Emails []string `arg:"" help:"Emails to move to the new person."`
+	Name   string   `help:"Name of the new person. Default is the name of the current person."`
+}
+
+func (c *PeopleSplitCmd) Run(ctx *context) error {
+	return ctx.ws.SplitPerson(c.Person, c.Emails, c.Name)
+}
+
+type PeopleRenameCmd struct {
+	Person string `arg:"" help:"Person to rename: id:<id>, email or name."`
+	Name   string `arg:"" help:"New name."`
+}
+
+func (c *PeopleRenameCmd) Run(ctx *context) error {
+	return ctx.ws.RenamePerson(c.Person, c.Name)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/filters"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/releases"
+	"github.com/pescuma/archer/lib/storages"
+)
+
+type ReleasesCmd struct {
+	Repo   string `short:"r" help:"Only show releases of repositories with this name. Accepts * and re:<regex>."`
+	Format string `short:"f" default:"text" enum:"text,json" help:"Output format: text or json."`
+	Output string `short:"o" help:"Output file to write. Default is stdout." type:"path"`
+}
+
+func (c *ReleasesCmd) Run(ctx *context) error {
+	return ctx.ws.Execute(func(console consoles.Console, storage storages.Storage) error {
+		files, err := storage.LoadFiles()
+		if err != nil {
+			return err
+		}
+
+		people, err := storage.LoadPeople()
+		if err != nil {
+			return err
+		}
+
+		repos, err := storage.LoadRepositories()
+		if err != nil {
+			return err
+		}
+
+		filter, err := filters.ParseStringFilter(c.Repo)
+		if err != nil {
+			return err
+		}
+
+		rs := lo.Filter(repos.List(), func(r *model.Repository, _ int) bool { return filter(r.Name) })
+
+		rrs := releases.ComputeAll(rs, people, files)
+
+		var w io.Writer = os.Stdout
+		if c.Output != "" {
+			f, err := os.Create(c.Output)
+			if err != nil {
+				return err
+			}
+
+			defer func() {
+				_ = f.Close()
+			}()
+
+			w = f
+		}
+
+		switch c.Format {
+		case "json":
+			return releases.WriteJSON(w, rrs)
+		default:
+			return releases.WriteText(w, rrs)
+		}
+	})
+}
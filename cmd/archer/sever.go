@@ -7,13 +7,15 @@ import (
 )
 
 type ServerCmd struct {
-	Port uint `default:"2724" help:"Port to listen to."`
+	Port       uint   `default:"2724" help:"Port to listen to."`
+	Components string `help:"File with logical components and layers, to allow grouping projects by component." type:"existingfile"`
 }
 
 func (c *ServerCmd) Run(ctx *context) error {
 	return ctx.ws.Execute(func(console consoles.Console, storage storages.Storage) error {
 		return server.Run(console, storage, &server.Options{
-			Port: c.Port,
+			Port:       c.Port,
+			Components: c.Components,
 		})
 	})
 }
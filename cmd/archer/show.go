@@ -2,16 +2,27 @@ package main
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
 
 	"github.com/pescuma/archer/lib/filters"
+	"github.com/pescuma/archer/lib/importers/metrics"
+	"github.com/pescuma/archer/lib/importers/osv"
 	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/utils"
 )
 
 type ShowCmd struct {
 	cmdWithFilters
+	cmdWithComponents
 
-	Levels int  `short:"l" help:"How many levels of subprojects should be considered."`
-	Simple bool `short:"s" help:"Only show project names"`
+	Levels   int  `short:"l" help:"How many levels of subprojects should be considered."`
+	Simple   bool `short:"s" help:"Only show project names"`
+	Columns  bool `help:"Show the columns of database projects, including FKs without indexes and differences between the database and the code."`
+	Coupling bool `help:"Show the coupling metrics (Ca, Ce, I, A and D) of each project or group."`
+	Vulns    bool `help:"Show the paths to libraries with vulnerabilities found by import osv."`
 }
 
 func (c *ShowCmd) Run(ctx *context) error {
@@ -25,17 +36,36 @@ func (c *ShowCmd) Run(ctx *context) error {
 		return err
 	}
 
-	c.print(projects, filter)
+	rootGrouping, grouping, err := c.createGrouping(projects, func(p *model.Project) string {
+		return p.LevelSimpleName(c.Levels)
+	})
+	if err != nil {
+		return err
+	}
+
+	c.print(projects, filter, rootGrouping, grouping)
 
 	return nil
 }
 
-func (c *ShowCmd) print(projects *model.Projects, filter filters.ProjectFilter) {
+func (c *ShowCmd) print(projects *model.Projects, filter filters.ProjectFilter,
+	rootGrouping func(*model.Project) string, grouping func(*model.Project) string,
+) {
 	ps := projects.ListProjects(model.FilterExcludeExternal)
 
-	tg := groupByGroups(ps, filter, false, func(p *model.Project) string {
-		return p.LevelSimpleName(c.Levels)
-	})
+	tg := groupByGroups(ps, filter, false, rootGrouping, grouping)
+
+	var coupling map[string]*model.Coupling
+	if c.Coupling {
+		coupling = c.loadCoupling(projects, ps, grouping)
+	}
+
+	var vulns map[string][]*osv.VulnerablePath
+	if c.Vulns {
+		vulns = lo.GroupBy(osv.FindVulnerablePaths(ps), func(v *osv.VulnerablePath) string {
+			return grouping(v.Path[0])
+		})
+	}
 
 	for _, rg := range tg.children {
 		c.println("", "Root", rg.name, rg.size.text())
@@ -48,6 +78,18 @@ func (c *ShowCmd) print(projects *model.Projects, filter filters.ProjectFilter)
 					c.println("      ", "depends on", dg.name, "")
 				}
 			}
+
+			if cp, ok := coupling[pg.name]; ok {
+				c.printCoupling(cp)
+			}
+
+			for _, v := range vulns[pg.name] {
+				c.printVulnerablePath(v)
+			}
+
+			if c.Columns && c.Levels == 0 && c.Components == "" {
+				c.printColumns(pg.proj)
+			}
 		}
 
 		fmt.Println()
@@ -58,6 +100,79 @@ func (c *ShowCmd) print(projects *model.Projects, filter filters.ProjectFilter)
 	}
 }
 
+func (c *ShowCmd) printColumns(proj *model.Project) {
+	issues := proj.ListColumnIssues()
+
+	for _, col := range proj.ListColumns() {
+		var flags []string
+		if col.PrimaryKey {
+			flags = append(flags, "PK")
+		}
+		if col.ForeignKey != "" {
+			flags = append(flags, "FK "+col.ForeignKey)
+		}
+		if col.InDatabase && !col.Nullable || !col.InDatabase && !col.CodeNullable {
+			flags = append(flags, "not null")
+		}
+		if len(col.Indexes) > 0 {
+			flags = append(flags, "indexes: "+strings.Join(col.Indexes, ", "))
+		}
+
+		colType := utils.IIf(col.InDatabase, col.Type, col.CodeType)
+
+		fmt.Printf("      column %v %v", col.Name, colType)
+		if len(flags) > 0 {
+			fmt.Printf(" [%v]", strings.Join(flags, ", "))
+		}
+		for _, issue := range issues[col] {
+			fmt.Printf(" (WARNING: %v)", issue)
+		}
+		fmt.Println()
+	}
+}
+
+func (c *ShowCmd) loadCoupling(projects *model.Projects, ps []*model.Project, grouping func(*model.Project) string,
+) map[string]*model.Coupling {
+	// Groups use the values stored by compute metrics, when they exist
+	if c.Components == "" {
+		result := map[string]*model.Coupling{}
+		for _, p := range ps {
+			name := grouping(p)
+			if cp := projects.GetGroupCoupling(c.Levels, name); cp != nil {
+				result[name] = cp
+			}
+		}
+
+		if len(result) > 0 {
+			return result
+		}
+	}
+
+	return metrics.ComputeCoupling(ps, grouping)
+}
+
+func (c *ShowCmd) printCoupling(cp *model.Coupling) {
+	ratio := func(v float64) string {
+		if v < 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.2f", v)
+	}
+
+	fmt.Printf("      coupling [Ca %v, Ce %v, I %v, A %v, D %v]\n",
+		cp.Afferent, cp.Efferent, ratio(cp.Instability), ratio(cp.Abstractness), ratio(cp.Distance))
+}
+
+func (c *ShowCmd) printVulnerablePath(v *osv.VulnerablePath) {
+	versions := v.Dependency.Versions.Slice()
+	sort.Strings(versions)
+
+	fmt.Printf("      vulnerable %v [%v]\n", v.PathText(), strings.Join(versions, ", "))
+	for _, vuln := range v.Vulns {
+		fmt.Printf("         %v: %v\n", vuln.ID, vuln.Description)
+	}
+}
+
 func (c *ShowCmd) println(prefix, category, name, size string) {
 	switch {
 	case c.Simple:
@@ -10,12 +10,14 @@ import (
 	"github.com/pescuma/archer/lib/utils"
 )
 
-func groupByGroups(ps []*model.Project, filter filters.ProjectFilter, forceShowDependentProjects bool, projGrouping func(project *model.Project) string) *group {
+func groupByGroups(ps []*model.Project, filter filters.ProjectFilter, forceShowDependentProjects bool,
+	rootGrouping func(project *model.Project) string, projGrouping func(project *model.Project) string,
+) *group {
 	show := computeNodesShow(ps, filter, forceShowDependentProjects)
 
 	ps = lo.Filter(ps, func(p *model.Project, _ int) bool { return show[p.Name] })
 
-	gs := lo.GroupBy(ps, func(p *model.Project) string { return p.FullGroup() })
+	gs := lo.GroupBy(ps, rootGrouping)
 
 	keys := lo.Keys(gs)
 	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
@@ -69,7 +71,7 @@ func groupByGroups(ps []*model.Project, filter filters.ProjectFilter, forceShowD
 				if !ok {
 					dg = &group{
 						category: DependencyCategory,
-						name:     utils.IIf(p.FullGroup() == d.Target.FullGroup(), dgn, dgfn),
+						name:     utils.IIf(rootGrouping(p) == rootGrouping(d.Target), dgn, dgfn),
 						fullName: dgfn,
 						dep:      d,
 					}
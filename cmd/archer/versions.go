@@ -0,0 +1,53 @@
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages"
+	"github.com/pescuma/archer/lib/versions"
+)
+
+type VersionsCmd struct {
+	Format string `short:"f" default:"text" enum:"text,json" help:"Output format: text or json."`
+	Output string `short:"o" help:"Output file to write. Default is stdout." type:"path"`
+}
+
+func (c *VersionsCmd) Run(ctx *context) error {
+	return ctx.ws.Execute(func(console consoles.Console, storage storages.Storage) error {
+		projects, err := storage.LoadProjects()
+		if err != nil {
+			return err
+		}
+
+		repos, err := storage.LoadRepositories()
+		if err != nil {
+			return err
+		}
+
+		cs := versions.FindConflicts(projects.ListProjects(model.FilterAll))
+
+		var w io.Writer = os.Stdout
+		if c.Output != "" {
+			f, err := os.Create(c.Output)
+			if err != nil {
+				return err
+			}
+
+			defer func() {
+				_ = f.Close()
+			}()
+
+			w = f
+		}
+
+		switch c.Format {
+		case "json":
+			return versions.WriteJSON(w, cs, repos)
+		default:
+			return versions.WriteText(w, cs, repos)
+		}
+	})
+}
@@ -0,0 +1,235 @@
+package affected
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/impact"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages"
+	"github.com/pescuma/archer/lib/utils"
+)
+
+type Finder struct {
+	console consoles.Console
+	storage storages.Storage
+}
+
+type Options struct {
+	// Dir inside the git repository. Paths in patches are relative to its root.
+	Dir   string
+	From  string
+	To    string
+	Patch string
+	// MaxDepth of dependents to consider. 0 means no limit.
+	MaxDepth int
+	// MaxPeople is the number of people to list per project
+	MaxPeople int
+}
+
+type Result struct {
+	Files []string
+	// UnknownFiles are the changed files that are not part of any imported project
+	UnknownFiles []string
+	Projects     []*Project
+}
+
+type Project struct {
+	Project *model.Project
+	// Depth is 0 for projects with changed files
+	Depth int
+	// Path goes from Project to the project with changed files
+	Path         []*model.Project
+	ProductAreas []string
+	People       []*Person
+}
+
+type Person struct {
+	Person *model.Person
+	// Lines is the number of code lines in the project blamed to the person
+	Lines int
+}
+
+func NewFinder(console consoles.Console, storage storages.Storage) *Finder {
+	return &Finder{
+		console: console,
+		storage: storage,
+	}
+}
+
+func (f *Finder) Find(opts *Options) (*Result, error) {
+	root, paths, err := f.listChangedFiles(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	projectsDB, err := f.storage.LoadProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	filesDB, err := f.storage.LoadFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	peopleDB, err := f.storage.LoadPeople()
+	if err != nil {
+		return nil, err
+	}
+
+	blames, err := f.storage.QueryBlamePerAuthor()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, p := range paths {
+		paths[i] = filepath.Join(root, p)
+	}
+
+	return computeAffected(projectsDB, filesDB, peopleDB, blames, paths, opts), nil
+}
+
+func (f *Finder) listChangedFiles(opts *Options) (string, []string, error) {
+	dir, err := utils.PathAbs(opts.Dir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if opts.Patch == "" {
+		return listGitChanges(dir, opts.From, opts.To)
+	}
+
+	file, err := os.Open(opts.Patch)
+	if err != nil {
+		return "", nil, err
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	paths, err := parsePatch(file)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return findGitRoot(dir), paths, nil
+}
+
+func computeAffected(projectsDB *model.Projects, filesDB *model.Files, peopleDB *model.People,
+	blames []*storages.BlamePerAuthor, paths []string, opts *Options,
+) *Result {
+	result := &Result{
+		Files: paths,
+	}
+
+	var changed []*model.Project
+	seen := map[model.ID]bool{}
+	for _, path := range paths {
+		file := filesDB.Get(path)
+		if file == nil || file.ProjectID == nil {
+			result.UnknownFiles = append(result.UnknownFiles, path)
+			continue
+		}
+
+		if !seen[*file.ProjectID] {
+			seen[*file.ProjectID] = true
+			changed = append(changed, projectsDB.GetByID(*file.ProjectID))
+		}
+	}
+
+	sort.Slice(changed, func(i, j int) bool { return changed[i].Name < changed[j].Name })
+
+	for _, p := range changed {
+		result.Projects = append(result.Projects, &Project{
+			Project: p,
+			Path:    []*model.Project{p},
+		})
+	}
+
+	ds := impact.FindDependents(projectsDB.ListProjects(model.FilterAll), changed, opts.MaxDepth)
+	for _, d := range ds {
+		result.Projects = append(result.Projects, &Project{
+			Project: d.Project,
+			Depth:   d.Depth,
+			Path:    d.Path,
+		})
+	}
+
+	lines := map[model.ID]map[model.ID]int{}
+	for _, b := range blames {
+		if b.LineType != model.CodeFileLine {
+			continue
+		}
+
+		file := filesDB.GetByID(b.FileID)
+		if file == nil || file.ProjectID == nil {
+			continue
+		}
+
+		ls, ok := lines[*file.ProjectID]
+		if !ok {
+			ls = map[model.ID]int{}
+			lines[*file.ProjectID] = ls
+		}
+		ls[b.AuthorID] += b.Lines
+	}
+
+	for _, p := range result.Projects {
+		p.ProductAreas = listProductAreas(filesDB.ListByProject(p.Project), peopleDB)
+		p.People = listPeople(lines[p.Project.ID], peopleDB, opts.MaxPeople)
+	}
+
+	return result
+}
+
+func listProductAreas(files []*model.File, peopleDB *model.People) []string {
+	count := map[string]int{}
+	for _, f := range files {
+		if f.ProductAreaID != nil {
+			count[peopleDB.GetProductAreaByID(*f.ProductAreaID).Name]++
+		}
+	}
+
+	result := make([]string, 0, len(count))
+	for n := range count {
+		result = append(result, n)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if count[result[i]] != count[result[j]] {
+			return count[result[i]] > count[result[j]]
+		}
+		return result[i] < result[j]
+	})
+
+	return result
+}
+
+func listPeople(lines map[model.ID]int, peopleDB *model.People, maxPeople int) []*Person {
+	result := make([]*Person, 0, len(lines))
+	for id, l := range lines {
+		if l > 0 {
+			result = append(result, &Person{
+				Person: peopleDB.GetPersonByID(id),
+				Lines:  l,
+			})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Lines != result[j].Lines {
+			return result[i].Lines > result[j].Lines
+		}
+		return result[i].Person.Name < result[j].Person.Name
+	})
+
+	if maxPeople > 0 && len(result) > maxPeople {
+		result = result[:maxPeople]
+	}
+
+	return result
+}
@@ -0,0 +1,156 @@
+package affected
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages"
+)
+
+func TestParsePatch(t *testing.T) {
+	t.Parallel()
+
+	patch := `diff --git a/core/a.go b/core/a.go
+index 1..2 100644
+--- a/core/a.go
++++ b/core/a.go
+@@ -1 +1 @@
+-x
++y
+diff --git a/old.txt b/new.txt
+--- a/old.txt
++++ b/new.txt
+diff --git a/app/b.go b/app/b.go
+new file mode 100644
+--- /dev/null
++++ b/app/b.go
+@@ -0,0 +1 @@
++z
+`
+
+	paths, err := parsePatch(strings.NewReader(patch))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"app/b.go", "core/a.go", "new.txt", "old.txt"}, paths)
+}
+
+func TestParsePatchRename(t *testing.T) {
+	t.Parallel()
+
+	patch := `diff --git a/core/old.go b/core/new.go
+similarity index 100%
+rename from core/old.go
+rename to core/new.go
+`
+
+	paths, err := parsePatch(strings.NewReader(patch))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"core/new.go", "core/old.go"}, paths)
+}
+
+func TestParsePatchBinary(t *testing.T) {
+	t.Parallel()
+
+	patch := `diff --git a/img/logo.png b/img/logo.png
+new file mode 100644
+index 0000000..1111111
+Binary files /dev/null and b/img/logo.png differ
+`
+
+	paths, err := parsePatch(strings.NewReader(patch))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"img/logo.png"}, paths)
+
+	paths, err = parsePatch(strings.NewReader("Binary files a/x.bin and b/x.bin differ\n"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"x.bin"}, paths)
+}
+
+func TestParsePatchModeChange(t *testing.T) {
+	t.Parallel()
+
+	patch := `diff --git a/scripts/run.sh b/scripts/run.sh
+old mode 100644
+new mode 100755
+`
+
+	paths, err := parsePatch(strings.NewReader(patch))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"scripts/run.sh"}, paths)
+}
+
+func TestParsePatchSpecialNames(t *testing.T) {
+	t.Parallel()
+
+	patch := `diff --git a/docs/read me.md b/docs/read me.md
+old mode 100644
+new mode 100755
+diff --git "a/docs/t\303\251st.md" "b/docs/t\303\251st.md"
+old mode 100644
+new mode 100755
+diff --git a/a/x.go b/a/x.go
+deleted file mode 100644
+`
+
+	paths, err := parsePatch(strings.NewReader(patch))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a/x.go", "docs/read me.md", "docs/tést.md"}, paths)
+}
+
+func TestComputeAffected(t *testing.T) {
+	t.Parallel()
+
+	projs := model.NewProjects()
+	core := projs.GetOrCreate("core")
+	core.Type = model.CodeType
+	app := projs.GetOrCreate("app")
+	app.Type = model.CodeType
+	app.GetOrCreateDependency(core)
+	projs.GetOrCreate("other").Type = model.CodeType
+
+	people := model.NewPeople()
+	ann := people.GetOrCreatePerson(nil)
+	ann.Name = "Ann"
+	bob := people.GetOrCreatePerson(nil)
+	bob.Name = "Bob"
+	billing := people.GetOrCreateProductArea("billing")
+
+	files := model.NewFiles()
+	a := files.GetOrCreate("/r/core/a.go")
+	a.ProjectID = &core.ID
+	a.ProductAreaID = &billing.ID
+	b := files.GetOrCreate("/r/app/b.go")
+	b.ProjectID = &app.ID
+
+	blames := []*storages.BlamePerAuthor{
+		{AuthorID: ann.ID, FileID: a.ID, LineType: model.CodeFileLine, Lines: 10},
+		{AuthorID: bob.ID, FileID: a.ID, LineType: model.CodeFileLine, Lines: 20},
+		{AuthorID: ann.ID, FileID: a.ID, LineType: model.BlankFileLine, Lines: 100},
+		{AuthorID: ann.ID, FileID: b.ID, LineType: model.CodeFileLine, Lines: 5},
+	}
+
+	r := computeAffected(projs, files, people, blames, []string{"/r/core/a.go", "/r/README.md"}, &Options{MaxPeople: 1})
+
+	assert.Equal(t, []string{"/r/README.md"}, r.UnknownFiles)
+	assert.Equal(t, 2, len(r.Projects))
+
+	assert.Equal(t, core, r.Projects[0].Project)
+	assert.Equal(t, 0, r.Projects[0].Depth)
+	assert.Equal(t, []string{"billing"}, r.Projects[0].ProductAreas)
+	assert.Equal(t, 1, len(r.Projects[0].People))
+	assert.Equal(t, bob, r.Projects[0].People[0].Person)
+	assert.Equal(t, 20, r.Projects[0].People[0].Lines)
+
+	assert.Equal(t, app, r.Projects[1].Project)
+	assert.Equal(t, 1, r.Projects[1].Depth)
+	assert.Equal(t, []string{"app", "core"}, projectNames(r.Projects[1].Path))
+	assert.Equal(t, ann, r.Projects[1].People[0].Person)
+}
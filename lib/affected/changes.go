@@ -0,0 +1,209 @@
+package affected
+
+import (
+	"bufio"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/hashicorp/go-set/v2"
+	"github.com/pkg/errors"
+)
+
+// listGitChanges returns the root dir of the repository and the paths, relative to it, changed between the revisions
+func listGitChanges(dir, from, to string) (string, []string, error) {
+	gitRepo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return "", nil, err
+	}
+
+	wt, err := gitRepo.Worktree()
+	if err != nil {
+		return "", nil, err
+	}
+
+	fromTree, err := resolveTree(gitRepo, from)
+	if err != nil {
+		return "", nil, err
+	}
+
+	toTree, err := resolveTree(gitRepo, to)
+	if err != nil {
+		return "", nil, err
+	}
+
+	changes, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		return "", nil, err
+	}
+
+	paths := set.New[string](len(changes))
+	for _, c := range changes {
+		if c.From.Name != "" {
+			paths.Insert(c.From.Name)
+		}
+		if c.To.Name != "" {
+			paths.Insert(c.To.Name)
+		}
+	}
+
+	return wt.Filesystem.Root(), sortedPaths(paths), nil
+}
+
+// findGitRoot returns the root dir of the git repository that contains dir, or dir itself if it is not inside one
+func findGitRoot(dir string) string {
+	gitRepo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return dir
+	}
+
+	wt, err := gitRepo.Worktree()
+	if err != nil {
+		return dir
+	}
+
+	return wt.Filesystem.Root()
+}
+
+func resolveTree(gitRepo *git.Repository, revision string) (*object.Tree, error) {
+	hash, err := gitRepo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error resolving revision %v", revision)
+	}
+
+	commit, err := gitRepo.CommitObject(*hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return commit.Tree()
+}
+
+// parsePatch returns the paths changed in a unified diff, as generated by git diff. Besides the ---/+++ lines, the
+// git headers are used, so renames, mode changes and binary files are also found.
+func parsePatch(r io.Reader) ([]string, error) {
+	paths := set.New[string](100)
+
+	add := func(ps ...string) {
+		for _, p := range ps {
+			if p != "" {
+				paths.Insert(p)
+			}
+		}
+	}
+
+	prev := ""
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			from, to := splitGitDiffHeader(line[len("diff --git "):])
+			add(cleanPatchPath(from, true), cleanPatchPath(to, true))
+
+		case strings.HasPrefix(line, "rename from "), strings.HasPrefix(line, "copy from "):
+			add(cleanPatchPath(line[strings.Index(line, " from ")+len(" from "):], false))
+
+		case strings.HasPrefix(line, "rename to "), strings.HasPrefix(line, "copy to "):
+			add(cleanPatchPath(line[strings.Index(line, " to ")+len(" to "):], false))
+
+		case strings.HasPrefix(line, "Binary files ") && strings.HasSuffix(line, " differ"):
+			files := strings.TrimSuffix(strings.TrimPrefix(line, "Binary files "), " differ")
+			if from, to, ok := strings.Cut(files, " and "); ok {
+				add(cleanPatchPath(from, true), cleanPatchPath(to, true))
+			}
+
+		case strings.HasPrefix(line, "+++ ") && strings.HasPrefix(prev, "--- "):
+			add(cleanPatchPath(prev[4:], true), cleanPatchPath(line[4:], true))
+		}
+
+		prev = line
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return sortedPaths(paths), nil
+}
+
+// splitGitDiffHeader splits the "a/X b/Y" part of a diff --git line. Paths with special chars are quoted, but paths
+// with spaces are not, so when both are unquoted the header is split where both halves have the same name.
+func splitGitDiffHeader(header string) (string, string) {
+	if strings.HasPrefix(header, `"`) {
+		if end := closingQuote(header); end > 0 {
+			return header[:end+1], strings.TrimSpace(header[end+1:])
+		}
+	}
+
+	if strings.HasSuffix(header, `"`) {
+		if i := strings.LastIndex(header, ` "`); i >= 0 {
+			return header[:i], header[i+1:]
+		}
+	}
+
+	if len(header)%2 == 1 {
+		half := len(header) / 2
+		from, to := header[:half], header[half+1:]
+		if header[half] == ' ' && len(from) > 2 && from[2:] == to[min(2, len(to)):] {
+			return from, to
+		}
+	}
+
+	if i := strings.Index(header, " b/"); i >= 0 {
+		return header[:i], header[i+1:]
+	}
+
+	return header, ""
+}
+
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// cleanPatchPath unquotes the path and removes the a/ and b/ prefixes, if the path has them
+func cleanPatchPath(p string, prefixed bool) string {
+	if i := strings.Index(p, "\t"); i >= 0 {
+		p = p[:i]
+	}
+
+	p = strings.TrimSpace(p)
+
+	if strings.HasPrefix(p, `"`) {
+		if u, err := strconv.Unquote(p); err == nil {
+			p = u
+		} else {
+			p = strings.Trim(p, `"`)
+		}
+	}
+
+	if p == "" || p == "/dev/null" {
+		return ""
+	}
+
+	if prefixed && (strings.HasPrefix(p, "a/") || strings.HasPrefix(p, "b/")) {
+		p = p[2:]
+	}
+
+	return filepath.FromSlash(p)
+}
+
+func sortedPaths(paths *set.Set[string]) []string {
+	result := paths.Slice()
+	sort.Strings(result)
+	return result
+}
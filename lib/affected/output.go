@@ -0,0 +1,99 @@
+package affected
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+func (r *Result) WriteText(w io.Writer) error {
+	_, _ = fmt.Fprintf(w, "%v changed files, %v not in any project\n", len(r.Files), len(r.UnknownFiles))
+
+	if len(r.Projects) == 0 {
+		_, err := fmt.Fprintf(w, "No projects affected\n")
+		return err
+	}
+
+	_, _ = fmt.Fprintf(w, "Affected projects:\n")
+	for _, p := range r.Projects {
+		_, _ = fmt.Fprintf(w, "   [%v] %v", p.Depth, p.Project.Name)
+		if p.Depth > 0 {
+			_, _ = fmt.Fprintf(w, " (%v)", strings.Join(projectNames(p.Path), " -> "))
+		}
+		_, _ = fmt.Fprintln(w)
+
+		if len(p.ProductAreas) > 0 {
+			_, _ = fmt.Fprintf(w, "      product areas: %v\n", strings.Join(p.ProductAreas, ", "))
+		}
+		if len(p.People) > 0 {
+			people := lo.Map(p.People, func(i *Person, _ int) string {
+				return fmt.Sprintf("%v (%v lines)", i.Person.Name, i.Lines)
+			})
+			_, _ = fmt.Fprintf(w, "      people: %v\n", strings.Join(people, ", "))
+		}
+	}
+
+	return nil
+}
+
+// WriteNames writes only the names of the affected projects, one per line
+func (r *Result) WriteNames(w io.Writer) error {
+	for _, p := range r.Projects {
+		_, err := fmt.Fprintln(w, p.Project.Name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Result) WriteJSON(w io.Writer) error {
+	type jsonPerson struct {
+		Name  string `json:"name"`
+		Lines int    `json:"lines"`
+	}
+	type jsonProject struct {
+		Name         string       `json:"name"`
+		Depth        int          `json:"depth"`
+		Path         []string     `json:"path"`
+		ProductAreas []string     `json:"productAreas"`
+		People       []jsonPerson `json:"people"`
+	}
+	type jsonResult struct {
+		Files        []string      `json:"files"`
+		UnknownFiles []string      `json:"unknownFiles"`
+		Projects     []jsonProject `json:"projects"`
+	}
+
+	out := jsonResult{
+		Files:        lo.Ternary(r.Files == nil, []string{}, r.Files),
+		UnknownFiles: lo.Ternary(r.UnknownFiles == nil, []string{}, r.UnknownFiles),
+		Projects: lo.Map(r.Projects, func(p *Project, _ int) jsonProject {
+			return jsonProject{
+				Name:         p.Project.Name,
+				Depth:        p.Depth,
+				Path:         projectNames(p.Path),
+				ProductAreas: p.ProductAreas,
+				People: lo.Map(p.People, func(i *Person, _ int) jsonPerson {
+					return jsonPerson{
+						Name:  i.Person.Name,
+						Lines: i.Lines,
+					}
+				}),
+			}
+		}),
+	}
+
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(out)
+}
+
+func projectNames(ps []*model.Project) []string {
+	return lo.Map(ps, func(p *model.Project, _ int) string { return p.Name })
+}
@@ -0,0 +1,109 @@
+package branches
+
+import (
+	"sort"
+
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+// Comparison lists what is only in one of the branches of a repository
+type Comparison struct {
+	Repository *model.Repository
+	A          *Side
+	B          *Side
+}
+
+type Side struct {
+	Branch   string
+	Commits  []*model.RepositoryCommit
+	Files    []*model.File
+	Projects []*model.Project
+	People   []*model.Person
+}
+
+// Compare lists the commits that are in only one of the branches, with the files, projects and
+// people of these commits. Only repositories where at least one of the branches was imported are returned.
+func Compare(repos []*model.Repository, a string, b string,
+	files *model.Files, projects *model.Projects, people *model.People,
+) []*Comparison {
+	var result []*Comparison
+
+	for _, repo := range repos {
+		var onlyA, onlyB []*model.RepositoryCommit
+		found := false
+
+		for _, c := range repo.ListCommits() {
+			inA := lo.Contains(c.Branches, a)
+			inB := lo.Contains(c.Branches, b)
+			found = found || inA || inB
+
+			switch {
+			case inA && !inB:
+				onlyA = append(onlyA, c)
+			case inB && !inA:
+				onlyB = append(onlyB, c)
+			}
+		}
+
+		if !found {
+			continue
+		}
+
+		result = append(result, &Comparison{
+			Repository: repo,
+			A:          newSide(a, onlyA, files, projects, people),
+			B:          newSide(b, onlyB, files, projects, people),
+		})
+	}
+
+	return result
+}
+
+func newSide(branch string, commits []*model.RepositoryCommit,
+	files *model.Files, projects *model.Projects, people *model.People,
+) *Side {
+	sort.Slice(commits, func(i, j int) bool {
+		return commits[i].Date.After(commits[j].Date)
+	})
+
+	fs := map[model.ID]*model.File{}
+	ps := map[model.ID]*model.Project{}
+	as := map[model.ID]*model.Person{}
+
+	for _, c := range commits {
+		for _, a := range c.AuthorIDs {
+			if p := people.GetPersonByID(a); p != nil {
+				as[a] = p
+			}
+		}
+
+		for fileID := range c.Files {
+			f := files.GetByID(fileID)
+			if f == nil {
+				continue
+			}
+
+			fs[f.ID] = f
+
+			if f.ProjectID != nil {
+				ps[*f.ProjectID] = projects.GetByID(*f.ProjectID)
+			}
+		}
+	}
+
+	result := &Side{
+		Branch:   branch,
+		Commits:  commits,
+		Files:    lo.Values(fs),
+		Projects: lo.Values(ps),
+		People:   lo.Values(as),
+	}
+
+	sort.Slice(result.Files, func(i, j int) bool { return result.Files[i].Path < result.Files[j].Path })
+	sort.Slice(result.Projects, func(i, j int) bool { return result.Projects[i].Name < result.Projects[j].Name })
+	sort.Slice(result.People, func(i, j int) bool { return result.People[i].Name < result.People[j].Name })
+
+	return result
+}
@@ -0,0 +1,90 @@
+package branches
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	repos := model.NewRepositories()
+	repo := repos.GetOrCreate("/repo")
+	other := repos.GetOrCreate("/other")
+	files := model.NewFiles()
+	projects := model.NewProjects()
+	people := model.NewPeople()
+
+	api := projects.GetOrCreate("api")
+	impl := projects.GetOrCreate("impl")
+
+	apiFile := files.GetOrCreate("/repo/api/a.go")
+	apiFile.ProjectID = &api.ID
+	implFile := files.GetOrCreate("/repo/impl/b.go")
+	implFile.ProjectID = &impl.ID
+
+	alice := people.GetOrCreatePerson(nil)
+	alice.Name = "alice"
+	bob := people.GetOrCreatePerson(nil)
+	bob.Name = "bob"
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	commit := func(r *model.Repository, hash string, day int, author *model.Person, file *model.File, branches ...string) {
+		c := r.GetOrCreateCommit(hash)
+		c.Date = start.AddDate(0, 0, day)
+		c.AuthorIDs = []model.ID{author.ID}
+		c.Files[file.ID] = model.NewRepositoryCommitFile(file.ID)
+		c.Branches = branches
+	}
+
+	commit(repo, "1", 0, alice, apiFile, "main", "release/1")
+	commit(repo, "2", 1, alice, apiFile, "main")
+	commit(repo, "3", 2, alice, apiFile, "main")
+	commit(repo, "4", 3, bob, implFile, "release/1")
+	commit(other, "5", 0, bob, implFile, "main")
+
+	cs := Compare(repos.List(), "main", "release/1", files, projects, people)
+
+	assert.Equal(t, 2, len(cs))
+
+	c := cs[0]
+	if c.Repository != repo {
+		c = cs[1]
+	}
+
+	assert.Equal(t, "main", c.A.Branch)
+	assert.Equal(t, []string{"3", "2"}, hashes(c.A.Commits))
+	assert.Equal(t, []*model.File{apiFile}, c.A.Files)
+	assert.Equal(t, []*model.Project{api}, c.A.Projects)
+	assert.Equal(t, []*model.Person{alice}, c.A.People)
+
+	assert.Equal(t, "release/1", c.B.Branch)
+	assert.Equal(t, []string{"4"}, hashes(c.B.Commits))
+	assert.Equal(t, []*model.File{implFile}, c.B.Files)
+	assert.Equal(t, []*model.Project{impl}, c.B.Projects)
+	assert.Equal(t, []*model.Person{bob}, c.B.People)
+}
+
+func TestCompareSkipsReposWithoutBranches(t *testing.T) {
+	t.Parallel()
+
+	repos := model.NewRepositories()
+	repo := repos.GetOrCreate("/repo")
+	repo.GetOrCreateCommit("1").Branches = []string{"main"}
+
+	cs := Compare(repos.List(), "develop", "release/1", model.NewFiles(), model.NewProjects(), model.NewPeople())
+
+	assert.Equal(t, 0, len(cs))
+}
+
+func hashes(cs []*model.RepositoryCommit) []string {
+	result := make([]string, len(cs))
+	for i, c := range cs {
+		result[i] = c.Hash
+	}
+	return result
+}
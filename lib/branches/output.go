@@ -0,0 +1,126 @@
+package branches
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+func WriteText(w io.Writer, cs []*Comparison, people *model.People) error {
+	if len(cs) == 0 {
+		_, err := fmt.Fprintf(w, "No repository with the branches imported found. Use 'archer import git history --branches'.\n")
+		return err
+	}
+
+	for _, c := range cs {
+		_, _ = fmt.Fprintf(w, "%v: %v commits only in %v, %v commits only in %v\n",
+			c.Repository.Name, len(c.A.Commits), c.A.Branch, len(c.B.Commits), c.B.Branch)
+
+		for _, s := range []*Side{c.A, c.B} {
+			if len(s.Commits) == 0 {
+				continue
+			}
+
+			_, _ = fmt.Fprintf(w, "   Only in %v:\n", s.Branch)
+
+			_, _ = fmt.Fprintf(w, "      Commits (%v):\n", len(s.Commits))
+			for _, commit := range s.Commits {
+				_, _ = fmt.Fprintf(w, "         %v %v %v: %v\n", shortHash(commit.Hash), commit.Date.Format("2006-01-02"),
+					authorName(commit, people), firstLine(commit.Message))
+			}
+
+			_, _ = fmt.Fprintf(w, "      Files (%v):\n", len(s.Files))
+			for _, f := range s.Files {
+				_, _ = fmt.Fprintf(w, "         %v\n", relativePath(c.Repository, f))
+			}
+
+			if len(s.Projects) > 0 {
+				_, _ = fmt.Fprintf(w, "      Projects: %v\n",
+					strings.Join(lo.Map(s.Projects, func(p *model.Project, _ int) string { return p.Name }), ", "))
+			}
+
+			if len(s.People) > 0 {
+				_, _ = fmt.Fprintf(w, "      People: %v\n",
+					strings.Join(lo.Map(s.People, func(p *model.Person, _ int) string { return p.Name }), ", "))
+			}
+		}
+	}
+
+	return nil
+}
+
+func ToJSON(cs []*Comparison, people *model.People) []map[string]any {
+	result := make([]map[string]any, 0, len(cs))
+
+	for _, c := range cs {
+		result = append(result, map[string]any{
+			"repo": map[string]any{
+				"id":   c.Repository.ID,
+				"name": c.Repository.Name,
+			},
+			"a": sideToJSON(c.Repository, c.A, people),
+			"b": sideToJSON(c.Repository, c.B, people),
+		})
+	}
+
+	return result
+}
+
+func sideToJSON(repo *model.Repository, s *Side, people *model.People) map[string]any {
+	return map[string]any{
+		"branch": s.Branch,
+		"commits": lo.Map(s.Commits, func(c *model.RepositoryCommit, _ int) map[string]any {
+			return map[string]any{
+				"hash":    c.Hash,
+				"date":    c.Date,
+				"author":  authorName(c, people),
+				"message": firstLine(c.Message),
+			}
+		}),
+		"files":    lo.Map(s.Files, func(f *model.File, _ int) string { return relativePath(repo, f) }),
+		"projects": lo.Map(s.Projects, func(p *model.Project, _ int) string { return p.Name }),
+		"people":   lo.Map(s.People, func(p *model.Person, _ int) string { return p.Name }),
+	}
+}
+
+func WriteJSON(w io.Writer, cs []*Comparison, people *model.People) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(ToJSON(cs, people))
+}
+
+func authorName(c *model.RepositoryCommit, people *model.People) string {
+	if len(c.AuthorIDs) == 0 {
+		return ""
+	}
+
+	p := people.GetPersonByID(c.AuthorIDs[0])
+	if p == nil {
+		return ""
+	}
+
+	return p.Name
+}
+
+func shortHash(hash string) string {
+	return hash[:min(len(hash), 8)]
+}
+
+func firstLine(message string) string {
+	return strings.TrimSpace(strings.SplitN(message, "\n", 2)[0])
+}
+
+func relativePath(repo *model.Repository, f *model.File) string {
+	rel, err := filepath.Rel(repo.RootDir, f.Path)
+	if err != nil {
+		return f.Path
+	}
+
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,232 @@
+package components
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pescuma/archer/lib/filters"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/utils"
+)
+
+// Config has the logical components and layers, each one defined by a project query. The file has one
+// definition per line, in the format `<name> = <project query>`, optionally inside [components] and [layers]
+// sections. Lines before any section are components. The first matching definition is used.
+type Config struct {
+	Components []*Definition
+	Layers     []*Definition
+}
+
+type Definition struct {
+	Name string
+	Rule string
+
+	filter filters.ProjectFilter
+}
+
+func Load(path string, projs *model.Projects) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := Parse(string(content), projs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error parsing %v", path)
+	}
+
+	return result, nil
+}
+
+func Parse(content string, projs *model.Projects) (*Config, error) {
+	result := &Config{}
+	target := &result.Components
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for i := 1; scanner.Scan(); i++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+
+		case line == "[components]":
+			target = &result.Components
+			continue
+
+		case line == "[layers]":
+			target = &result.Layers
+			continue
+		}
+
+		name, rule, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		rule = strings.TrimSpace(rule)
+		if !ok || name == "" || rule == "" {
+			return nil, errors.Errorf("line %v: expected <name> = <project query>, got: %v", i, line)
+		}
+
+		filter, err := filters.ParseProjectFilter(projs, rule)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %v", i)
+		}
+
+		*target = append(*target, &Definition{
+			Name:   name,
+			Rule:   rule,
+			filter: filter,
+		})
+	}
+
+	return result, scanner.Err()
+}
+
+// GetComponent returns the name of the component of the project, or the project name if it is not part of any
+func (c *Config) GetComponent(p *model.Project) string {
+	if d := find(c.Components, p); d != nil {
+		return d.Name
+	}
+	return p.Name
+}
+
+// GetLayer returns the name of the layer of the project, or empty if it is not part of any
+func (c *Config) GetLayer(p *model.Project) string {
+	if d := find(c.Layers, p); d != nil {
+		return d.Name
+	}
+	return ""
+}
+
+func find(ds []*Definition, p *model.Project) *Definition {
+	for _, d := range ds {
+		if d.filter.FilterProject(p) {
+			return d
+		}
+	}
+	return nil
+}
+
+// Component aggregates the information of the projects that are part of it
+type Component struct {
+	Name         string
+	Layer        string
+	Projects     []*model.Project
+	Size         *model.Size
+	Changes      *model.Changes
+	Metrics      *model.Metrics
+	Dependencies map[string]*Dependency
+}
+
+// Dependency aggregates the project dependencies between two components
+type Dependency struct {
+	Source       *Component
+	Target       *Component
+	Dependencies []*model.ProjectDependency
+}
+
+// CheckLayers returns an error if the projects of a component are in different layers
+func (c *Config) CheckLayers(ps []*model.Project) error {
+	layers := map[string]map[string][]string{}
+	for _, p := range ps {
+		name := c.GetComponent(p)
+
+		ls, ok := layers[name]
+		if !ok {
+			ls = map[string][]string{}
+			layers[name] = ls
+		}
+
+		l := c.GetLayer(p)
+		ls[l] = append(ls[l], p.Name)
+	}
+
+	var msgs []string
+	for name, ls := range layers {
+		if len(ls) < 2 {
+			continue
+		}
+
+		var parts []string
+		for l, projs := range ls {
+			parts = append(parts, fmt.Sprintf("%v (%v)", utils.IIf(l == "", "no layer", l), strings.Join(projs, ", ")))
+		}
+		sort.Strings(parts)
+
+		msgs = append(msgs, fmt.Sprintf("component %v has projects in different layers: %v", name, strings.Join(parts, "; ")))
+	}
+
+	if len(msgs) > 0 {
+		sort.Strings(msgs)
+		return errors.New(strings.Join(msgs, "\n"))
+	}
+
+	return nil
+}
+
+// Aggregate groups the projects in components. Dependencies to projects not in ps are ignored. All the projects of a
+// component must be in the same layer.
+func (c *Config) Aggregate(ps []*model.Project) ([]*Component, error) {
+	err := c.CheckLayers(ps)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]*Component{}
+	byProject := map[*model.Project]*Component{}
+
+	var result []*Component
+	for _, p := range ps {
+		name := c.GetComponent(p)
+
+		comp, ok := byName[name]
+		if !ok {
+			comp = &Component{
+				Name:         name,
+				Layer:        c.GetLayer(p),
+				Size:         model.NewSize(),
+				Changes:      model.NewChanges(),
+				Metrics:      model.NewMetrics(),
+				Dependencies: map[string]*Dependency{},
+			}
+			byName[name] = comp
+			result = append(result, comp)
+		}
+
+		comp.Projects = append(comp.Projects, p)
+		comp.Size.Add(p.Size)
+		comp.Changes.Add(p.Changes)
+		comp.Metrics.Add(p.Metrics)
+		byProject[p] = comp
+	}
+
+	for _, p := range ps {
+		source := byProject[p]
+
+		for _, d := range p.ListDependencies(model.FilterAll) {
+			target, ok := byProject[d.Target]
+			if !ok || target == source {
+				continue
+			}
+
+			dep, ok := source.Dependencies[target.Name]
+			if !ok {
+				dep = &Dependency{
+					Source: source,
+					Target: target,
+				}
+				source.Dependencies[target.Name] = dep
+			}
+
+			dep.Dependencies = append(dep.Dependencies, d)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result, nil
+}
@@ -0,0 +1,114 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	projs := model.NewProjects()
+
+	cfg, err := Parse(`
+# comment
+billing = billing-*|invoice-*
+
+[layers]
+web = *-web
+`, projs)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(cfg.Components))
+	assert.Equal(t, "billing", cfg.Components[0].Name)
+	assert.Equal(t, "billing-*|invoice-*", cfg.Components[0].Rule)
+	assert.Equal(t, 1, len(cfg.Layers))
+	assert.Equal(t, "web", cfg.Layers[0].Name)
+
+	_, err = Parse("billing", projs)
+	assert.NotNil(t, err)
+}
+
+func TestAggregate(t *testing.T) {
+	t.Parallel()
+
+	projs := model.NewProjects()
+
+	add := func(from, to string) {
+		f := projs.GetOrCreate(from)
+		f.Type = model.CodeType
+		tp := projs.GetOrCreate(to)
+		tp.Type = model.CodeType
+		f.GetOrCreateDependency(tp)
+	}
+
+	add("billing-web", "billing-core")
+	add("billing-core", "invoice-api")
+	add("billing-core", "accounts")
+	add("invoice-api", "accounts")
+	projs.GetOrCreate("billing-core").Size.Lines = 10
+	projs.GetOrCreate("invoice-api").Size.Lines = 5
+	projs.GetOrCreate("invoice-api").Changes.Total = 3
+
+	cfg, err := Parse(`
+billing = billing-*|invoice-*
+
+[layers]
+finance = billing-*|invoice-*
+`, projs)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "billing", cfg.GetComponent(projs.GetOrCreate("invoice-api")))
+	assert.Equal(t, "accounts", cfg.GetComponent(projs.GetOrCreate("accounts")))
+	assert.Equal(t, "finance", cfg.GetLayer(projs.GetOrCreate("billing-web")))
+	assert.Equal(t, "", cfg.GetLayer(projs.GetOrCreate("accounts")))
+
+	cs, err := cfg.Aggregate(projs.ListProjects(model.FilterExcludeExternal))
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, len(cs))
+	assert.Equal(t, "accounts", cs[0].Name)
+	assert.Equal(t, 0, len(cs[0].Dependencies))
+
+	billing := cs[1]
+	assert.Equal(t, "billing", billing.Name)
+	assert.Equal(t, "finance", billing.Layer)
+	assert.Equal(t, 3, len(billing.Projects))
+	assert.Equal(t, 15, billing.Size.Lines)
+	assert.Equal(t, 3, billing.Changes.Total)
+	assert.Equal(t, 1, len(billing.Dependencies))
+	assert.Equal(t, 2, len(billing.Dependencies["accounts"].Dependencies))
+}
+
+func TestAggregateDifferentLayers(t *testing.T) {
+	t.Parallel()
+
+	projs := model.NewProjects()
+
+	add := func(from, to string) {
+		f := projs.GetOrCreate(from)
+		f.Type = model.CodeType
+		tp := projs.GetOrCreate(to)
+		tp.Type = model.CodeType
+		f.GetOrCreateDependency(tp)
+	}
+
+	add("billing-web", "billing-core")
+	add("billing-core", "accounts")
+
+	cfg, err := Parse(`
+billing = billing-*
+
+[layers]
+web = *-web
+`, projs)
+	assert.Nil(t, err)
+
+	_, err = cfg.Aggregate(projs.ListProjects(model.FilterExcludeExternal))
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "component billing has projects in different layers: no layer (billing-core); web (billing-web)", err.Error())
+	}
+}
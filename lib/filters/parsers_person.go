@@ -0,0 +1,102 @@
+package filters
+
+import (
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+func ParsePersonFilterWithUsage(rule string, filterType UsageType) (PersonFilterWithUsage, error) {
+	filter, err := ParsePersonFilter(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return LiftPersonFilter(filter, filterType), nil
+}
+
+func ParsePersonFilter(rule string) (PersonFilter, error) {
+	rule = strings.TrimSpace(rule)
+
+	switch {
+	case rule == "":
+		return func(person *model.Person) bool {
+			return true
+		}, nil
+
+	case strings.Index(rule, "|") >= 0:
+		clauses, err := ParsePersonFilterList(strings.Split(rule, "|"))
+		if err != nil {
+			return nil, err
+		}
+
+		return func(person *model.Person) bool {
+			result := false
+			for _, f := range clauses {
+				result = result || f(person)
+			}
+			return result
+		}, nil
+
+	case strings.Index(rule, "&") >= 0:
+		clauses, err := ParsePersonFilterList(strings.Split(rule, "&"))
+		if err != nil {
+			return nil, err
+		}
+
+		return func(person *model.Person) bool {
+			result := true
+			for _, f := range clauses {
+				result = result && f(person)
+			}
+			return result
+		}, nil
+
+	case strings.HasPrefix(rule, "!"):
+		f, err := ParsePersonFilter(rule[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		return func(person *model.Person) bool {
+			return !f(person)
+		}, nil
+
+	case strings.HasPrefix(rule, "id:"):
+		id, err := model.StringToID(rule[3:])
+		if err != nil {
+			return nil, err
+		}
+
+		return func(person *model.Person) bool {
+			return person.ID == id
+		}, nil
+
+	default:
+		f, err := ParseStringFilter(rule)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(person *model.Person) bool {
+			return f(person.Name) || lo.SomeBy(person.ListNames(), f) || lo.SomeBy(person.ListEmails(), f)
+		}, nil
+	}
+}
+
+func ParsePersonFilterList(rules []string) ([]PersonFilter, error) {
+	result := make([]PersonFilter, 0, len(rules))
+
+	for _, rule := range rules {
+		f, err := ParsePersonFilter(rule)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, f)
+	}
+
+	return result, nil
+}
@@ -105,7 +105,15 @@ func ParseProjectFilterList(projs *model.Projects, rules []string) ([]ProjectFil
 	return result, nil
 }
 
-func ParseDependencyFilter(projs *model.Projects, rule string) (ProjectFilter, error) {
+// DependencyRule is the parsed version of an edge filter, in the format `src -[depth][G]-> dest`
+type DependencyRule struct {
+	Source            func(proj *model.Project) bool
+	Dest              func(proj *model.Project) bool
+	MaxDepth          int
+	OnlyRequiredEdges bool
+}
+
+func ParseDependencyRule(rule string) (*DependencyRule, error) {
 	re := regexp.MustCompile(`^([^>]*?)\s*(?:-(\d+)?(G)?)?->\s*([^>]*)$`)
 
 	parts := re.FindStringSubmatch(rule)
@@ -131,11 +139,30 @@ func ParseDependencyFilter(projs *model.Projects, rule string) (ProjectFilter, e
 		onlyRequiredEdges = false
 	}
 
-	destFilter, _ := ParseOnlyProjsFilter(parts[4])
+	destFilter, err := ParseOnlyProjsFilter(parts[4])
+	if err != nil {
+		return nil, err
+	}
+
+	return &DependencyRule{
+		Source:            srcFilter,
+		Dest:              destFilter,
+		MaxDepth:          maxDepth,
+		OnlyRequiredEdges: onlyRequiredEdges,
+	}, nil
+}
+
+func ParseDependencyFilter(projs *model.Projects, rule string) (ProjectFilter, error) {
+	r, err := ParseDependencyRule(rule)
 	if err != nil {
 		return nil, err
 	}
 
+	srcFilter := r.Source
+	destFilter := r.Dest
+	maxDepth := r.MaxDepth
+	onlyRequiredEdges := r.OnlyRequiredEdges
+
 	matches := map[model.ID]map[model.ID]bool{}
 
 	for _, src := range projs.ListProjects(model.FilterExcludeExternal) {
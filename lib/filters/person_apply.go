@@ -0,0 +1,19 @@
+package filters
+
+import (
+	"github.com/pescuma/archer/lib/model"
+)
+
+func LiftPersonFilter(filter PersonFilter, usage UsageType) PersonFilterWithUsage {
+	return &simplePersonFilterWithUsage{filter, usage}
+}
+
+func UnliftPersonFilter(filter PersonFilterWithUsage) PersonFilter {
+	return func(person *model.Person) bool {
+		return filter.Decide(filter.Filter(person))
+	}
+}
+
+func GroupPersonFilters(filters ...PersonFilterWithUsage) PersonFilterWithUsage {
+	return &personFilterWithUsageGroup{filters}
+}
@@ -0,0 +1,12 @@
+package filters
+
+import "github.com/pescuma/archer/lib/model"
+
+type PersonFilter func(*model.Person) bool
+
+type PersonFilterWithUsage interface {
+	Filter(*model.Person) UsageType
+
+	// Decide does not return DontCase, so it should decide what to do in this case
+	Decide(u UsageType) bool
+}
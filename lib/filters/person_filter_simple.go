@@ -0,0 +1,49 @@
+package filters
+
+import (
+	"github.com/pescuma/archer/lib/model"
+)
+
+type simplePersonFilterWithUsage struct {
+	filter PersonFilter
+	usage  UsageType
+}
+
+func (s *simplePersonFilterWithUsage) Filter(person *model.Person) UsageType {
+	if s.filter(person) {
+		return s.usage
+	} else {
+		return DontCare
+	}
+}
+
+func (s *simplePersonFilterWithUsage) Decide(u UsageType) bool {
+	return u.DecideFor(s.usage)
+}
+
+type personFilterWithUsageGroup struct {
+	filters []PersonFilterWithUsage
+}
+
+func (g *personFilterWithUsageGroup) Filter(person *model.Person) UsageType {
+	result := DontCare
+	for _, f := range g.filters {
+		result = result.Merge(f.Filter(person))
+	}
+	return result
+}
+
+func (g *personFilterWithUsageGroup) Decide(u UsageType) bool {
+	switch u {
+	case Include:
+		return true
+	case Exclude:
+		return false
+	default:
+		result := true
+		for _, f := range g.filters {
+			result = result && f.Decide(u)
+		}
+		return result
+	}
+}
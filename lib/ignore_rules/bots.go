@@ -0,0 +1,116 @@
+package ignore_rules
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+const knownBots = `dependabot|renovate|greenkeeper|snyk-bot|github-actions|semantic-release-bot|allcontributors|mergify|pre-commit-ci|codecov|imgbot|whitesource|depfu|pyup-bot|web-flow`
+
+// knownBotsRE matches a whole name or email local part, like "dependabot[bot]" or "49699333+dependabot[bot]"
+var knownBotsRE = regexp.MustCompile(`(?i)^(\d+\+)?(` + knownBots + `)([-_ ]?bot|\[bot])?$`)
+
+// knownBotDomainsRE matches the whole domain of an email, like "renovateapp.com"
+var knownBotDomainsRE = regexp.MustCompile(`(?i)^(` + knownBots + `)(app|bot|hq)?\.(com|io|net|org)$`)
+var botSuffixRE = regexp.MustCompile(`(?i)(\[bot]|-bot|_bot|\bbot)$`)
+
+// ciEmailRE matches a whole email local part used by CI servers and service accounts, like "jenkins" or "noreply"
+var ciEmailRE = regexp.MustCompile(`(?i)^(ci|ci-bot|build|builder|buildbot|builds|jenkins|bamboo|teamcity|travis|circleci|gitlab-ci|automation|deploy|deployer|release|releases|svc|service|no-?reply|do-?not-?reply)\d*$`)
+
+// ciDomainsRE matches the whole domain of emails used by hosted CI services
+var ciDomainsRE = regexp.MustCompile(`(?i)^(travis-ci\.(org|com)|circleci\.com|appveyor\.com|buildkite\.com|semaphoreci\.com|drone\.io)$`)
+
+var botCommitRE = regexp.MustCompile(`(?i)^(bump |chore\(deps[^)]*\)|build\(deps[^)]*\)|fix\(deps[^)]*\)|update dependency |update .*(lockfile|lock file)|\[bot]|automated |auto-generated |\[ci skip]|\[skip ci])`)
+
+const botMinCommits = 5
+const botCommitsPercent = 0.8
+
+type LikelyBot struct {
+	Person  *model.Person
+	Reasons []string
+}
+
+// FindLikelyBots uses the names, emails and commit messages of people to find bots and service accounts
+func FindLikelyBots(people *model.People, repos *model.Repositories) []*LikelyBot {
+	commits := map[model.ID]int{}
+	botCommits := map[model.ID]int{}
+	for _, repo := range repos.List() {
+		for _, c := range repo.ListCommits() {
+			isBot := botCommitRE.MatchString(c.Message)
+
+			for _, a := range lo.Uniq(c.AuthorIDs) {
+				commits[a]++
+				if isBot {
+					botCommits[a]++
+				}
+			}
+		}
+	}
+
+	var result []*LikelyBot
+	for _, p := range people.ListPeople() {
+		emails := p.ListEmails()
+		if len(emails) == 0 {
+			continue
+		}
+
+		reasons := findBotReasons(p, emails, commits[p.ID], botCommits[p.ID])
+		if len(reasons) > 0 {
+			result = append(result, &LikelyBot{
+				Person:  p,
+				Reasons: reasons,
+			})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return strings.ToLower(result[i].Person.Name) < strings.ToLower(result[j].Person.Name)
+	})
+
+	return result
+}
+
+func findBotReasons(p *model.Person, emails []string, commits int, botCommits int) []string {
+	var result []string
+
+	names := lo.Uniq(append([]string{p.Name}, p.ListNames()...))
+
+	if lo.SomeBy(names, knownBotsRE.MatchString) || lo.SomeBy(emails, isKnownBotEmail) {
+		result = append(result, "known bot")
+	}
+
+	if lo.SomeBy(names, botSuffixRE.MatchString) ||
+		lo.SomeBy(emails, func(e string) bool { return botSuffixRE.MatchString(strings.Split(e, "@")[0]) }) {
+		result = append(result, "bot name")
+	}
+
+	if lo.SomeBy(emails, isCIEmail) {
+		result = append(result, "CI email")
+	}
+
+	if commits >= botMinCommits && float64(botCommits) >= botCommitsPercent*float64(commits) {
+		result = append(result, "automated commits")
+	}
+
+	return result
+}
+
+func isKnownBotEmail(email string) bool {
+	local, domain, _ := strings.Cut(email, "@")
+	return knownBotsRE.MatchString(local) || knownBotDomainsRE.MatchString(domain)
+}
+
+func isCIEmail(email string) bool {
+	local, domain, _ := strings.Cut(email, "@")
+	return ciEmailRE.MatchString(local) || ciDomainsRE.MatchString(domain)
+}
+
+// CreateBotRule returns a person rule that matches the emails of the bot
+func CreateBotRule(p *model.Person) string {
+	return strings.Join(p.ListEmails(), "|")
+}
@@ -0,0 +1,108 @@
+package ignore_rules
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+func TestFindLikelyBots(t *testing.T) {
+	t.Parallel()
+
+	people := model.NewPeople()
+	repos := model.NewRepositories()
+	repo := repos.GetOrCreate("/repo")
+
+	add := func(name string, email string) *model.Person {
+		p := people.GetOrCreatePerson(nil)
+		p.Name = name
+		p.AddName(name)
+		p.AddEmail(email)
+		return p
+	}
+	commit := func(p *model.Person, message string) {
+		c := repo.GetOrCreateCommit(fmt.Sprintf("%v", repo.CountCommits()))
+		c.Message = message
+		c.AuthorIDs = []model.ID{p.ID}
+	}
+
+	add("dependabot[bot]", "49699333+dependabot[bot]@users.noreply.github.com")
+	add("Renovate Bot", "bot@renovateapp.com")
+	add("Jenkins", "jenkins@example.com")
+	updater := add("Updater", "updater@example.com")
+	john := add("John Smith", "john@example.com")
+	add("Jane Doe", "jane.renovate-fan@example.com")
+	add("Mary Codecov", "mary@codecovfan.com")
+	add("Rita Release", "release.manager@example.com")
+	add("Bill Builder", "build-engineering@example.com")
+	add("Noah", "12345+noah@users.noreply.github.com")
+
+	for i := 0; i < 5; i++ {
+		commit(updater, "chore(deps): update module x to v1.2.3")
+		commit(john, "Fix the thing")
+	}
+	commit(john, "Bump version")
+
+	bots := FindLikelyBots(people, repos)
+
+	assert.Equal(t, []string{"dependabot[bot]", "Jenkins", "Renovate Bot", "Updater"},
+		lo.Map(bots, func(b *LikelyBot, _ int) string { return b.Person.Name }))
+	assert.Equal(t, []string{"known bot", "bot name"}, bots[0].Reasons)
+	assert.Equal(t, []string{"CI email"}, bots[1].Reasons)
+	assert.Equal(t, []string{"known bot", "bot name"}, bots[2].Reasons)
+	assert.Equal(t, []string{"automated commits"}, bots[3].Reasons)
+}
+
+func TestIsKnownBotEmail(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, isKnownBotEmail("49699333+dependabot[bot]@users.noreply.github.com"))
+	assert.True(t, isKnownBotEmail("github-actions@github.com"))
+	assert.True(t, isKnownBotEmail("bot@renovateapp.com"))
+	assert.True(t, isKnownBotEmail("support@dependabot.com"))
+	assert.False(t, isKnownBotEmail("jane.renovate-fan@example.com"))
+	assert.False(t, isKnownBotEmail("mary@codecovfan.com"))
+	assert.False(t, isKnownBotEmail("mary@mail.codecov.io.example.com"))
+}
+
+func TestIsCIEmail(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, isCIEmail("jenkins@example.com"))
+	assert.True(t, isCIEmail("build2@example.com"))
+	assert.True(t, isCIEmail("noreply@example.com"))
+	assert.True(t, isCIEmail("do-not-reply@example.com"))
+	assert.True(t, isCIEmail("builds@travis-ci.org"))
+	assert.False(t, isCIEmail("release.manager@example.com"))
+	assert.False(t, isCIEmail("build-engineering@example.com"))
+	assert.False(t, isCIEmail("service.desk@example.com"))
+	assert.False(t, isCIEmail("ci.lee@example.com"))
+	assert.False(t, isCIEmail("12345+noah@users.noreply.github.com"))
+	assert.False(t, isCIEmail("jane@circleci.com.example.com"))
+}
+
+func TestCreateBotRule(t *testing.T) {
+	t.Parallel()
+
+	p := model.NewPerson(1)
+	p.AddEmail("bot@renovateapp.com")
+	p.AddEmail("renovate@example.com")
+
+	rule := CreateBotRule(p)
+	assert.Equal(t, "bot@renovateapp.com|renovate@example.com", rule)
+
+	other := model.NewPerson(2)
+	other.AddEmail("john@example.com")
+
+	rules := model.NewIgnoreRules()
+	rules.AddPersonRule(rule)
+
+	i := &IgnoreRules{rules: rules}
+	assert.Nil(t, i.parseRules())
+	assert.True(t, i.IgnorePerson(p))
+	assert.False(t, i.IgnorePerson(other))
+}
@@ -18,6 +18,7 @@ type IgnoreRules struct {
 	rules        *model.IgnoreRules
 	fileFilter   filters.FileFilter
 	commitFilter filters.CommitFilter
+	personFilter filters.PersonFilter
 }
 
 func New(console consoles.Console, storage storages.Storage) (*IgnoreRules, error) {
@@ -134,6 +135,52 @@ func (i *IgnoreRules) addCommitRule(rule string) (bool, error) {
 	return true, err
 }
 
+func (i *IgnoreRules) AddPersonRule(rule string) error {
+	_, err := filters.ParsePersonFilter(rule)
+	if err != nil {
+		return err
+	}
+
+	changed, err := i.addPersonRule(rule)
+	if err != nil {
+		return err
+	}
+
+	if !changed {
+		i.console.Printf("Ignoring duplicated rule: %v\n", rule)
+		return nil
+	}
+
+	people, err := i.storage.LoadPeople()
+	if err != nil {
+		return err
+	}
+
+	i.console.Printf("Updating people with new ignore information...\n")
+
+	for _, person := range people.ListPeople() {
+		person.Ignore = i.IgnorePerson(person)
+	}
+
+	return nil
+}
+
+func (i *IgnoreRules) addPersonRule(rule string) (bool, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	for _, r := range i.rules.ListRules() {
+		if r.Type == model.PersonRule && r.Rule == rule {
+			return false, nil
+		}
+	}
+
+	i.rules.AddPersonRule(rule)
+
+	err := i.parseRules()
+	return true, err
+}
+
 func (i *IgnoreRules) IgnoreFile(file *model.File) bool {
 	i.mutex.RLock()
 	defer i.mutex.RUnlock()
@@ -148,9 +195,17 @@ func (i *IgnoreRules) IgnoreCommit(repo *model.Repository, commit *model.Reposit
 	return !i.commitFilter(repo, commit)
 }
 
+func (i *IgnoreRules) IgnorePerson(person *model.Person) bool {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	return !i.personFilter(person)
+}
+
 func (i *IgnoreRules) parseRules() error {
 	cs := make([]filters.CommitFilterWithUsage, 0, 10)
 	fs := make([]filters.FileFilterWithUsage, 0, 10)
+	ps := make([]filters.PersonFilterWithUsage, 0, 10)
 
 	for _, r := range i.rules.ListRules() {
 		if r.Deleted {
@@ -174,11 +229,20 @@ func (i *IgnoreRules) parseRules() error {
 			}
 
 			cs = append(cs, f)
+
+		case model.PersonRule:
+			f, err := filters.ParsePersonFilterWithUsage(r.Rule, filters.Exclude)
+			if err != nil {
+				return err
+			}
+
+			ps = append(ps, f)
 		}
 	}
 
 	i.fileFilter = filters.UnliftFileFilter(filters.GroupFileFilters(fs...))
 	i.commitFilter = filters.UnliftCommitFilter(filters.GroupCommitFilters(cs...))
+	i.personFilter = filters.UnliftPersonFilter(filters.GroupPersonFilters(ps...))
 
 	return nil
 }
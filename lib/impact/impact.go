@@ -0,0 +1,85 @@
+package impact
+
+import (
+	"sort"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+// Dependent is a project that transitively depends on one of the selected projects
+type Dependent struct {
+	Project *model.Project
+	// Depth is 1 for direct dependents
+	Depth int
+	// Path goes from Project to the selected project it depends on
+	Path []*model.Project
+}
+
+func (d *Dependent) Target() *model.Project {
+	return d.Path[len(d.Path)-1]
+}
+
+// FindDependents walks the dependency graph backwards from the selected projects. maxDepth <= 0 means no limit.
+// Each dependent is returned once, with one of the shortest paths, sorted by depth and name.
+func FindDependents(ps []*model.Project, selected []*model.Project, maxDepth int) []*Dependent {
+	dependents := map[*model.Project][]*model.Project{}
+	for _, p := range ps {
+		for _, d := range p.ListDependencies(model.FilterAll) {
+			if d.Target != p {
+				dependents[d.Target] = append(dependents[d.Target], d.Source)
+			}
+		}
+	}
+
+	for _, ds := range dependents {
+		sort.Slice(ds, func(i, j int) bool { return ds[i].Name < ds[j].Name })
+	}
+
+	next := map[*model.Project]*model.Project{}
+	depth := map[*model.Project]int{}
+	for _, p := range selected {
+		depth[p] = 0
+	}
+
+	var result []*Dependent
+
+	queue := append([]*model.Project{}, selected...)
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+
+		if maxDepth > 0 && depth[p] >= maxDepth {
+			continue
+		}
+
+		for _, s := range dependents[p] {
+			if _, ok := depth[s]; ok {
+				continue
+			}
+
+			depth[s] = depth[p] + 1
+			next[s] = p
+			queue = append(queue, s)
+
+			path := []*model.Project{s}
+			for n := p; n != nil; n = next[n] {
+				path = append(path, n)
+			}
+
+			result = append(result, &Dependent{
+				Project: s,
+				Depth:   depth[s],
+				Path:    path,
+			})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Depth != result[j].Depth {
+			return result[i].Depth < result[j].Depth
+		}
+		return result[i].Project.Name < result[j].Project.Name
+	})
+
+	return result
+}
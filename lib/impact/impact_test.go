@@ -0,0 +1,54 @@
+package impact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+func TestFindDependents(t *testing.T) {
+	t.Parallel()
+
+	projs := model.NewProjects()
+
+	add := func(from, to string) {
+		f := projs.GetOrCreate(from)
+		f.Type = model.CodeType
+		f.GetOrCreateDependency(projs.GetOrCreate(to))
+	}
+
+	add("service", "lib")
+	add("app", "service")
+	add("app", "lib")
+	add("cli", "app")
+	add("other", "guava")
+	add("lib", "guava")
+
+	ps := projs.ListProjects(model.FilterAll)
+	lib := projs.GetOrCreate("lib")
+
+	ds := FindDependents(ps, []*model.Project{lib}, 0)
+
+	assert.Equal(t, []string{"app", "service", "cli"}, projectNames(dependentProjects(ds)))
+	assert.Equal(t, []int{1, 1, 2}, []int{ds[0].Depth, ds[1].Depth, ds[2].Depth})
+	assert.Equal(t, "cli -> app -> lib", ds[2].PathText())
+	assert.Equal(t, lib, ds[2].Target())
+
+	ds = FindDependents(ps, []*model.Project{lib}, 1)
+
+	assert.Equal(t, []string{"app", "service"}, projectNames(dependentProjects(ds)))
+
+	ds = FindDependents(ps, []*model.Project{projs.GetOrCreate("guava")}, 0)
+
+	assert.Equal(t, []string{"lib", "other", "app", "service", "cli"}, projectNames(dependentProjects(ds)))
+}
+
+func dependentProjects(ds []*Dependent) []*model.Project {
+	var result []*model.Project
+	for _, d := range ds {
+		result = append(result, d.Project)
+	}
+	return result
+}
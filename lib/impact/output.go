@@ -0,0 +1,58 @@
+package impact
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+func (d *Dependent) PathText() string {
+	return strings.Join(projectNames(d.Path), " -> ")
+}
+
+func WriteText(w io.Writer, selected []*model.Project, ds []*Dependent) error {
+	_, _ = fmt.Fprintf(w, "Projects that depend on %v:\n", strings.Join(projectNames(selected), ", "))
+
+	for _, d := range ds {
+		_, _ = fmt.Fprintf(w, "   [%v] %v (%v)\n", d.Depth, d.Project.Name, d.PathText())
+	}
+
+	_, err := fmt.Fprintf(w, "\n%v dependent projects found\n", len(ds))
+	return err
+}
+
+func WriteJSON(w io.Writer, selected []*model.Project, ds []*Dependent) error {
+	type jsonDependent struct {
+		Name  string   `json:"name"`
+		Depth int      `json:"depth"`
+		Path  []string `json:"path"`
+	}
+	type jsonImpact struct {
+		Selected   []string        `json:"selected"`
+		Dependents []jsonDependent `json:"dependents"`
+	}
+
+	out := jsonImpact{
+		Selected: projectNames(selected),
+		Dependents: lo.Map(ds, func(d *Dependent, _ int) jsonDependent {
+			return jsonDependent{
+				Name:  d.Project.Name,
+				Depth: d.Depth,
+				Path:  projectNames(d.Path),
+			}
+		}),
+	}
+
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(out)
+}
+
+func projectNames(ps []*model.Project) []string {
+	return lo.Map(ps, func(p *model.Project, _ int) string { return p.Name })
+}
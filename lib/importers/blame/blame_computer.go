@@ -84,6 +84,10 @@ func (i *Computer) Compute() error {
 		}
 
 		pa := peopleDB.GetPersonByID(blame.AuthorID)
+		if pa.Ignore {
+			continue
+		}
+
 		add(pa.Blame, blame)
 
 		file := filesDB.GetByID(blame.FileID)
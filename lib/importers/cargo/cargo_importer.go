@@ -0,0 +1,236 @@
+package cargo
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/importers/common"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages"
+	"github.com/pescuma/archer/lib/utils"
+)
+
+type Importer struct {
+	console consoles.Console
+	storage storages.Storage
+}
+
+type Options struct {
+	Groups           []string
+	RespectGitignore bool
+}
+
+func NewImporter(console consoles.Console, storage storages.Storage) *Importer {
+	return &Importer{
+		console: console,
+		storage: storage,
+	}
+}
+
+func (i *Importer) Import(dirs []string, opts *Options) error {
+	projsDB, err := i.storage.LoadProjects()
+	if err != nil {
+		return err
+	}
+
+	filesDB, err := i.storage.LoadFiles()
+	if err != nil {
+		return err
+	}
+
+	manifests := map[string]*manifest{}
+
+	err = common.FindAndImportFiles(i.console, "Cargo.toml files", dirs,
+		func(name string) bool {
+			return name == "Cargo.toml"
+		},
+		func(path string) error {
+			// Packaged crates
+			if lo.Contains(strings.Split(filepath.ToSlash(path), "/"), "target") {
+				return nil
+			}
+
+			m, err := loadManifest(path)
+			if err != nil {
+				return err
+			}
+
+			manifests[m.RootDir()] = m
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	resolveWorkspaces(manifests)
+
+	queue := lo.Keys(manifests)
+	sort.Strings(queue)
+
+	i.console.Printf("Importing %v projects...\n", len(queue))
+
+	locks := map[string]*lock{}
+
+	return common.ImportFiles(queue, func(dir string) error {
+		return i.process(projsDB, filesDB, manifests, locks, manifests[dir], opts)
+	})
+}
+
+func resolveWorkspaces(manifests map[string]*manifest) {
+	for _, ws := range manifests {
+		if ws.Workspace == nil {
+			continue
+		}
+
+		ws.workspace = ws
+
+		for dir, m := range manifests {
+			if m.workspace == nil && ws.IsMember(dir) {
+				m.workspace = ws
+			}
+		}
+	}
+}
+
+func (i *Importer) process(projsDB *model.Projects, filesDB *model.Files, manifests map[string]*manifest, locks map[string]*lock,
+	m *manifest, opts *Options,
+) error {
+	name := m.Name()
+	if name == "" {
+		// Virtual workspace manifest
+		return nil
+	}
+
+	proj := projsDB.GetOrCreate(name)
+	proj.Groups = i.computeGroups(m, opts)
+	proj.Type = model.CodeType
+	proj.RootDir = m.RootDir()
+	proj.ProjectFile = m.path
+	proj.Dependencies = make(map[string]*model.ProjectDependency)
+	proj.SeenAt(time.Now())
+
+	dir := proj.GetDirectory(".")
+	dir.Type = model.SourceDir
+	dir.SeenAt(time.Now())
+
+	projFile := filesDB.GetOrCreate(m.path)
+	projFile.ProjectID = &proj.ID
+	projFile.ProjectDirectoryID = &dir.ID
+	projFile.SeenAt(time.Now())
+
+	if projFile.RepositoryID != nil {
+		proj.RepositoryID = projFile.RepositoryID
+	}
+
+	lockDir := m.RootDir()
+	if m.workspace != nil {
+		lockDir = m.workspace.RootDir()
+	}
+
+	l, ok := locks[lockDir]
+	if !ok {
+		var err error
+		l, err = loadLock(lockDir)
+		if err != nil {
+			return err
+		}
+
+		locks[lockDir] = l
+	}
+
+	for _, d := range m.ListDependencies() {
+		depName := d.Name
+		local := false
+
+		if d.Path != "" {
+			if target, ok := manifests[d.Path]; ok && target.Name() != "" {
+				depName = target.Name()
+			}
+			local = true
+
+		} else if m.workspace != nil {
+			local = lo.ContainsBy(lo.Values(manifests), func(o *manifest) bool {
+				return o.workspace == m.workspace && o.Name() == depName
+			})
+		}
+
+		dp := projsDB.GetOrCreate(depName)
+
+		dep := proj.GetOrCreateDependency(dp)
+		if dep.GetData("scope") == "" {
+			dep.SetData("scope", d.Scope)
+		}
+
+		if local {
+			continue
+		}
+
+		// Without a Cargo.lock we only know the version requirement, not the version used
+		if d.Version != "" {
+			dep.SetData("requirement", d.Version)
+		}
+
+		if l != nil {
+			if version := l.Version(d.Name); version != "" {
+				dep.Versions.Insert(version)
+			}
+		}
+	}
+
+	filter, err := common.CreateFileFilter(proj.RootDir, opts.RespectGitignore,
+		func(path string) bool {
+			name := filepath.Base(path)
+			return name == "Cargo.toml" || name == "Cargo.lock" || filepath.Ext(name) == ".rs"
+		},
+		func(path string, isDir bool) bool {
+			name := filepath.Base(path)
+			if strings.HasPrefix(name, ".") || (isDir && name == "target") {
+				return true
+			}
+
+			// Other crates own their files
+			if isDir && path != proj.RootDir {
+				exists, _ := utils.FileExists(filepath.Join(path, "Cargo.toml"))
+				return exists
+			}
+
+			return false
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = common.MarkDeletedFilesAndUnmarkExistingOnes(filesDB, proj, dir, filter)
+	if err != nil {
+		return err
+	}
+
+	err = common.AddFiles(filesDB, proj, dir, filter)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *Importer) computeGroups(m *manifest, opts *Options) []string {
+	result := lo.Filter(opts.Groups, func(g string, _ int) bool { return g != "" })
+
+	switch {
+	case m.workspace == nil:
+		result = append(result, m.Name())
+	case m.workspace.Name() != "":
+		result = append(result, m.workspace.Name())
+	default:
+		result = append(result, filepath.Base(m.workspace.RootDir()))
+	}
+
+	return result
+}
@@ -0,0 +1,101 @@
+package cargo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/model"
+)
+
+func TestWorkspace(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "Cargo.toml"), `
+[workspace]
+members = ["crates/*"]
+exclude = ["crates/old"]
+
+[workspace.dependencies]
+serde = { version = "1.0", features = ["derive"] }
+core = { path = "crates/core" }
+`)
+	writeFile(t, filepath.Join(dir, "crates", "core", "Cargo.toml"), `
+[package]
+name = "my-core"
+version = "0.1.0"
+
+[dependencies]
+serde.workspace = true
+`)
+	writeFile(t, filepath.Join(dir, "crates", "cli", "Cargo.toml"), `
+[package]
+name = "my-cli"
+version = "0.1.0"
+
+[dependencies]
+core = { workspace = true }
+clap = "4.4"
+
+[dev-dependencies]
+tempfile = "3"
+`)
+	writeFile(t, filepath.Join(dir, "crates", "old", "Cargo.toml"), `
+[package]
+name = "old"
+`)
+	writeFile(t, filepath.Join(dir, "Cargo.lock"), `
+version = 3
+
+[[package]]
+name = "clap"
+version = "4.4.11"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "my-core"
+version = "0.1.0"
+`)
+
+	manifests := map[string]*manifest{}
+	for _, p := range []string{"Cargo.toml", "crates/core/Cargo.toml", "crates/cli/Cargo.toml", "crates/old/Cargo.toml"} {
+		m, err := loadManifest(filepath.Join(dir, p))
+		assert.Nil(t, err)
+		manifests[m.RootDir()] = m
+	}
+	resolveWorkspaces(manifests)
+
+	projsDB := model.NewProjects()
+	filesDB := model.NewFiles()
+	importer := NewImporter(consoles.NewStdOutConsole(), nil)
+	locks := map[string]*lock{}
+	for _, m := range manifests {
+		assert.Nil(t, importer.process(projsDB, filesDB, manifests, locks, m, &Options{}))
+	}
+
+	cli := projsDB.GetOrCreate("my-cli")
+	assert.Equal(t, model.CodeType, cli.Type)
+	assert.Equal(t, []string{filepath.Base(dir)}, cli.Groups)
+	assert.True(t, cli.Dependencies["my-core"].Target.IsCode())
+	assert.Equal(t, []string{"4.4.11"}, cli.Dependencies["clap"].Versions.Slice())
+	assert.Equal(t, "dev", cli.Dependencies["tempfile"].GetData("scope"))
+	assert.Equal(t, 0, cli.Dependencies["tempfile"].Versions.Size())
+	assert.Equal(t, "3", cli.Dependencies["tempfile"].GetData("requirement"))
+
+	core := projsDB.GetOrCreate("my-core")
+	assert.Equal(t, 0, core.Dependencies["serde"].Versions.Size())
+	assert.Equal(t, "1.0", core.Dependencies["serde"].GetData("requirement"))
+	assert.False(t, core.Dependencies["serde"].Target.IsCode())
+
+	assert.Equal(t, []string{"old"}, projsDB.GetOrCreate("old").Groups)
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+}
@@ -0,0 +1,236 @@
+package cargo
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+type manifest struct {
+	path      string
+	Package   *manifestPackage   `toml:"package"`
+	Workspace *manifestWorkspace `toml:"workspace"`
+
+	Dependencies      map[string]any `toml:"dependencies"`
+	DevDependencies   map[string]any `toml:"dev-dependencies"`
+	BuildDependencies map[string]any `toml:"build-dependencies"`
+	Target            map[string]struct {
+		Dependencies      map[string]any `toml:"dependencies"`
+		DevDependencies   map[string]any `toml:"dev-dependencies"`
+		BuildDependencies map[string]any `toml:"build-dependencies"`
+	} `toml:"target"`
+
+	workspace *manifest
+}
+
+type manifestPackage struct {
+	Name string `toml:"name"`
+}
+
+type manifestWorkspace struct {
+	Members      []string       `toml:"members"`
+	Exclude      []string       `toml:"exclude"`
+	Dependencies map[string]any `toml:"dependencies"`
+}
+
+type dependency struct {
+	Name    string
+	Scope   string
+	Version string
+	Path    string
+}
+
+func loadManifest(path string) (*manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseManifest(path, data)
+}
+
+func parseManifest(path string, data []byte) (*manifest, error) {
+	var result manifest
+	err := toml.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	result.path = path
+
+	return &result, nil
+}
+
+func (m *manifest) RootDir() string {
+	return filepath.Dir(m.path)
+}
+
+func (m *manifest) Name() string {
+	if m.Package == nil {
+		return ""
+	}
+
+	return m.Package.Name
+}
+
+// ListDependencies returns the dependencies of all sections, with paths relative to this manifest resolved to
+// absolute paths and workspace inheritance applied
+func (m *manifest) ListDependencies() []*dependency {
+	var result []*dependency
+
+	add := func(scope string, deps map[string]any) {
+		keys := make([]string, 0, len(deps))
+		for k := range deps {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			dep := m.parseDependency(key, deps[key])
+			if dep == nil {
+				continue
+			}
+
+			dep.Scope = scope
+			result = append(result, dep)
+		}
+	}
+
+	add("normal", m.Dependencies)
+	add("dev", m.DevDependencies)
+	add("build", m.BuildDependencies)
+
+	for _, t := range m.Target {
+		add("normal", t.Dependencies)
+		add("dev", t.DevDependencies)
+		add("build", t.BuildDependencies)
+	}
+
+	return result
+}
+
+func (m *manifest) parseDependency(key string, value any) *dependency {
+	result := &dependency{
+		Name: key,
+	}
+
+	switch v := value.(type) {
+	case string:
+		result.Version = v
+
+	case map[string]any:
+		if inherit, _ := v["workspace"].(bool); inherit && m.workspace != nil && m.workspace.Workspace != nil {
+			parent := m.workspace.parseDependency(key, m.workspace.Workspace.Dependencies[key])
+			if parent != nil {
+				result = parent
+			}
+		}
+
+		if pkg, ok := v["package"].(string); ok {
+			result.Name = pkg
+		}
+		if version, ok := v["version"].(string); ok {
+			result.Version = version
+		}
+		if path, ok := v["path"].(string); ok {
+			result.Path = filepath.Clean(filepath.Join(m.RootDir(), path))
+		}
+
+	default:
+		return nil
+	}
+
+	return result
+}
+
+// IsMember returns true if the manifest in dir is included in this workspace
+func (m *manifest) IsMember(dir string) bool {
+	if m.Workspace == nil {
+		return false
+	}
+
+	rel, err := filepath.Rel(m.RootDir(), dir)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+
+	for _, pattern := range m.Workspace.Exclude {
+		if match(pattern, rel) {
+			return false
+		}
+	}
+
+	for _, pattern := range m.Workspace.Members {
+		if match(pattern, rel) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func match(pattern string, path string) bool {
+	pattern = strings.TrimSuffix(strings.TrimPrefix(pattern, "./"), "/")
+
+	m, err := filepath.Match(pattern, path)
+	return err == nil && m
+}
+
+type lock struct {
+	versions map[string][]string
+}
+
+func loadLock(dir string) (*lock, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "Cargo.lock"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return parseLock(data)
+}
+
+func parseLock(data []byte) (*lock, error) {
+	var content struct {
+		Package []struct {
+			Name    string `toml:"name"`
+			Version string `toml:"version"`
+			Source  string `toml:"source"`
+		} `toml:"package"`
+	}
+
+	err := toml.Unmarshal(data, &content)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &lock{
+		versions: map[string][]string{},
+	}
+
+	for _, p := range content.Package {
+		// Local crates don't have a source
+		if p.Source == "" {
+			continue
+		}
+
+		result.versions[p.Name] = append(result.versions[p.Name], p.Version)
+	}
+
+	return result, nil
+}
+
+// Version returns the locked version of a crate, or "" if it is not locked or if there are many versions of it
+func (l *lock) Version(name string) string {
+	versions := l.versions[name]
+	if len(versions) != 1 {
+		return ""
+	}
+
+	return versions[0]
+}
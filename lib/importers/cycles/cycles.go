@@ -0,0 +1,274 @@
+package cycles
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+// Cycle is a strongly connected component of the dependency graph, with more than one node
+type Cycle struct {
+	Nodes []string
+	Edges []*Edge
+	// Cut is a set of edges that, when removed, breaks all the cycles between the nodes
+	Cut []*Edge
+}
+
+// Edge aggregates the project dependencies between two nodes
+type Edge struct {
+	Source       string
+	Target       string
+	Dependencies []*model.ProjectDependency
+	// Usages is the number of source imports that use the dependency, or -1 if unknown
+	Usages int
+}
+
+func (e *Edge) String() string {
+	return e.Source + " -> " + e.Target
+}
+
+// cost is used to find the edge that is cheapest to cut. Usages are preferred, when known.
+func (e *Edge) cost() (int, int) {
+	if e.Usages >= 0 {
+		return 0, e.Usages
+	}
+	return 1, len(e.Dependencies)
+}
+
+// FindCycles finds the cycles between code projects, grouping projects by the name returned by getName
+func FindCycles(ps []*model.Project, getName func(*model.Project) string) []*Cycle {
+	g := newGraph()
+
+	for _, p := range ps {
+		if p.IsExternalDependency() {
+			continue
+		}
+
+		src := getName(p)
+		g.addNode(src)
+
+		for _, d := range p.ListDependencies(model.FilterExcludeExternal) {
+			dest := getName(d.Target)
+			if dest == src {
+				continue
+			}
+
+			g.addEdge(src, dest, d)
+		}
+	}
+
+	var result []*Cycle
+	for _, scc := range g.stronglyConnectedComponents() {
+		if len(scc) < 2 {
+			continue
+		}
+
+		in := map[string]bool{}
+		for _, n := range scc {
+			in[n] = true
+		}
+
+		c := &Cycle{}
+		c.Nodes = scc
+		sort.Strings(c.Nodes)
+
+		for _, n := range c.Nodes {
+			for _, e := range g.sortedEdges(n) {
+				if in[e.Target] {
+					c.Edges = append(c.Edges, e)
+				}
+			}
+		}
+
+		c.Cut = findCut(c.Nodes, c.Edges)
+
+		result = append(result, c)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Nodes[0] < result[j].Nodes[0]
+	})
+
+	return result
+}
+
+// findCut removes the cheapest edge of each strongly connected component and checks again, until there are no
+// cycles left. It is greedy, so the result is not always the cheapest set.
+func findCut(nodes []string, edges []*Edge) []*Edge {
+	g := newGraph()
+	for _, n := range nodes {
+		g.addNode(n)
+	}
+	for _, e := range edges {
+		g.edges[e.Source][e.Target] = e
+	}
+
+	var result []*Edge
+	for {
+		cut := false
+
+		for _, scc := range g.stronglyConnectedComponents() {
+			if len(scc) < 2 {
+				continue
+			}
+
+			in := map[string]bool{}
+			for _, n := range scc {
+				in[n] = true
+			}
+
+			var cheapest *Edge
+			for _, n := range scc {
+				for _, e := range g.sortedEdges(n) {
+					if in[e.Target] && (cheapest == nil || lessCost(e, cheapest)) {
+						cheapest = e
+					}
+				}
+			}
+
+			delete(g.edges[cheapest.Source], cheapest.Target)
+			result = append(result, cheapest)
+			cut = true
+		}
+
+		if !cut {
+			break
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+
+	return result
+}
+
+func lessCost(a, b *Edge) bool {
+	a1, a2 := a.cost()
+	b1, b2 := b.cost()
+
+	if a1 != b1 {
+		return a1 < b1
+	}
+	if a2 != b2 {
+		return a2 < b2
+	}
+	return a.String() < b.String()
+}
+
+func dependencyUsages(d *model.ProjectDependency) int {
+	for _, k := range []string{"imports:statements", "imports"} {
+		v := d.GetData(k)
+		if v == "" {
+			continue
+		}
+
+		i, err := strconv.Atoi(v)
+		if err == nil {
+			return i
+		}
+	}
+
+	return -1
+}
+
+type graph struct {
+	nodes []string
+	edges map[string]map[string]*Edge
+}
+
+func newGraph() *graph {
+	return &graph{
+		edges: map[string]map[string]*Edge{},
+	}
+}
+
+func (g *graph) addNode(n string) {
+	if _, ok := g.edges[n]; !ok {
+		g.nodes = append(g.nodes, n)
+		g.edges[n] = map[string]*Edge{}
+	}
+}
+
+func (g *graph) addEdge(src, dest string, d *model.ProjectDependency) {
+	g.addNode(src)
+	g.addNode(dest)
+
+	e, ok := g.edges[src][dest]
+	if !ok {
+		e = &Edge{
+			Source: src,
+			Target: dest,
+			Usages: -1,
+		}
+		g.edges[src][dest] = e
+	}
+
+	e.Dependencies = append(e.Dependencies, d)
+
+	if u := dependencyUsages(d); u >= 0 {
+		e.Usages = max(e.Usages, 0) + u
+	}
+}
+
+func (g *graph) sortedEdges(n string) []*Edge {
+	result := make([]*Edge, 0, len(g.edges[n]))
+	for _, e := range g.edges[n] {
+		result = append(result, e)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Target < result[j].Target
+	})
+	return result
+}
+
+// stronglyConnectedComponents uses Tarjan's algorithm
+func (g *graph) stronglyConnectedComponents() [][]string {
+	index := 0
+	indexes := map[string]int{}
+	lowLinks := map[string]int{}
+	onStack := map[string]bool{}
+	var stack []string
+	var result [][]string
+
+	var visit func(n string)
+	visit = func(n string) {
+		indexes[n] = index
+		lowLinks[n] = index
+		index++
+		stack = append(stack, n)
+		onStack[n] = true
+
+		for _, e := range g.sortedEdges(n) {
+			if _, ok := indexes[e.Target]; !ok {
+				visit(e.Target)
+				lowLinks[n] = min(lowLinks[n], lowLinks[e.Target])
+			} else if onStack[e.Target] {
+				lowLinks[n] = min(lowLinks[n], indexes[e.Target])
+			}
+		}
+
+		if lowLinks[n] == indexes[n] {
+			var scc []string
+			for {
+				m := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[m] = false
+				scc = append(scc, m)
+				if m == n {
+					break
+				}
+			}
+			result = append(result, scc)
+		}
+	}
+
+	for _, n := range g.nodes {
+		if _, ok := indexes[n]; !ok {
+			visit(n)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,113 @@
+package cycles
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages"
+)
+
+type Computer struct {
+	console consoles.Console
+	storage storages.Storage
+}
+
+type Options struct {
+	// Levels of groups to check. 0 means projects.
+	Levels []int
+}
+
+func NewComputer(console consoles.Console, storage storages.Storage) *Computer {
+	return &Computer{
+		console: console,
+		storage: storage,
+	}
+}
+
+func (c *Computer) Compute(opts *Options) error {
+	projectsDB, err := c.storage.LoadProjects()
+	if err != nil {
+		return err
+	}
+
+	ps := projectsDB.ListProjects(model.FilterExcludeExternal)
+
+	for _, level := range opts.Levels {
+		cs := FindCycles(ps, func(p *model.Project) string {
+			return GetLevelName(p, level)
+		})
+
+		if level == 0 {
+			markCycleDependencies(ps, cs)
+		}
+
+		c.print(level, cs)
+	}
+
+	return nil
+}
+
+// GetLevelName returns the name of the node of the project at the level. Level 0 is the project itself.
+func GetLevelName(p *model.Project, level int) string {
+	if level == 0 {
+		return p.Name
+	}
+	return p.LevelSimpleName(level)
+}
+
+// markCycleDependencies stores in the dependencies if they are part of a cycle, so other commands can use it
+func markCycleDependencies(ps []*model.Project, cs []*Cycle) {
+	inCycle := map[*model.ProjectDependency]bool{}
+	for _, cycle := range cs {
+		for _, e := range cycle.Edges {
+			for _, d := range e.Dependencies {
+				inCycle[d] = true
+			}
+		}
+	}
+
+	for _, p := range ps {
+		for _, d := range p.Dependencies {
+			if inCycle[d] {
+				d.SetData("cycle", "true")
+			} else {
+				d.SetData("cycle", "")
+			}
+		}
+	}
+}
+
+func (c *Computer) print(level int, cs []*Cycle) {
+	what := "projects"
+	if level > 0 {
+		what = fmt.Sprintf("groups at level %v", level)
+	}
+
+	if len(cs) == 0 {
+		c.console.Printf("No cycles found between %v\n", what)
+		return
+	}
+
+	c.console.Printf("Found %v cycles between %v:\n", len(cs), what)
+
+	for _, cycle := range cs {
+		c.console.Printf("   Cycle between %v\n", strings.Join(cycle.Nodes, ", "))
+
+		for _, e := range cycle.Edges {
+			c.console.Printf("      %v%v\n", e, usagesText(e))
+		}
+
+		for _, e := range cycle.Cut {
+			c.console.Printf("      Cut: %v%v\n", e, usagesText(e))
+		}
+	}
+}
+
+func usagesText(e *Edge) string {
+	if e.Usages < 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%v usages)", e.Usages)
+}
@@ -0,0 +1,90 @@
+package cycles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+func TestFindCycles(t *testing.T) {
+	t.Parallel()
+
+	projs := model.NewProjects()
+
+	add := func(from, to string, usages string) {
+		f := projs.GetOrCreate(from)
+		f.Type = model.CodeType
+		f.Groups = []string{from[:1]}
+		tp := projs.GetOrCreate(to)
+		tp.Type = model.CodeType
+		tp.Groups = []string{to[:1]}
+		d := f.GetOrCreateDependency(tp)
+		d.SetData("imports:statements", usages)
+	}
+
+	add("a1", "a2", "10")
+	add("a2", "b1", "5")
+	add("b1", "a1", "1")
+	add("b1", "c1", "")
+	add("c1", "b2", "")
+	add("b2", "c2", "")
+
+	ps := projs.ListProjects(model.FilterExcludeExternal)
+
+	cs := FindCycles(ps, func(p *model.Project) string { return GetLevelName(p, 0) })
+	if assert.Len(t, cs, 1) {
+		assert.Equal(t, []string{"a1", "a2", "b1"}, cs[0].Nodes)
+		assert.Len(t, cs[0].Edges, 3)
+		assert.Equal(t, []string{"b1 -> a1"}, edgeNames(cs[0].Cut))
+		assert.Equal(t, 1, cs[0].Cut[0].Usages)
+	}
+
+	cs = FindCycles(ps, func(p *model.Project) string { return GetLevelName(p, 1) })
+	if assert.Len(t, cs, 1) {
+		assert.Equal(t, []string{"a", "b", "c"}, cs[0].Nodes)
+		assert.Equal(t, []string{"a -> b", "b -> a", "b -> c", "c -> b"}, edgeNames(cs[0].Edges))
+		assert.Equal(t, []string{"b -> a", "c -> b"}, edgeNames(cs[0].Cut))
+	}
+
+	markCycleDependencies(ps, FindCycles(ps, func(p *model.Project) string { return p.Name }))
+	assert.Equal(t, "true", projs.GetOrCreate("b1").Dependencies["a1"].GetData("cycle"))
+	assert.Equal(t, "", projs.GetOrCreate("b1").Dependencies["c1"].GetData("cycle"))
+}
+
+func TestFindCyclesCutsAllCycles(t *testing.T) {
+	t.Parallel()
+
+	projs := model.NewProjects()
+
+	add := func(from, to string, usages string) {
+		f := projs.GetOrCreate(from)
+		f.Type = model.CodeType
+		tp := projs.GetOrCreate(to)
+		tp.Type = model.CodeType
+		d := f.GetOrCreateDependency(tp)
+		d.SetData("imports:statements", usages)
+	}
+
+	// Two cycles that share only a: a -> b -> a and a -> c -> d -> a
+	add("a", "b", "10")
+	add("b", "a", "1")
+	add("a", "c", "10")
+	add("c", "d", "2")
+	add("d", "a", "10")
+
+	cs := FindCycles(projs.ListProjects(model.FilterExcludeExternal), func(p *model.Project) string { return p.Name })
+	if assert.Len(t, cs, 1) {
+		assert.Equal(t, []string{"a", "b", "c", "d"}, cs[0].Nodes)
+		assert.Equal(t, []string{"b -> a", "c -> d"}, edgeNames(cs[0].Cut))
+	}
+}
+
+func edgeNames(es []*Edge) []string {
+	var result []string
+	for _, e := range es {
+		result = append(result, e.String())
+	}
+	return result
+}
@@ -0,0 +1,55 @@
+package git
+
+import (
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages"
+)
+
+// commitDetailsWriter writes the commit details of all repositories from a single goroutine
+type commitDetailsWriter struct {
+	storage storages.Storage
+	input   chan []*model.RepositoryCommitDetails
+	done    chan struct{}
+	err     error
+}
+
+func newCommitDetailsWriter(storage storages.Storage) *commitDetailsWriter {
+	result := &commitDetailsWriter{
+		storage: storage,
+		input:   make(chan []*model.RepositoryCommitDetails, 10),
+		done:    make(chan struct{}),
+	}
+
+	go result.run()
+
+	return result
+}
+
+func (w *commitDetailsWriter) run() {
+	defer close(w.done)
+
+	for details := range w.input {
+		// After an error, only drain the input so writers don't block
+		if w.err != nil {
+			continue
+		}
+
+		w.err = w.storage.WriteRepositoryCommitDetails(details)
+	}
+}
+
+func (w *commitDetailsWriter) Write(details []*model.RepositoryCommitDetails) {
+	if len(details) == 0 {
+		return
+	}
+
+	w.input <- details
+}
+
+// Close waits for all pending writes and returns the first error
+func (w *commitDetailsWriter) Close() error {
+	close(w.input)
+	<-w.done
+
+	return w.err
+}
@@ -0,0 +1,60 @@
+package git
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages"
+)
+
+type detailsStorage struct {
+	storages.Storage
+
+	mutex   sync.Mutex
+	written []model.ID
+	err     error
+}
+
+func (s *detailsStorage) WriteRepositoryCommitDetails(details []*model.RepositoryCommitDetails) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, d := range details {
+		s.written = append(s.written, d.CommitID)
+	}
+
+	return s.err
+}
+
+func TestCommitDetailsWriter(t *testing.T) {
+	t.Parallel()
+
+	storage := &detailsStorage{}
+	w := newCommitDetailsWriter(storage)
+
+	w.Write([]*model.RepositoryCommitDetails{model.NewRepositoryCommitDetails(1, 1), model.NewRepositoryCommitDetails(1, 2)})
+	w.Write(nil)
+	w.Write([]*model.RepositoryCommitDetails{model.NewRepositoryCommitDetails(2, 3)})
+
+	assert.Nil(t, w.Close())
+	assert.Equal(t, []model.ID{1, 2, 3}, storage.written)
+}
+
+func TestCommitDetailsWriterKeepsFirstError(t *testing.T) {
+	t.Parallel()
+
+	storage := &detailsStorage{err: errors.New("failed")}
+	w := newCommitDetailsWriter(storage)
+
+	// More than the channel buffer, so it blocks if the input is not drained after the error
+	for i := 0; i < 50; i++ {
+		w.Write([]*model.RepositoryCommitDetails{model.NewRepositoryCommitDetails(1, model.ID(i))})
+	}
+
+	assert.EqualError(t, w.Close(), "failed")
+	assert.Equal(t, []model.ID{0}, storage.written)
+}
@@ -2,10 +2,13 @@ package git
 
 import (
 	"fmt"
+	"io"
 	"io/fs"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
@@ -62,6 +65,32 @@ func log(gitRepo *git.Repository, gitRevision plumbing.Hash) (object.CommitIter,
 	})
 }
 
+// forEachCommit calls f once for each commit reachable from any of the revisions
+func forEachCommit(gitRepo *git.Repository, gitRevisions []plumbing.Hash, f func(*object.Commit) error) error {
+	seen := map[plumbing.Hash]bool{}
+
+	for _, gitRevision := range gitRevisions {
+		commitsIter, err := log(gitRepo, gitRevision)
+		if err != nil {
+			return err
+		}
+
+		err = commitsIter.ForEach(func(commit *object.Commit) error {
+			if seen[commit.Hash] {
+				return nil
+			}
+			seen[commit.Hash] = true
+
+			return f(commit)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func findBranchHash(repo *model.Repository, gitRepo *git.Repository, branch string) (string, plumbing.Hash, error) {
 	if branch == "" && repo != nil {
 		branch = repo.Branch
@@ -85,3 +114,143 @@ func findBranchHash(repo *model.Repository, gitRepo *git.Repository, branch stri
 
 	return "", plumbing.ZeroHash, fmt.Errorf("%v: no branch found with name: %v", repo.Name, branch)
 }
+
+type branchRef struct {
+	Name string
+	Hash plumbing.Hash
+}
+
+// findBranches finds the local and remote branches that match the globs. Remote branches are
+// matched and named without the remote name, and local branches are preferred.
+func findBranches(gitRepo *git.Repository, globs []string) ([]*branchRef, error) {
+	if len(globs) == 0 {
+		return nil, nil
+	}
+
+	refs, err := gitRepo.References()
+	if err != nil {
+		return nil, err
+	}
+
+	local := map[string]*branchRef{}
+	remote := map[string]*branchRef{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+
+		switch {
+		case ref.Name().IsBranch():
+			name := ref.Name().Short()
+			local[name] = &branchRef{Name: name, Hash: ref.Hash()}
+
+		case ref.Name().IsRemote():
+			name := ref.Name().Short()
+			if i := strings.Index(name, "/"); i >= 0 {
+				name = name[i+1:]
+			}
+			if name != "HEAD" {
+				remote[name] = &branchRef{Name: name, Hash: ref.Hash()}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for name, b := range remote {
+		if _, ok := local[name]; !ok {
+			local[name] = b
+		}
+	}
+
+	var result []*branchRef
+	for name, b := range local {
+		for _, glob := range globs {
+			matched, err := path.Match(strings.TrimSpace(glob), name)
+			if err != nil {
+				return nil, err
+			}
+
+			if matched {
+				result = append(result, b)
+				break
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// findBranchName returns the name of the branch used to import, resolving HEAD to the current branch
+func findBranchName(gitRepo *git.Repository, branch string) string {
+	if branch != "HEAD" {
+		return branch
+	}
+
+	gitHead, err := gitRepo.Head()
+	if err != nil || !gitHead.Name().IsBranch() {
+		return branch
+	}
+
+	return gitHead.Name().Short()
+}
+
+// gitRepoPool keeps one opened repository per goroutine, because go-git repositories can't be shared between them
+type gitRepoPool struct {
+	dir   string
+	mutex sync.Mutex
+	free  []*git.Repository
+}
+
+func newGitRepoPool(dir string) *gitRepoPool {
+	return &gitRepoPool{
+		dir: dir,
+	}
+}
+
+func (p *gitRepoPool) Get() (*git.Repository, error) {
+	p.mutex.Lock()
+	if len(p.free) > 0 {
+		result := p.free[len(p.free)-1]
+		p.free = p.free[:len(p.free)-1]
+		p.mutex.Unlock()
+		return result, nil
+	}
+	p.mutex.Unlock()
+
+	return git.PlainOpen(p.dir)
+}
+
+func (p *gitRepoPool) Put(gitRepo *git.Repository) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.free = append(p.free, gitRepo)
+}
+
+// Close closes the repositories in the pool. Repositories still in use are not closed.
+func (p *gitRepoPool) Close() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var result error
+	for _, gitRepo := range p.free {
+		if c, ok := gitRepo.Storer.(io.Closer); ok {
+			err := c.Close()
+			if err != nil && result == nil {
+				result = err
+			}
+		}
+	}
+
+	p.free = nil
+
+	return result
+}
@@ -0,0 +1,39 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitRepoPool(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	_, err := git.PlainInit(dir, false)
+	assert.Nil(t, err)
+
+	pool := newGitRepoPool(dir)
+
+	a, err := pool.Get()
+	assert.Nil(t, err)
+	b, err := pool.Get()
+	assert.Nil(t, err)
+	assert.NotSame(t, a, b)
+
+	pool.Put(a)
+
+	c, err := pool.Get()
+	assert.Nil(t, err)
+	assert.Same(t, a, c)
+
+	pool.Put(b)
+	pool.Put(c)
+
+	assert.Nil(t, pool.Close())
+	assert.Empty(t, pool.free)
+
+	_, err = newGitRepoPool(t.TempDir()).Get()
+	assert.Equal(t, git.ErrRepositoryNotExists, err)
+}
@@ -3,7 +3,9 @@ package git
 import (
 	"context"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-git/go-git/v5"
@@ -11,6 +13,7 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/pkg/errors"
 	"github.com/samber/lo"
+	"github.com/schollz/progressbar/v3"
 
 	"github.com/pescuma/archer/lib/consoles"
 	"github.com/pescuma/archer/lib/ignore_rules"
@@ -28,10 +31,23 @@ type HistoryImporter struct {
 	commitsTotal    int
 	commitsImported int
 	abort           error
+
+	// mutex protects the shared model (files, projects, people and repositories list) when importing
+	// repositories in parallel, and the writes of these to the storage
+	mutex   sync.Mutex
+	details *commitDetailsWriter
+
+	// routines is the number of repositories imported at the same time. workers limits the commits processed
+	// at the same time by all of them to the same number.
+	routines int
+	workers  chan struct{}
+	// hideProgress hides the progress of each repository when they are imported in parallel
+	hideProgress bool
 }
 
 type HistoryOptions struct {
 	Branch             string
+	Branches           []string
 	Incremental        bool
 	MaxImportedCommits *int
 	MaxCommits         *int
@@ -42,9 +58,10 @@ type HistoryOptions struct {
 
 func NewHistoryImporter(console consoles.Console, storage storages.Storage) *HistoryImporter {
 	return &HistoryImporter{
-		console: console,
-		storage: storage,
-		abort:   errors.New("ABORT"),
+		console:  console,
+		storage:  storage,
+		abort:    errors.New("ABORT"),
+		routines: utils.DefaultRoutines(),
 	}
 }
 
@@ -86,11 +103,15 @@ func (i *HistoryImporter) Import(dirs []string, opts *HistoryOptions) error {
 
 	i.console.Printf("Importing and grouping authors...\n")
 
-	i.grouper, err = importPeople(configDB, peopleDB, reposDB, dirs, opts.Branch)
+	i.grouper, err = importPeople(configDB, peopleDB, reposDB, dirs, opts.Branch, opts.Branches)
 	if err != nil {
 		return err
 	}
 
+	for _, p := range peopleDB.ListPeople() {
+		p.Ignore = ignored.IgnorePerson(p)
+	}
+
 	if opts.SaveEvery != nil {
 		err = i.storage.WritePeople()
 		if err != nil {
@@ -98,60 +119,195 @@ func (i *HistoryImporter) Import(dirs []string, opts *HistoryOptions) error {
 		}
 	}
 
-	for _, dir := range dirs {
-		gitRepo, err := git.PlainOpen(dir)
-		if err != nil {
-			i.console.Printf("Skipping '%s': %s\n", dir, err)
-			continue
+	i.details = newCommitDetailsWriter(i.storage)
+	i.commitsTotal = 0
+	i.commitsImported = 0
+	i.workers = make(chan struct{}, i.routines)
+	i.hideProgress = len(dirs) > 1
+
+	group := utils.ParallelFor(dirs, func(dir string) (string, error) {
+		return dir, i.importRepo(filesDB, projectsDB, reposDB, ignored, dir, opts)
+	}, utils.ParallelOptions{Routines: i.routines})
+
+	var bar *progressbar.ProgressBar
+	if i.hideProgress {
+		bar = utils.NewProgressBar(len(dirs))
+	}
+
+	for dir := range group.Output {
+		if bar != nil {
+			bar.Describe(filepath.Base(dir))
+			_ = bar.Add(1)
 		}
+	}
+
+	err = group.Error()
+
+	detailsErr := i.details.Close()
+	if err != nil {
+		return err
+	}
+
+	return detailsErr
+}
+
+func (i *HistoryImporter) importRepo(filesDB *model.Files, projectsDB *model.Projects, reposDB *model.Repositories,
+	ignored *ignore_rules.IgnoreRules, dir string, opts *HistoryOptions,
+) error {
+	// go-git repositories can't be used by more than one goroutine, so each one gets its own from the pool
+	gitRepos := newGitRepoPool(dir)
+	defer func() {
+		_ = gitRepos.Close()
+	}()
+
+	gitRepo, err := gitRepos.Get()
+	if err != nil {
+		i.console.Printf("Skipping '%s': %s\n", dir, err)
+		return nil
+	}
+	defer gitRepos.Put(gitRepo)
+
+	i.mutex.Lock()
+	repo := reposDB.GetOrCreate(dir)
+	repo.Name = filepath.Base(dir)
+	repo.VCS = "git"
+	i.mutex.Unlock()
+
+	branch, gitRevision, err := findBranchHash(repo, gitRepo, opts.Branch)
+	if err != nil {
+		return err
+	}
 
-		repo := reposDB.GetOrCreate(dir)
-		repo.Name = filepath.Base(dir)
-		repo.VCS = "git"
+	repo.Branch = branch
 
-		branch, gitRevision, err := findBranchHash(repo, gitRepo, opts.Branch)
+	others, err := findBranches(gitRepo, opts.Branches)
+	if err != nil {
+		return err
+	}
+
+	others = lo.Filter(others, func(b *branchRef, _ int) bool { return b.Hash != gitRevision })
+
+	// Commits of the other branches that are also in the main branch are only handled once in this run
+	commitsDone := map[plumbing.Hash]bool{}
+	changesDone := map[plumbing.Hash]bool{}
+
+	mm, err := loadMailmap(dir)
+	if err != nil {
+		return err
+	}
+
+	commitsImported, err := i.importCommits(repo, ignored, gitRepo, gitRevision, mm, commitsDone, opts)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range others {
+		imported, err := i.importCommits(repo, ignored, gitRepo, b.Hash, mm, commitsDone, opts)
 		if err != nil {
 			return err
 		}
 
-		repo.Branch = branch
+		commitsImported += imported
+	}
+
+	repo.MainBranch = findBranchName(gitRepo, branch)
+	i.computeBranches(repo, gitRepo, append([]*branchRef{{Name: repo.MainBranch, Hash: gitRevision}}, others...))
+
+	repo.FilesHead, err = i.countFilesAtHEAD(gitRepo)
+	if err != nil {
+		return err
+	}
+
+	err = i.importTags(repo, gitRepo)
+	if err != nil {
+		return err
+	}
 
-		commitsImported, err := i.importCommits(repo, ignored, gitRepo, gitRevision, opts)
+	if opts.SaveEvery != nil && commitsImported > 0 {
+		err = i.writeRepository(repo)
 		if err != nil {
 			return err
 		}
+	}
+
+	err = i.importChanges(filesDB, projectsDB, repo, gitRepo, gitRepos, gitRevision, changesDone, opts)
+	if err != nil {
+		return err
+	}
 
-		repo.FilesHead, err = i.countFilesAtHEAD(gitRepo)
+	for _, b := range others {
+		err = i.importChanges(filesDB, projectsDB, repo, gitRepo, gitRepos, b.Hash, changesDone, opts)
 		if err != nil {
 			return err
 		}
+	}
 
-		if opts.SaveEvery != nil && commitsImported > 0 {
-			i.console.Printf("%v: Writing results...\n", repo.Name)
+	return nil
+}
 
-			err = i.storage.WritePeople()
-			if err != nil {
-				return err
-			}
+func (i *HistoryImporter) writeRepository(repo *model.Repository) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
 
-			err := i.storage.WriteRepository(repo)
-			if err != nil {
-				return err
-			}
+	i.console.Printf("%v: Writing results...\n", repo.Name)
 
-			err = i.storage.WritePeopleRelations()
-			if err != nil {
-				return err
-			}
+	err := i.storage.WritePeople()
+	if err != nil {
+		return err
+	}
+
+	err = i.storage.WriteRepository(repo)
+	if err != nil {
+		return err
+	}
+
+	err = i.storage.WritePeopleRelations()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// computeBranches records in each commit which of the branches contain it
+func (i *HistoryImporter) computeBranches(repo *model.Repository, gitRepo *git.Repository, branches []*branchRef) {
+	names := lo.Map(branches, func(b *branchRef, _ int) string { return b.Name })
+
+	for _, c := range repo.ListCommits() {
+		c.Branches = lo.Filter(c.Branches, func(b string, _ int) bool { return !lo.Contains(names, b) })
+	}
+
+	for _, b := range branches {
+		head := repo.GetCommit(b.Hash.String())
+		if head == nil {
+			continue
 		}
 
-		err = i.importChanges(filesDB, projectsDB, repo, gitRepo, gitRevision, opts)
-		if err != nil {
-			return err
+		visited := map[model.ID]bool{}
+		queue := []*model.RepositoryCommit{head}
+		for len(queue) > 0 {
+			c := queue[0]
+			queue = queue[1:]
+
+			if visited[c.ID] {
+				continue
+			}
+			visited[c.ID] = true
+
+			c.Branches = append(c.Branches, b.Name)
+
+			for _, p := range c.Parents {
+				// Parents may not be imported, for ex. in shallow clones or when the commits are limited
+				if parent := repo.GetCommitByID(p); parent != nil {
+					queue = append(queue, parent)
+				}
+			}
 		}
 	}
 
-	return nil
+	for _, c := range repo.ListCommits() {
+		sort.Strings(c.Branches)
+	}
 }
 
 func (i *HistoryImporter) countFilesAtHEAD(gitRepo *git.Repository) (int, error) {
@@ -182,7 +338,9 @@ func (i *HistoryImporter) countFilesAtHEAD(gitRepo *git.Repository) (int, error)
 	return result, nil
 }
 
-func (i *HistoryImporter) countCommitsToImport(repo *model.Repository, gitRepo *git.Repository, gitRevision plumbing.Hash, opts *HistoryOptions) (int, error) {
+func (i *HistoryImporter) countCommitsToImport(repo *model.Repository, gitRepo *git.Repository, gitRevision plumbing.Hash,
+	done map[plumbing.Hash]bool, opts *HistoryOptions,
+) (int, error) {
 	commitsIter, err := log(gitRepo, gitRevision)
 	if err != nil {
 		return 0, err
@@ -190,7 +348,7 @@ func (i *HistoryImporter) countCommitsToImport(repo *model.Repository, gitRepo *
 
 	imported := 0
 	err = commitsIter.ForEach(func(gitCommit *object.Commit) error {
-		if opts.Incremental && repo.ContainsCommit(gitCommit.Hash.String()) {
+		if done[gitCommit.Hash] || opts.Incremental && repo.ContainsCommit(gitCommit.Hash.String()) {
 			return nil
 		}
 
@@ -210,9 +368,11 @@ func (i *HistoryImporter) importCommits(
 	ignored *ignore_rules.IgnoreRules,
 	gitRepo *git.Repository,
 	gitRevision plumbing.Hash,
+	mm *mailmap,
+	done map[plumbing.Hash]bool,
 	opts *HistoryOptions,
 ) (int, error) {
-	imported, err := i.countCommitsToImport(repo, gitRepo, gitRevision, opts)
+	imported, err := i.countCommitsToImport(repo, gitRepo, gitRevision, done, opts)
 	if err != nil {
 		return 0, err
 	}
@@ -228,28 +388,47 @@ func (i *HistoryImporter) importCommits(
 		return 0, err
 	}
 
-	bar := utils.NewProgressBar(imported)
+	importedNow := map[plumbing.Hash]bool{}
+
+	bar := i.newProgressBar(imported)
 	err = commitsIter.ForEach(func(gitCommit *object.Commit) error {
-		if opts.Incremental && repo.ContainsCommit(gitCommit.Hash.String()) {
+		if done[gitCommit.Hash] || opts.Incremental && repo.ContainsCommit(gitCommit.Hash.String()) {
 			return nil
 		}
 
-		bar.Describe(gitCommit.Committer.When.Format("2006-01-02 15"))
+		done[gitCommit.Hash] = true
+		importedNow[gitCommit.Hash] = true
+
+		bar.Describe(repo.Name + ": " + gitCommit.Committer.When.Format("2006-01-02 15"))
 		_ = bar.Add(1)
 
-		author := i.grouper.getPerson(gitCommit.Author.Name, gitCommit.Author.Email)
-		committer := i.grouper.getPerson(gitCommit.Committer.Name, gitCommit.Committer.Email)
+		i.mutex.Lock()
+		defer i.mutex.Unlock()
+
+		author, err := i.grouper.getPerson(mm, gitCommit.Author.Name, gitCommit.Author.Email)
+		if err != nil {
+			return err
+		}
+
+		committer, err := i.grouper.getPerson(mm, gitCommit.Committer.Name, gitCommit.Committer.Email)
+		if err != nil {
+			return err
+		}
 
 		commit := repo.GetOrCreateCommit(gitCommit.Hash.String())
 		commit.Message = strings.TrimSpace(gitCommit.Message)
 		commit.Date = gitCommit.Committer.When
 		commit.CommitterID = committer.ID
 		commit.DateAuthored = gitCommit.Author.When
-		commit.AuthorIDs = append(commit.AuthorIDs, author.ID)
+		commit.AuthorIDs = []model.ID{author.ID}
 
 		coAuthors := coAuthorsRE.FindAllStringSubmatch(commit.Message, -1)
 		for _, ca := range coAuthors {
-			coAuthor := i.grouper.getPerson(ca[1], ca[2])
+			coAuthor, err := i.grouper.getPerson(mm, ca[1], ca[2])
+			if err != nil {
+				return err
+			}
+
 			commit.AuthorIDs = append(commit.AuthorIDs, coAuthor.ID)
 		}
 		// People duplicate a lot
@@ -275,14 +454,17 @@ func (i *HistoryImporter) importCommits(
 	err = commitsIter.ForEach(func(gitCommit *object.Commit) error {
 		repoCommit := repo.GetCommit(gitCommit.Hash.String())
 
-		if opts.Incremental && len(repoCommit.Parents) > 0 {
+		if !importedNow[gitCommit.Hash] && len(repoCommit.Parents) > 0 {
 			return nil
 		}
 
+		repoCommit.Parents = nil
 		err = gitCommit.Parents().ForEach(func(gitParent *object.Commit) error {
 			repoParent := repo.GetCommit(gitParent.Hash.String())
 			repoCommit.Parents = append(repoCommit.Parents, repoParent.ID)
-			repoParent.Children = append(repoParent.Children, repoCommit.ID)
+			if !lo.Contains(repoParent.Children, repoCommit.ID) {
+				repoParent.Children = append(repoParent.Children, repoCommit.ID)
+			}
 			return nil
 		})
 		if err != nil {
@@ -298,7 +480,9 @@ func (i *HistoryImporter) importCommits(
 	return imported, nil
 }
 
-func (i *HistoryImporter) listChangesToImport(repo *model.Repository, gitRepo *git.Repository, gitRevision plumbing.Hash, opts *HistoryOptions) ([]*changeWork, error) {
+func (i *HistoryImporter) listChangesToImport(repo *model.Repository, gitRepo *git.Repository, gitRevision plumbing.Hash,
+	done map[plumbing.Hash]bool, opts *HistoryOptions,
+) ([]*changeWork, error) {
 	commitsIter, err := log(gitRepo, gitRevision)
 	if err != nil {
 		return nil, err
@@ -306,22 +490,33 @@ func (i *HistoryImporter) listChangesToImport(repo *model.Repository, gitRepo *g
 
 	var result []*changeWork
 
-	imported := 0
-	total := 0
 	err = commitsIter.ForEach(func(gitCommit *object.Commit) error {
-		if !opts.ShouldContinue(i.commitsTotal+total, i.commitsImported+imported, gitCommit.Committer.When) {
+		// Already counted in another branch
+		if done[gitCommit.Hash] {
+			return nil
+		}
+
+		// The limits are shared by all the branches and repositories of the run
+		i.mutex.Lock()
+		defer i.mutex.Unlock()
+
+		if !opts.ShouldContinue(i.commitsTotal, i.commitsImported, gitCommit.Committer.When) {
 			return i.abort
 		}
-		total++
+		i.commitsTotal++
+		done[gitCommit.Hash] = true
 
 		commit := repo.GetCommit(gitCommit.Hash.String())
 
 		if opts.Incremental && commit.FilesModified != -1 {
 			return nil
 		}
-		imported++
+		i.commitsImported++
 
-		result = append(result, &changeWork{gitCommit: gitCommit})
+		result = append(result, &changeWork{
+			hash: gitCommit.Hash,
+			date: gitCommit.Committer.When,
+		})
 
 		return nil
 	})
@@ -338,16 +533,19 @@ func (i *HistoryImporter) listChangesToImport(repo *model.Repository, gitRepo *g
 }
 
 type changeWork struct {
-	gitCommit *object.Commit
-	commit    *model.RepositoryCommit
-	details   *model.RepositoryCommitDetails
+	hash    plumbing.Hash
+	date    time.Time
+	commit  *model.RepositoryCommit
+	changes *model.RepositoryCommit
+	details *model.RepositoryCommitDetails
+	err     error
 }
 
 func (i *HistoryImporter) importChanges(filesDB *model.Files, projsDB *model.Projects,
-	repo *model.Repository, gitRepo *git.Repository, gitRevision plumbing.Hash,
-	opts *HistoryOptions,
+	repo *model.Repository, gitRepo *git.Repository, gitRepos *gitRepoPool, gitRevision plumbing.Hash,
+	done map[plumbing.Hash]bool, opts *HistoryOptions,
 ) error {
-	toProcess, err := i.listChangesToImport(repo, gitRepo, gitRevision, opts)
+	toProcess, err := i.listChangesToImport(repo, gitRepo, gitRevision, done, opts)
 	if err != nil {
 		return err
 	}
@@ -359,120 +557,52 @@ func (i *HistoryImporter) importChanges(filesDB *model.Files, projsDB *model.Pro
 	i.console.Printf("%v: Importing changes...\n", repo.Name)
 
 	writeResults := func(details []*model.RepositoryCommitDetails) error {
-		i.console.Printf("%v: Writing results...\n", repo.Name)
-
-		err := i.storage.WriteFiles()
-		if err != nil {
-			return nil
-		}
-
-		err = i.storage.WriteProjects()
-		if err != nil {
-			return nil
-		}
-
-		err = i.storage.WriteRepository(repo)
-		if err != nil {
-			return err
-		}
-
-		err = i.storage.WriteRepositoryCommitDetails(details)
+		err := i.writeChanges(repo)
 		if err != nil {
 			return err
 		}
 
-		err = i.storage.WritePeopleRelations()
-		if err != nil {
-			return err
-		}
+		i.details.Write(details)
 
 		return nil
 	}
 
-	bar := utils.NewProgressBar(len(toProcess))
+	group := utils.ParallelFor(toProcess, func(w *changeWork) (*changeWork, error) {
+		i.workers <- struct{}{}
+		defer func() { <-i.workers }()
+
+		w.err = i.importCommitChanges(filesDB, repo, gitRepos, w)
+		return w, nil
+	}, utils.ParallelOptions{Routines: i.routines})
+
+	bar := i.newProgressBar(len(toProcess))
 	start := time.Now()
 	var detailsToWrite []*model.RepositoryCommitDetails
-	for _, w := range toProcess {
-		bar.Describe(w.gitCommit.Committer.When.Format("2006-01-02 15"))
-
-		err = i.importCommitChanges(filesDB, repo, w)
-		if err != nil {
-			return err
+	for w := range group.Output {
+		if w.err != nil {
+			group.Abort(w.err)
 		}
 
-		commit := w.commit
-		details := w.details
-
-		commit.FilesModified = 0
-		commit.FilesCreated = 0
-		commit.FilesDeleted = 0
-
-		if lo.SomeBy(lo.Values(commit.Files), func(i *model.RepositoryCommitFile) bool {
-			return i.LinesModified != -1
-		}) {
-			commit.LinesModified = 0
-			commit.LinesAdded = 0
-			commit.LinesDeleted = 0
-		} else {
-			commit.LinesModified = -1
-			commit.LinesAdded = -1
-			commit.LinesDeleted = -1
-		}
-
-		for _, cf := range commit.Files {
-			switch cf.Change {
-			case model.FileNotChanged:
-				// Nothing to do
-			case model.FileModified:
-				commit.FilesModified++
-			case model.FileRenamed:
-				commit.FilesModified++
-			case model.FileCreated:
-				commit.FilesCreated++
-			case model.FileDeleted:
-				commit.FilesDeleted++
-			default:
-				panic("unhandled default case")
-			}
-
-			if cf.LinesModified != -1 {
-				commit.LinesModified += cf.LinesModified
-				commit.LinesAdded += cf.LinesAdded
-				commit.LinesDeleted += cf.LinesDeleted
-			}
-
-			file := filesDB.GetByID(cf.FileID)
-			file.RepositoryID = &repo.ID
-			file.SeenAt(commit.Date, commit.DateAuthored)
-
-			if file.ProjectID != nil {
-				proj := projsDB.GetByID(*file.ProjectID)
-				proj.SeenAt(commit.Date, commit.DateAuthored)
-				proj.RepositoryID = &repo.ID
-			}
+		// Drain the queued changes without touching the model
+		if group.Aborted() {
+			continue
+		}
 
-			cfd := details.GetOrCreateFile(cf.FileID)
-			for _, of := range cfd.OldIDs {
-				oldFile := filesDB.GetByID(of)
-				oldFile.RepositoryID = &repo.ID
-				oldFile.SeenAt(commit.Date, commit.DateAuthored)
+		bar.Describe(repo.Name + ": " + w.date.Format("2006-01-02 15"))
 
-				if oldFile.ProjectID != nil {
-					proj := projsDB.GetByID(*oldFile.ProjectID)
-					proj.SeenAt(commit.Date, commit.DateAuthored)
-					proj.RepositoryID = &repo.ID
-				}
-			}
-		}
+		i.mutex.Lock()
+		i.addCommitChanges(filesDB, projsDB, repo, w)
+		i.mutex.Unlock()
 
-		detailsToWrite = append(detailsToWrite, details)
+		detailsToWrite = append(detailsToWrite, w.details)
 
 		if opts.SaveEvery != nil && time.Since(start) >= *opts.SaveEvery {
 			_ = bar.Clear()
 
 			err = writeResults(detailsToWrite)
 			if err != nil {
-				return err
+				group.Abort(err)
+				continue
 			}
 
 			detailsToWrite = nil
@@ -482,6 +612,11 @@ func (i *HistoryImporter) importChanges(filesDB *model.Files, projsDB *model.Pro
 		_ = bar.Add(1)
 	}
 
+	err = group.Error()
+	if err != nil {
+		return err
+	}
+
 	err = writeResults(detailsToWrite)
 	if err != nil {
 		return err
@@ -490,33 +625,166 @@ func (i *HistoryImporter) importChanges(filesDB *model.Files, projsDB *model.Pro
 	return nil
 }
 
-func (i *HistoryImporter) importCommitChanges(filesDB *model.Files, repo *model.Repository, w *changeWork) error {
-	commit := repo.GetCommit(w.gitCommit.Hash.String())
+func (i *HistoryImporter) newProgressBar(total int) *progressbar.ProgressBar {
+	if i.hideProgress {
+		return progressbar.DefaultSilent(int64(total))
+	}
+
+	return utils.NewProgressBar(total)
+}
+
+func (i *HistoryImporter) writeChanges(repo *model.Repository) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.console.Printf("%v: Writing results...\n", repo.Name)
+
+	err := i.storage.WriteFiles()
+	if err != nil {
+		return err
+	}
+
+	err = i.storage.WriteProjects()
+	if err != nil {
+		return err
+	}
+
+	err = i.storage.WriteRepository(repo)
+	if err != nil {
+		return err
+	}
+
+	err = i.storage.WritePeopleRelations()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// addCommitChanges copies the changes computed by importCommitChanges to the model. It must be called with the mutex locked.
+func (i *HistoryImporter) addCommitChanges(filesDB *model.Files, projsDB *model.Projects,
+	repo *model.Repository, w *changeWork,
+) {
+	commit := w.commit
+	details := w.details
+
+	for id, cf := range w.changes.Files {
+		commit.Files[id] = cf
+	}
+
+	commit.FilesModified = 0
+	commit.FilesCreated = 0
+	commit.FilesDeleted = 0
+
+	if lo.SomeBy(lo.Values(commit.Files), func(i *model.RepositoryCommitFile) bool {
+		return i.LinesModified != -1
+	}) {
+		commit.LinesModified = 0
+		commit.LinesAdded = 0
+		commit.LinesDeleted = 0
+	} else {
+		commit.LinesModified = -1
+		commit.LinesAdded = -1
+		commit.LinesDeleted = -1
+	}
+
+	for _, cf := range commit.Files {
+		switch cf.Change {
+		case model.FileNotChanged:
+			// Nothing to do
+		case model.FileModified:
+			commit.FilesModified++
+		case model.FileRenamed:
+			commit.FilesModified++
+		case model.FileCreated:
+			commit.FilesCreated++
+		case model.FileDeleted:
+			commit.FilesDeleted++
+		default:
+			panic("unhandled default case")
+		}
+
+		if cf.LinesModified != -1 {
+			commit.LinesModified += cf.LinesModified
+			commit.LinesAdded += cf.LinesAdded
+			commit.LinesDeleted += cf.LinesDeleted
+		}
+
+		file := filesDB.GetByID(cf.FileID)
+		file.RepositoryID = &repo.ID
+		file.SeenAt(commit.Date, commit.DateAuthored)
+
+		if file.ProjectID != nil {
+			proj := projsDB.GetByID(*file.ProjectID)
+			proj.SeenAt(commit.Date, commit.DateAuthored)
+			proj.RepositoryID = &repo.ID
+		}
+
+		cfd := details.GetOrCreateFile(cf.FileID)
+		for _, of := range cfd.OldIDs {
+			oldFile := filesDB.GetByID(of)
+			oldFile.RepositoryID = &repo.ID
+			oldFile.SeenAt(commit.Date, commit.DateAuthored)
+
+			if oldFile.ProjectID != nil {
+				proj := projsDB.GetByID(*oldFile.ProjectID)
+				proj.SeenAt(commit.Date, commit.DateAuthored)
+				proj.RepositoryID = &repo.ID
+			}
+		}
+	}
+}
+
+// importCommitChanges computes the changes of a commit. It runs in parallel, so it only changes w and
+// accesses the shared model through the mutex.
+func (i *HistoryImporter) importCommitChanges(filesDB *model.Files, repo *model.Repository, gitRepos *gitRepoPool, w *changeWork) error {
+	gitRepo, err := gitRepos.Get()
+	if err != nil {
+		return err
+	}
+	defer gitRepos.Put(gitRepo)
+
+	gitCommit, err := gitRepo.CommitObject(w.hash)
+	if err != nil {
+		return err
+	}
+
+	commit := repo.GetCommit(w.hash.String())
 
 	details, err := i.storage.LoadRepositoryCommitDetails(repo, commit)
 	if err != nil {
 		return err
 	}
 
+	changes := model.NewRepositoryCommit(commit.ID, commit.Hash)
+
 	if len(commit.Parents) == 0 {
-		err = i.computeChangesRootCommit(filesDB, repo, commit, details, w.gitCommit)
+		err = i.computeChangesRootCommit(filesDB, repo, changes, details, gitCommit)
 
 	} else if len(commit.Parents) == 1 {
-		err = i.computeChangesSimpleCommit(filesDB, repo, commit, details, w.gitCommit)
+		err = i.computeChangesSimpleCommit(filesDB, repo, changes, details, gitCommit)
 
 	} else if len(commit.Parents) > 1 {
-		err = i.computeChangesMergeCommit(filesDB, repo, commit, details, w.gitCommit)
+		err = i.computeChangesMergeCommit(filesDB, repo, changes, details, gitCommit)
 	}
 	if err != nil {
 		return err
 	}
 
-	w.gitCommit = nil
 	w.commit = commit
+	w.changes = changes
 	w.details = details
 	return nil
 }
 
+func (i *HistoryImporter) getOrCreateFile(filesDB *model.Files, path string) *model.File {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	return filesDB.GetOrCreate(path)
+}
+
 func (i *HistoryImporter) computeChangesMergeCommit(filesDB *model.Files, repo *model.Repository,
 	commit *model.RepositoryCommit, details *model.RepositoryCommitDetails,
 	gitCommit *object.Commit,
@@ -554,7 +822,7 @@ func (i *HistoryImporter) computeChangesMergeCommit(filesDB *model.Files, repo *
 	}
 
 	for filePath, parentCommits := range changesPerFile {
-		file := filesDB.GetOrCreate(filePath)
+		file := i.getOrCreateFile(filesDB, filePath)
 		cf := commit.GetOrCreateFile(file.ID)
 		cfd := details.GetOrCreateFile(file.ID)
 		var minChange *gitFileChange
@@ -618,7 +886,7 @@ func (i *HistoryImporter) computeChangesSimpleCommit(filesDB *model.Files, repo
 				return err
 			}
 
-			file := filesDB.GetOrCreate(filePath)
+			file := i.getOrCreateFile(filesDB, filePath)
 
 			cf := commit.GetOrCreateFile(file.ID)
 			cfd := details.GetOrCreateFile(file.ID)
@@ -656,7 +924,7 @@ func (i *HistoryImporter) fillHashesAndIDS(cfd *model.RepositoryCommitFileDetail
 			return err
 		}
 
-		oldFile := filesDB.GetOrCreate(oldFilePath)
+		oldFile := i.getOrCreateFile(filesDB, oldFilePath)
 
 		cfd.OldIDs[parentCommit.ID] = oldFile.ID
 	}
@@ -679,7 +947,7 @@ func (i *HistoryImporter) computeChangesRootCommit(filesDB *model.Files, repo *m
 			return err
 		}
 
-		file := filesDB.GetOrCreate(filePath)
+		file := i.getOrCreateFile(filesDB, filePath)
 
 		gitLines, err := gitFile.Lines()
 		if err != nil {
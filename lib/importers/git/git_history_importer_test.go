@@ -0,0 +1,239 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages/orm"
+)
+
+type testRepo struct {
+	t    *testing.T
+	dir  string
+	repo *git.Repository
+	wt   *git.Worktree
+	when time.Time
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	dir := t.TempDir()
+
+	repo, err := git.PlainInit(dir, false)
+	assert.Nil(t, err)
+	wt, err := repo.Worktree()
+	assert.Nil(t, err)
+
+	return &testRepo{
+		t:    t,
+		dir:  dir,
+		repo: repo,
+		wt:   wt,
+		when: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (r *testRepo) commit(name string, content string) string {
+	assert.Nil(r.t, os.WriteFile(filepath.Join(r.dir, name), []byte(content), 0o600))
+	_, err := r.wt.Add(name)
+	assert.Nil(r.t, err)
+
+	r.when = r.when.Add(time.Hour)
+	hash, err := r.wt.Commit(name, &git.CommitOptions{
+		Author: &object.Signature{Name: "John", Email: "john@example.com", When: r.when},
+	})
+	assert.Nil(r.t, err)
+	return hash.String()
+}
+
+func (r *testRepo) checkout(branch string, create bool) {
+	assert.Nil(r.t, r.wt.Checkout(&git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName(branch),
+		Create: create,
+	}))
+}
+
+func (r *testRepo) tag(name string) {
+	head, err := r.repo.Head()
+	assert.Nil(r.t, err)
+
+	_, err = r.repo.CreateTag(name, head.Hash(), nil)
+	assert.Nil(r.t, err)
+}
+
+func TestImportMultipleRepos(t *testing.T) {
+	t.Parallel()
+
+	var rs []*testRepo
+	for j := 0; j < 4; j++ {
+		r := newTestRepo(t)
+		for k := 0; k < 10; k++ {
+			r.commit(fmt.Sprintf("f%v.txt", k%3), fmt.Sprintf("%v %v", j, k))
+			if k%4 == 0 {
+				r.tag(fmt.Sprintf("v%v", k))
+			}
+		}
+		rs = append(rs, r)
+	}
+
+	// In memory databases are not shared between connections, and the repositories use more than one
+	storage, err := orm.NewGormStorage(orm.WithSqlite(filepath.Join(t.TempDir(), "archer.db")), consoles.NewStdOutConsole())
+	assert.Nil(t, err)
+
+	dirs := lo.Map(rs, func(r *testRepo, _ int) string { return r.dir })
+	importer := NewHistoryImporter(consoles.NewStdOutConsole(), storage)
+	importer.routines = 3
+	err = importer.Import(dirs, &HistoryOptions{Incremental: true})
+	assert.Nil(t, err)
+
+	repos, err := storage.LoadRepositories()
+	assert.Nil(t, err)
+
+	tagIDs := map[model.ID]bool{}
+	for _, r := range rs {
+		repo := repos.Get(r.dir)
+		if !assert.NotNil(t, repo) {
+			continue
+		}
+
+		assert.Equal(t, 10, repo.CountCommits())
+		for _, c := range repo.ListCommits() {
+			assert.NotEqual(t, -1, c.FilesModified)
+		}
+
+		tags := repo.ListTags()
+		assert.Equal(t, []string{"v0", "v4", "v8"}, lo.Map(tags, func(t *model.RepositoryTag, _ int) string { return t.Name }))
+		for _, tag := range tags {
+			assert.False(t, tagIDs[tag.ID])
+			tagIDs[tag.ID] = true
+		}
+	}
+}
+
+func TestImportBranchesNotIncremental(t *testing.T) {
+	t.Parallel()
+
+	r := newTestRepo(t)
+	first := r.commit("a.txt", "a")
+	r.checkout("feature", true)
+	feature := r.commit("b.txt", "b")
+	r.checkout("master", false)
+	last := r.commit("c.txt", "c")
+
+	storage, err := orm.NewGormStorage(orm.WithSqliteInMemory(), consoles.NewStdOutConsole())
+	assert.Nil(t, err)
+
+	opts := &HistoryOptions{Branches: []string{"feature"}, Incremental: false}
+	for j := 0; j < 2; j++ {
+		assert.Nil(t, NewHistoryImporter(consoles.NewStdOutConsole(), storage).Import([]string{r.dir}, opts))
+	}
+
+	repos, err := storage.LoadRepositories()
+	assert.Nil(t, err)
+
+	repo := repos.Get(r.dir)
+	assert.Equal(t, "master", repo.MainBranch)
+	assert.Equal(t, 3, repo.CountCommits())
+
+	c1 := repo.GetCommit(first)
+	assert.Len(t, c1.Children, 2)
+	assert.Equal(t, []string{"feature", "master"}, c1.Branches)
+
+	c2 := repo.GetCommit(feature)
+	assert.Len(t, c2.Parents, 1)
+	assert.Equal(t, 1, c2.FilesCreated)
+	assert.False(t, repo.InMainBranch(c2))
+
+	c3 := repo.GetCommit(last)
+	assert.Equal(t, []model.ID{c1.ID}, c3.Parents)
+	assert.True(t, repo.InMainBranch(c3))
+}
+
+func TestImportLimitSharedByBranches(t *testing.T) {
+	t.Parallel()
+
+	r := newTestRepo(t)
+	r.commit("a.txt", "a")
+	r.commit("a.txt", "aa")
+	r.checkout("feature", true)
+	r.commit("b.txt", "b")
+	r.commit("b.txt", "bb")
+	r.checkout("master", false)
+	r.commit("c.txt", "c")
+
+	storage, err := orm.NewGormStorage(orm.WithSqliteInMemory(), consoles.NewStdOutConsole())
+	assert.Nil(t, err)
+
+	limit := 3
+	opts := &HistoryOptions{Branches: []string{"feature"}, Incremental: true, MaxImportedCommits: &limit}
+	assert.Nil(t, NewHistoryImporter(consoles.NewStdOutConsole(), storage).Import([]string{r.dir}, opts))
+
+	repos, err := storage.LoadRepositories()
+	assert.Nil(t, err)
+
+	repo := repos.Get(r.dir)
+	assert.Equal(t, 5, repo.CountCommits())
+	assert.Equal(t, 3, lo.CountBy(repo.ListCommits(), func(c *model.RepositoryCommit) bool { return c.FilesModified != -1 }))
+}
+
+func TestComputeBranchesMissingParent(t *testing.T) {
+	t.Parallel()
+
+	repos := model.NewRepositories()
+	repo := repos.GetOrCreate("/repo")
+
+	hash := plumbing.NewHash("0123456789012345678901234567890123456789")
+	c := repo.GetOrCreateCommit(hash.String())
+	c.Parents = []model.ID{c.ID + 100}
+
+	i := &HistoryImporter{}
+	i.computeBranches(repo, nil, []*branchRef{{Name: "master", Hash: hash}})
+
+	assert.Equal(t, []string{"master"}, c.Branches)
+}
+
+func TestImportStopsAfterChangesError(t *testing.T) {
+	t.Parallel()
+
+	r := newTestRepo(t)
+	var hashes []string
+	for k := 0; k < 5; k++ {
+		hashes = append(hashes, r.commit("a.txt", fmt.Sprintf("%v", k)))
+	}
+
+	// Without the blob of the second commit, the changes of the second and third commits can't be computed
+	c, err := r.repo.CommitObject(plumbing.NewHash(hashes[1]))
+	assert.Nil(t, err)
+	f, err := c.File("a.txt")
+	assert.Nil(t, err)
+	blob := f.Hash.String()
+	assert.Nil(t, os.Remove(filepath.Join(r.dir, ".git", "objects", blob[:2], blob[2:])))
+
+	storage, err := orm.NewGormStorage(orm.WithSqliteInMemory(), consoles.NewStdOutConsole())
+	assert.Nil(t, err)
+
+	saveEvery := time.Duration(0)
+	importer := NewHistoryImporter(consoles.NewStdOutConsole(), storage)
+	importer.routines = 1
+	err = importer.Import([]string{r.dir}, &HistoryOptions{Incremental: true, SaveEvery: &saveEvery})
+	assert.NotNil(t, err)
+
+	repos, err := storage.LoadRepositories()
+	assert.Nil(t, err)
+
+	repo := repos.Get(r.dir)
+	assert.Equal(t, 1, repo.GetCommit(hashes[4]).FilesModified)
+	for _, h := range hashes[:3] {
+		assert.Equal(t, -1, repo.GetCommit(h).FilesModified)
+	}
+}
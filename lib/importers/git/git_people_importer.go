@@ -6,9 +6,12 @@ import (
 
 	"github.com/go-enry/go-enry/v2/regex"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/samber/lo"
 
 	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/ignore_rules"
 	"github.com/pescuma/archer/lib/model"
 	"github.com/pescuma/archer/lib/storages"
 	"github.com/pescuma/archer/lib/utils"
@@ -52,6 +55,11 @@ func (i PeopleImporter) Import(dirs []string, opts *PeopleOptions) error {
 		return err
 	}
 
+	ignored, err := ignore_rules.New(i.console, i.storage)
+	if err != nil {
+		return err
+	}
+
 	dirs, err = findRootDirs(dirs)
 	if err != nil {
 		return err
@@ -59,16 +67,20 @@ func (i PeopleImporter) Import(dirs []string, opts *PeopleOptions) error {
 
 	i.console.Printf("Importing people...\n")
 
-	_, err = importPeople(configDB, peopleDB, reposDB, dirs, opts.Branch)
+	_, err = importPeople(configDB, peopleDB, reposDB, dirs, opts.Branch, nil)
 	if err != nil {
 		return err
 	}
 
+	for _, p := range peopleDB.ListPeople() {
+		p.Ignore = ignored.IgnorePerson(p)
+	}
+
 	return nil
 }
 
 func importPeople(configDB *map[string]string, peopleDB *model.People, reposDB *model.Repositories,
-	dirs []string, branch string,
+	dirs []string, branch string, branches []string,
 ) (*nameEmailGrouper, error) {
 	grouper := newNameEmailGrouperFrom(configDB, peopleDB)
 
@@ -88,30 +100,35 @@ func importPeople(configDB *map[string]string, peopleDB *model.People, reposDB *
 			return nil, err
 		}
 
-		commitsIter, err := log(gitRepo, gitRevision)
+		mm, err := loadMailmap(dir)
 		if err != nil {
 			return nil, err
 		}
 
-		total := 0
-		err = commitsIter.ForEach(func(commit *object.Commit) error { total++; return nil })
+		others, err := findBranches(gitRepo, branches)
+		if err != nil {
+			return nil, err
+		}
+
+		revisions := append([]plumbing.Hash{gitRevision}, lo.Map(others, func(b *branchRef, _ int) plumbing.Hash { return b.Hash })...)
 
-		commitsIter, err = log(gitRepo, gitRevision)
+		total := 0
+		err = forEachCommit(gitRepo, revisions, func(commit *object.Commit) error { total++; return nil })
 		if err != nil {
 			return nil, err
 		}
 
 		bar := utils.NewProgressBar(total)
-		err = commitsIter.ForEach(func(commit *object.Commit) error {
+		err = forEachCommit(gitRepo, revisions, func(commit *object.Commit) error {
 			bar.Describe(filepath.Base(dir) + ": " + commit.Committer.When.Format("2006-01-02 15"))
 			_ = bar.Add(1)
 
-			grouper.add(commit.Author.Name, commit.Author.Email)
-			grouper.add(commit.Committer.Name, commit.Committer.Email)
+			grouper.add(mm, commit.Author.Name, commit.Author.Email)
+			grouper.add(mm, commit.Committer.Name, commit.Committer.Email)
 
 			coAuthors := coAuthorsRE.FindAllStringSubmatch(commit.Message, -1)
 			for _, ca := range coAuthors {
-				grouper.add(ca[1], ca[2])
+				grouper.add(mm, ca[1], ca[2])
 			}
 
 			return nil
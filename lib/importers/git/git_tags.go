@@ -0,0 +1,94 @@
+package git
+
+import (
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+// importTags imports annotated and lightweight tags that point to imported commits
+func (i *HistoryImporter) importTags(repo *model.Repository, gitRepo *git.Repository) error {
+	tagsIter, err := gitRepo.Tags()
+	if err != nil {
+		return err
+	}
+
+	imported := 0
+	seen := map[string]bool{}
+	err = tagsIter.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+
+		var gitCommit *object.Commit
+
+		gitTag, err := gitRepo.TagObject(ref.Hash())
+		switch err {
+		case nil:
+			gitCommit, err = gitTag.Commit()
+			if err == object.ErrUnsupportedObject {
+				// Tags of trees or blobs
+				return nil
+			} else if err != nil {
+				return err
+			}
+
+		case plumbing.ErrObjectNotFound:
+			gitTag = nil
+			gitCommit, err = gitRepo.CommitObject(ref.Hash())
+			if err == plumbing.ErrObjectNotFound {
+				return nil
+			} else if err != nil {
+				return err
+			}
+
+		default:
+			return err
+		}
+
+		commit := repo.GetCommit(gitCommit.Hash.String())
+		if commit == nil {
+			// Not in the imported branch
+			return nil
+		}
+
+		// Tag IDs are shared between the repositories imported in parallel
+		i.mutex.Lock()
+		tag := repo.GetOrCreateTag(name)
+		i.mutex.Unlock()
+
+		tag.CommitID = commit.ID
+		if gitTag != nil {
+			tag.Annotated = true
+			tag.Date = gitTag.Tagger.When
+			tag.Message = strings.TrimSpace(gitTag.Message)
+		} else {
+			tag.Annotated = false
+			tag.Date = commit.Date
+			tag.Message = ""
+		}
+
+		imported++
+		seen[name] = true
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Tags removed from the repository or moved out of the imported branch
+	for _, tag := range repo.ListTags() {
+		if !seen[tag.Name] {
+			repo.DeleteTag(tag.Name)
+		}
+	}
+
+	if imported > 0 {
+		i.console.Printf("%v: Imported %v tags\n", repo.Name, imported)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pescuma/archer/lib/utils"
+)
+
+var mailmapLineRE = regexp.MustCompile(`^([^<]*?)\s*<([^>]*)>\s*(?:([^<]*?)\s*<([^>]*)>)?$`)
+
+// mailmap maps the names and emails used in commits to the proper ones, using the format of git's .mailmap
+type mailmap struct {
+	byEmail map[string]*mailmapEntry
+	byBoth  map[string]*mailmapEntry
+}
+
+type mailmapEntry struct {
+	ProperName  string
+	ProperEmail string
+	CommitName  string
+	CommitEmail string
+}
+
+func newMailmap() *mailmap {
+	return &mailmap{
+		byEmail: map[string]*mailmapEntry{},
+		byBoth:  map[string]*mailmapEntry{},
+	}
+}
+
+func loadMailmap(dir string) (*mailmap, error) {
+	contents, err := os.ReadFile(filepath.Join(dir, ".mailmap"))
+	if os.IsNotExist(err) {
+		return newMailmap(), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return parseMailmap(string(contents)), nil
+}
+
+func parseMailmap(contents string) *mailmap {
+	result := newMailmap()
+
+	for _, line := range strings.Split(contents, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 && i > strings.LastIndex(line, ">") {
+			line = line[:i]
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		parts := mailmapLineRE.FindStringSubmatch(line)
+		if parts == nil {
+			continue
+		}
+
+		if parts[4] == "" {
+			// Proper Name <commit@email>
+			result.set(&mailmapEntry{ProperName: parts[1], CommitEmail: parts[2]})
+		} else {
+			result.set(&mailmapEntry{ProperName: parts[1], ProperEmail: parts[2], CommitName: parts[3], CommitEmail: parts[4]})
+		}
+	}
+
+	return result
+}
+
+func (m *mailmap) set(e *mailmapEntry) {
+	if e.CommitName == "" {
+		m.byEmail[m.key(e.CommitEmail)] = e
+	} else {
+		m.byBoth[m.key(e.CommitName)+"\n"+m.key(e.CommitEmail)] = e
+	}
+}
+
+// removeEmail removes all the entries that change the commit email
+func (m *mailmap) removeEmail(email string) {
+	delete(m.byEmail, m.key(email))
+
+	for k, e := range m.byBoth {
+		if m.key(e.CommitEmail) == m.key(email) {
+			delete(m.byBoth, k)
+		}
+	}
+}
+
+// resolve returns the proper name and email. The last result is true if any of them was changed.
+func (m *mailmap) resolve(name string, email string) (string, string, bool) {
+	if m == nil {
+		return name, email, false
+	}
+
+	e := m.byBoth[m.key(name)+"\n"+m.key(email)]
+	if e == nil {
+		e = m.byEmail[m.key(email)]
+	}
+	if e == nil {
+		return name, email, false
+	}
+
+	if e.ProperName != "" {
+		name = e.ProperName
+	}
+	if e.ProperEmail != "" {
+		email = e.ProperEmail
+	}
+
+	return name, email, true
+}
+
+func (m *mailmap) String() string {
+	var lines []string
+
+	for _, e := range m.byEmail {
+		lines = append(lines, e.String())
+	}
+	for _, e := range m.byBoth {
+		lines = append(lines, e.String())
+	}
+
+	sort.Strings(lines)
+
+	return strings.Join(lines, "\n")
+}
+
+func (m *mailmap) key(s string) string {
+	return strings.TrimSpace(utils.ToLowerNoAccents(s))
+}
+
+func (e *mailmapEntry) String() string {
+	var parts []string
+
+	if e.ProperName != "" {
+		parts = append(parts, e.ProperName)
+	}
+	if e.ProperEmail != "" {
+		parts = append(parts, "<"+e.ProperEmail+">")
+	}
+	if e.CommitName != "" {
+		parts = append(parts, e.CommitName)
+	}
+	parts = append(parts, "<"+e.CommitEmail+">")
+
+	return strings.Join(parts, " ")
+}
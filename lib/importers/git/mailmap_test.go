@@ -0,0 +1,122 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/bloomberg/go-testgroup"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+func TestMailmap(t *testing.T) {
+	testgroup.RunInParallel(t, &MailmapTests{})
+}
+
+type MailmapTests struct {
+}
+
+func (g *MailmapTests) ProperName(t *testgroup.T) {
+	mm := parseMailmap("Joe Developer <joe@example.com>")
+
+	name, email, changed := mm.resolve("joe", "JOE@example.com")
+
+	t.True(changed)
+	t.Equal("Joe Developer", name)
+	t.Equal("JOE@example.com", email)
+}
+
+func (g *MailmapTests) ProperEmail(t *testgroup.T) {
+	mm := parseMailmap("<joe@example.com> <joe@laptop.(none)>")
+
+	name, email, _ := mm.resolve("joe", "joe@laptop.(none)")
+
+	t.Equal("joe", name)
+	t.Equal("joe@example.com", email)
+}
+
+func (g *MailmapTests) ProperNameAndEmail(t *testgroup.T) {
+	mm := parseMailmap("# comment\nJoe Developer <joe@example.com> <joe@laptop.(none)> # other\n")
+
+	name, email, _ := mm.resolve("joe", "joe@laptop.(none)")
+
+	t.Equal("Joe Developer", name)
+	t.Equal("joe@example.com", email)
+}
+
+func (g *MailmapTests) OnlyWithCommitName(t *testgroup.T) {
+	mm := parseMailmap("Joe Developer <joe@example.com> joe <shared@example.com>")
+
+	name, email, _ := mm.resolve("Joe", "shared@example.com")
+	t.Equal("Joe Developer", name)
+	t.Equal("joe@example.com", email)
+
+	name, email, changed := mm.resolve("jane", "shared@example.com")
+	t.False(changed)
+	t.Equal("jane", name)
+	t.Equal("shared@example.com", email)
+}
+
+func (g *MailmapTests) RoundTrip(t *testgroup.T) {
+	contents := "<joe@example.com> <joe@laptop.(none)>\nJoe Developer <joe@example.com> joe <shared@example.com>"
+
+	t.Equal(contents, parseMailmap(contents).String())
+}
+
+func (g *MailmapTests) GrouperUsesRepositoryMailmap(t *testgroup.T) {
+	grouper, peopleDB := g.createGrouper(map[string]string{})
+	mm := parseMailmap("Joe Developer <joe@example.com> <jd@old.com>")
+
+	grouper.add(mm, "jd", "jd@old.com")
+	grouper.add(mm, "joe", "joe@example.com")
+	grouper.copyToPeopleDB()
+
+	people := peopleDB.ListPeople()
+	t.Equal(1, len(people))
+	t.Equal("Joe Developer", people[0].Name)
+	p, err := grouper.getPerson(mm, "jd", "jd@old.com")
+	t.Nil(err)
+	t.Equal(people[0], p)
+
+	_, err = grouper.getPerson(mm, "unknown", "unknown@example.com")
+	t.NotNil(err)
+}
+
+func (g *MailmapTests) GrouperUsesManualDecisions(t *testgroup.T) {
+	grouper, peopleDB := g.createGrouper(map[string]string{
+		"people:grouper:mailmap": "<joe@example.com> <joe@home.com>\nJoe D <joe@example.com>",
+	})
+
+	grouper.add(nil, "joe", "joe@example.com")
+	grouper.add(nil, "Joseph", "joe@home.com")
+	grouper.copyToPeopleDB()
+
+	people := peopleDB.ListPeople()
+	t.Equal(1, len(people))
+	t.Equal("Joe D", people[0].Name)
+}
+
+func (g *MailmapTests) GrouperKeepsSplitEmailsApart(t *testgroup.T) {
+	grouper, peopleDB := g.createGrouper(map[string]string{
+		"people:grouper:split-emails": "john@other.com",
+	})
+
+	grouper.add(nil, "John Smith", "john@example.com")
+	grouper.add(nil, "John Smith", "john@other.com")
+	grouper.add(nil, "John Smith", "smith@example.com")
+	grouper.copyToPeopleDB()
+
+	t.Equal(2, len(peopleDB.ListPeople()))
+
+	p1, err := grouper.getPerson(nil, "John Smith", "john@example.com")
+	t.Nil(err)
+	p2, err := grouper.getPerson(nil, "John Smith", "john@other.com")
+	t.Nil(err)
+	t.NotEqual(p1, p2)
+	t.Equal([]string{"john@example.com", "smith@example.com"}, p1.ListEmails())
+	t.Equal([]string{"john@other.com"}, p2.ListEmails())
+}
+
+func (g *MailmapTests) createGrouper(config map[string]string) (*nameEmailGrouper, *model.People) {
+	peopleDB := model.NewPeople()
+	return newNameEmailGrouperFrom(&config, peopleDB), peopleDB
+}
@@ -1,12 +1,12 @@
 package git
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/pescuma/archer/lib/utils"
 
 	"github.com/hashicorp/go-set/v2"
+	"github.com/pkg/errors"
 	"github.com/samber/lo"
 
 	"github.com/pescuma/archer/lib/model"
@@ -16,6 +16,9 @@ type nameEmailGrouper struct {
 	peopleDB      *model.People
 	auto          bool
 	ignoredEmails map[string]bool
+	splitEmails   map[string]bool
+	manual        *mailmap
+	properNames   map[string]bool
 
 	byOne   map[string]*namesEmails
 	byBoth  map[string]*namesEmails
@@ -42,6 +45,7 @@ func newNameEmailGrouperFrom(configDB *map[string]string, peopleDB *model.People
 			r.Emails.Insert(e)
 		}
 		r.people.Insert(p)
+		r.split = lo.EveryBy(emails, func(e string) bool { return grouper.splitEmails[grouper.keyOne(e)] })
 
 		grouper.store(r)
 	}
@@ -53,6 +57,8 @@ func newNameEmailGrouper(configDB *map[string]string, peopleDB *model.People) *n
 	result := &nameEmailGrouper{
 		peopleDB:      peopleDB,
 		ignoredEmails: map[string]bool{},
+		splitEmails:   map[string]bool{},
+		properNames:   map[string]bool{},
 		byOne:         map[string]*namesEmails{},
 		byBoth:        map[string]*namesEmails{},
 		removed:       set.New[*namesEmails](10),
@@ -66,14 +72,26 @@ func newNameEmailGrouper(configDB *map[string]string, peopleDB *model.People) *n
 		}
 	}
 
+	splitEmails := strings.Split((*configDB)["people:grouper:split-emails"], ",")
+	for _, e := range splitEmails {
+		e = strings.TrimSpace(e)
+		if e != "" {
+			result.splitEmails[result.keyOne(e)] = true
+		}
+	}
+
+	result.manual = parseMailmap((*configDB)["people:grouper:mailmap"])
+
 	result.auto = utils.ToBool((*configDB)["people:grouper:auto"], true)
 
 	return result
 }
 
 func (g *nameEmailGrouper) store(r *namesEmails) {
-	for _, n := range r.Names.Slice() {
-		g.byOne[g.keyOne(n)] = r
+	if !r.split {
+		for _, n := range r.Names.Slice() {
+			g.byOne[g.keyOne(n)] = r
+		}
 	}
 	for _, e := range r.Emails.Slice() {
 		e = g.keyOne(e)
@@ -92,15 +110,45 @@ func (g *nameEmailGrouper) addIgnoredEmail(email string) {
 	g.ignoredEmails[g.keyOne(email)] = true
 }
 
-func (g *nameEmailGrouper) add(name string, email string) {
+// resolve applies the repository mailmap and then the manual decisions
+func (g *nameEmailGrouper) resolve(mm *mailmap, name string, email string) (string, string) {
 	name = strings.TrimSpace(name)
 	email = strings.TrimSpace(email)
 
+	name, email, changed := mm.resolve(name, email)
+
+	// Manual decisions can point to other manual decisions (for ex. a merge into a renamed person)
+	for i := 0; i < 5; i++ {
+		var c bool
+		name, email, c = g.manual.resolve(name, email)
+		if !c {
+			break
+		}
+		changed = true
+	}
+
+	if changed && name != "" {
+		g.properNames[g.keyOne(name)] = true
+	}
+
+	return name, email
+}
+
+func (g *nameEmailGrouper) add(mm *mailmap, name string, email string) {
+	name, email = g.resolve(mm, name, email)
+
 	var r *namesEmails
 
 	isGithubEmail := strings.HasSuffix(email, "@users.noreply.github.com")
+	split := g.splitEmails[g.keyOne(email)]
+
+	if split {
+		r = g.byOne[g.keyOne(email)]
+		if r == nil {
+			r = g.byBoth[g.keyBoth(name, email)]
+		}
 
-	if !g.auto && !isGithubEmail && name != "" {
+	} else if !g.auto && !isGithubEmail && name != "" {
 		r = g.byBoth[g.keyBoth(name, email)]
 
 	} else {
@@ -136,6 +184,7 @@ func (g *nameEmailGrouper) add(name string, email string) {
 
 	if r == nil {
 		r = newNamesEmails()
+		r.split = split
 	}
 
 	if name != "" {
@@ -177,6 +226,12 @@ func (g *nameEmailGrouper) removeNamesThatAreEmails(ne *namesEmails, names []str
 
 func (g *nameEmailGrouper) findBestName(names []string) string {
 	return lo.MaxBy(names, func(a string, b string) bool {
+		properA := g.properNames[g.keyOne(a)]
+		properB := g.properNames[g.keyOne(b)]
+		if properA != properB {
+			return properA
+		}
+
 		ignoreA := utils.IsEmail(a)
 		ignoreB := utils.IsEmail(b)
 		if ignoreA != ignoreB {
@@ -206,35 +261,25 @@ func (g *nameEmailGrouper) findBestPerson(people []*model.Person, name string) *
 	}
 }
 
-func (g *nameEmailGrouper) getPerson(name string, email string) *model.Person {
-	name = strings.TrimSpace(name)
-	email = strings.TrimSpace(email)
-
-	if !g.auto {
-		key := g.keyBoth(name, email)
-
-		r := g.byBoth[key]
-		if r == nil {
-			panic(fmt.Sprintf("Could not find person for %s <%s>", name, email))
-		}
-
-		return r.Person
+func (g *nameEmailGrouper) getPerson(mm *mailmap, name string, email string) (*model.Person, error) {
+	name, email = g.resolve(mm, name, email)
 
+	var r *namesEmails
+	if g.splitEmails[g.keyOne(email)] {
+		r = g.byOne[g.keyOne(email)]
+	} else if !g.auto {
+		r = g.byBoth[g.keyBoth(name, email)]
 	} else if !g.ignoredEmails[g.keyOne(email)] {
-		e := g.byOne[g.keyOne(email)]
-		if e == nil {
-			panic(fmt.Sprintf("Could not find person for %s <%s>", name, email))
-		}
-
-		return e.Person
+		r = g.byOne[g.keyOne(email)]
 	} else {
-		n := g.byOne[g.keyOne(name)]
-		if n == nil {
-			panic(fmt.Sprintf("Could not find person for %s <%s>", name, email))
-		}
+		r = g.byOne[g.keyOne(name)]
+	}
 
-		return n.Person
+	if r == nil {
+		return nil, errors.Errorf("could not find person for %s <%s>", name, email)
 	}
+
+	return r.Person, nil
 }
 
 func (g *nameEmailGrouper) list() []*namesEmails {
@@ -265,6 +310,7 @@ type namesEmails struct {
 	Names  *set.Set[string]
 	Emails *set.Set[string]
 	people *set.Set[*model.Person]
+	split  bool
 }
 
 func newNamesEmails() *namesEmails {
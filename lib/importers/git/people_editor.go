@@ -0,0 +1,272 @@
+package git
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/filters"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages"
+)
+
+// PeopleEditor applies manual decisions about people and stores them in the config,
+// so the next imports respect them
+type PeopleEditor struct {
+	console consoles.Console
+	storage storages.Storage
+}
+
+func NewPeopleEditor(console consoles.Console, storage storages.Storage) *PeopleEditor {
+	return &PeopleEditor{
+		console: console,
+		storage: storage,
+	}
+}
+
+func (e *PeopleEditor) List(filter string) ([]*model.Person, error) {
+	peopleDB, err := e.storage.LoadPeople()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := filters.ParsePersonFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := lo.Filter(peopleDB.ListPeople(), func(p *model.Person, _ int) bool {
+		return len(p.ListEmails()) > 0 && f(p)
+	})
+
+	sort.Slice(result, func(i, j int) bool {
+		return strings.ToLower(result[i].Name) < strings.ToLower(result[j].Name)
+	})
+
+	return result, nil
+}
+
+// Merge moves all names and emails of the others people to the target one, and removes the others
+func (e *PeopleEditor) Merge(target string, others []string) error {
+	configDB, peopleDB, err := e.load()
+	if err != nil {
+		return err
+	}
+
+	t, err := e.findPerson(peopleDB, target)
+	if err != nil {
+		return err
+	}
+	if len(t.ListEmails()) == 0 {
+		return errors.Errorf("%v has no emails", e.describe(t))
+	}
+
+	manual := parseMailmap((*configDB)["people:grouper:mailmap"])
+	split := e.loadSplitEmails(configDB)
+	properEmail := t.ListEmails()[0]
+
+	for _, other := range others {
+		o, err := e.findPerson(peopleDB, other)
+		if err != nil {
+			return err
+		}
+
+		if o == t {
+			continue
+		}
+
+		e.console.Printf("Merging %v into %v\n", e.describe(o), e.describe(t))
+
+		for _, n := range o.ListNames() {
+			t.AddName(n)
+			o.RemoveName(n)
+		}
+		for _, email := range o.ListEmails() {
+			t.AddEmail(email)
+			o.RemoveEmail(email)
+
+			delete(split, strings.ToLower(email))
+			manual.removeEmail(email)
+			if !strings.EqualFold(email, properEmail) {
+				manual.set(&mailmapEntry{ProperEmail: properEmail, CommitEmail: email})
+			}
+		}
+
+		if !o.FirstSeen.IsZero() {
+			t.SeenAt(o.FirstSeen, o.LastSeen)
+		}
+
+		peopleDB.ReplacePerson(o, t)
+	}
+
+	e.store(configDB, manual, split)
+
+	return nil
+}
+
+// Split moves the emails to a new person, that will not be grouped with others by name
+func (e *PeopleEditor) Split(person string, emails []string, name string) (*model.Person, error) {
+	configDB, peopleDB, err := e.load()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := e.findPerson(peopleDB, person)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, email := range emails {
+		if !lo.Contains(p.ListEmails(), email) {
+			return nil, errors.Errorf("%v does not have the email %v", e.describe(p), email)
+		}
+	}
+	if len(emails) == len(p.ListEmails()) {
+		return nil, errors.Errorf("can't split all emails of %v", e.describe(p))
+	}
+
+	manual := parseMailmap((*configDB)["people:grouper:mailmap"])
+	split := e.loadSplitEmails(configDB)
+
+	result := peopleDB.GetOrCreatePerson(nil)
+	result.Name = lo.CoalesceOrEmpty(name, p.Name)
+	result.AddName(result.Name)
+
+	for _, email := range emails {
+		p.RemoveEmail(email)
+		result.AddEmail(email)
+
+		split[strings.ToLower(email)] = true
+		manual.removeEmail(email)
+		if name != "" {
+			manual.set(&mailmapEntry{ProperName: name, CommitEmail: email})
+		}
+	}
+
+	e.console.Printf("Created %v\n", e.describe(result))
+
+	e.store(configDB, manual, split)
+
+	return result, nil
+}
+
+// Rename sets the name of the person, and keeps it in the next imports
+func (e *PeopleEditor) Rename(person string, name string) error {
+	configDB, peopleDB, err := e.load()
+	if err != nil {
+		return err
+	}
+
+	p, err := e.findPerson(peopleDB, person)
+	if err != nil {
+		return err
+	}
+
+	e.console.Printf("Renaming %v to '%v'\n", e.describe(p), name)
+
+	manual := parseMailmap((*configDB)["people:grouper:mailmap"])
+	split := e.loadSplitEmails(configDB)
+
+	p.Name = name
+	p.AddName(name)
+
+	for _, email := range p.ListEmails() {
+		if _, properEmail, changed := manual.resolve("", email); changed && !strings.EqualFold(properEmail, email) {
+			// Merged emails already point to an email of this person
+			continue
+		}
+
+		manual.removeEmail(email)
+		manual.set(&mailmapEntry{ProperName: name, CommitEmail: email})
+	}
+
+	e.store(configDB, manual, split)
+
+	return nil
+}
+
+func (e *PeopleEditor) load() (*map[string]string, *model.People, error) {
+	configDB, err := e.storage.LoadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	peopleDB, err := e.storage.LoadPeople()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return configDB, peopleDB, nil
+}
+
+func (e *PeopleEditor) loadSplitEmails(configDB *map[string]string) map[string]bool {
+	result := map[string]bool{}
+
+	for _, email := range strings.Split((*configDB)["people:grouper:split-emails"], ",") {
+		email = strings.TrimSpace(email)
+		if email != "" {
+			result[strings.ToLower(email)] = true
+		}
+	}
+
+	return result
+}
+
+func (e *PeopleEditor) store(configDB *map[string]string, manual *mailmap, split map[string]bool) {
+	(*configDB)["people:grouper:mailmap"] = manual.String()
+
+	emails := lo.Keys(split)
+	sort.Strings(emails)
+	(*configDB)["people:grouper:split-emails"] = strings.Join(emails, ",")
+
+	e.console.Printf("Run 'archer import git history --no-incremental' and 'archer import git blame --no-incremental' " +
+		"to update already imported commits\n")
+}
+
+// findPerson finds a person by id (id:<id>), email or name
+func (e *PeopleEditor) findPerson(peopleDB *model.People, query string) (*model.Person, error) {
+	query = strings.TrimSpace(query)
+
+	if strings.HasPrefix(query, "id:") {
+		id, err := model.StringToID(query[3:])
+		if err != nil {
+			return nil, err
+		}
+
+		p := peopleDB.GetPersonByID(id)
+		if p == nil {
+			return nil, errors.Errorf("person not found: %v", query)
+		}
+
+		return p, nil
+	}
+
+	people := lo.Filter(peopleDB.ListPeople(), func(p *model.Person, _ int) bool {
+		return lo.SomeBy(p.ListEmails(), func(email string) bool { return strings.EqualFold(email, query) })
+	})
+	if len(people) == 0 {
+		people = lo.Filter(peopleDB.ListPeople(), func(p *model.Person, _ int) bool {
+			return len(p.ListEmails()) > 0 &&
+				(strings.EqualFold(p.Name, query) ||
+					lo.SomeBy(p.ListNames(), func(name string) bool { return strings.EqualFold(name, query) }))
+		})
+	}
+
+	switch len(people) {
+	case 0:
+		return nil, errors.Errorf("person not found: %v", query)
+	case 1:
+		return people[0], nil
+	default:
+		sort.Slice(people, func(i, j int) bool { return people[i].ID < people[j].ID })
+		ids := lo.Map(people, func(p *model.Person, _ int) string { return "id:" + p.ID.String() })
+		return nil, errors.Errorf("more than one person found for '%v': %v", query, strings.Join(ids, ", "))
+	}
+}
+
+func (e *PeopleEditor) describe(p *model.Person) string {
+	return "'" + p.Name + "' (id:" + p.ID.String() + ")"
+}
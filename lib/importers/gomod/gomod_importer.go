@@ -23,6 +23,7 @@ type Importer struct {
 type Options struct {
 	Groups           []string
 	RespectGitignore bool
+	Packages         bool
 }
 
 func NewImporter(console consoles.Console, storage storages.Storage) *Importer {
@@ -92,13 +93,24 @@ func (i *Importer) process(projsDB *model.Projects, filesDB *model.Files, path s
 	}
 
 	for _, req := range ast.Require {
-		i.addDep(projsDB, proj, req.Mod)
+		dep := i.addDep(projsDB, proj, req.Mod)
+		if dep != nil && req.Indirect {
+			dep.SetData("indirect", "true")
+		}
 	}
 
 	for _, rep := range ast.Replace {
 		i.addDep(projsDB, proj, rep.New)
 	}
 
+	var pkgs map[string][]string
+	if opts.Packages {
+		pkgs, err = listPackages(proj.RootDir)
+		if err != nil {
+			return err
+		}
+	}
+
 	filter, err := common.CreateFileFilter(proj.RootDir, opts.RespectGitignore,
 		func(path string) bool {
 			name := filepath.Base(path)
@@ -106,7 +118,17 @@ func (i *Importer) process(projsDB *model.Projects, filesDB *model.Files, path s
 		},
 		func(path string, isDir bool) bool {
 			name := filepath.Base(path)
-			return strings.HasPrefix(name, ".") || name == "node_modules"
+			if strings.HasPrefix(name, ".") || name == "node_modules" {
+				return true
+			}
+
+			// Packages own their files
+			if isDir && path != proj.RootDir {
+				_, ok := pkgs[path]
+				return ok
+			}
+
+			return false
 		},
 	)
 	if err != nil {
@@ -123,12 +145,19 @@ func (i *Importer) process(projsDB *model.Projects, filesDB *model.Files, path s
 		return err
 	}
 
+	if opts.Packages {
+		err = i.importPackages(projsDB, filesDB, proj, pkgs, opts)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (i *Importer) addDep(projsDB *model.Projects, proj *model.Project, mod module.Version) {
+func (i *Importer) addDep(projsDB *model.Projects, proj *model.Project, mod module.Version) *model.ProjectDependency {
 	if mod.Path == "" {
-		return
+		return nil
 	}
 
 	dp := projsDB.GetOrCreate(mod.Path)
@@ -137,4 +166,6 @@ func (i *Importer) addDep(projsDB *model.Projects, proj *model.Project, mod modu
 	if mod.Version != "" {
 		dep.Versions.Insert(strings.TrimPrefix(mod.Version, "v"))
 	}
+
+	return dep
 }
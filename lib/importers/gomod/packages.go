@@ -0,0 +1,185 @@
+package gomod
+
+import (
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/importers/common"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/utils"
+)
+
+// listPackages returns the dirs of the packages inside a module, with the .go files of each one.
+// Nested modules, vendor and testdata are not part of the module.
+func listPackages(rootDir string) (map[string][]string, error) {
+	result := map[string][]string{}
+
+	err := filepath.WalkDir(rootDir, func(path string, entry fs.DirEntry, err error) error {
+		switch {
+		case err != nil:
+			return nil
+
+		case entry.IsDir():
+			if path == rootDir {
+				return nil
+			}
+
+			name := entry.Name()
+			if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || utils.In(name, "vendor", "testdata", "node_modules") {
+				return filepath.SkipDir
+			}
+
+			exists, err := utils.FileExists(filepath.Join(path, "go.mod"))
+			if err != nil {
+				return err
+			}
+			if exists {
+				return filepath.SkipDir
+			}
+
+			return nil
+
+		case strings.HasSuffix(entry.Name(), ".go"):
+			dir := filepath.Dir(path)
+			result[dir] = append(result[dir], path)
+			return nil
+
+		default:
+			return nil
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// importPackages creates one project for each package of the module, grouped under it, with the dependencies
+// between them. The root package is represented by the module project itself.
+func (i *Importer) importPackages(projsDB *model.Projects, filesDB *model.Files, mod *model.Project, pkgs map[string][]string,
+	opts *Options,
+) error {
+	pkgProjs := map[string]*model.Project{}
+	pkgProjs[mod.RootDir] = mod
+
+	dirs := lo.Keys(pkgs)
+	sort.Strings(dirs)
+
+	for _, dir := range dirs {
+		if dir == mod.RootDir {
+			continue
+		}
+
+		rel, err := filepath.Rel(mod.RootDir, dir)
+		if err != nil {
+			return err
+		}
+
+		proj := projsDB.GetOrCreate(mod.Name + "/" + filepath.ToSlash(rel))
+		proj.Groups = append(lo.Filter(opts.Groups, func(g string, _ int) bool { return g != "" }), mod.Name)
+		proj.Type = model.CodeType
+		proj.RootDir = dir
+		proj.ProjectFile = mod.ProjectFile
+		proj.RepositoryID = mod.RepositoryID
+		proj.Dependencies = make(map[string]*model.ProjectDependency)
+		proj.SeenAt(time.Now())
+
+		pkgProjs[dir] = proj
+	}
+
+	for _, dir := range dirs {
+		proj := pkgProjs[dir]
+
+		imports := map[string]int{}
+
+		for _, file := range pkgs[dir] {
+			// Tests can import packages that would create cycles
+			if strings.HasSuffix(file, "_test.go") {
+				continue
+			}
+
+			ast, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.ImportsOnly)
+			if err != nil {
+				i.console.Printf("Error parsing %v: %v\n", file, err)
+				continue
+			}
+
+			for _, imp := range ast.Imports {
+				path, err := strconv.Unquote(imp.Path.Value)
+				if err != nil {
+					continue
+				}
+
+				imports[path]++
+			}
+		}
+
+		for path, count := range imports {
+			var target *model.Project
+			if path == mod.Name {
+				target = mod
+			} else if rel, ok := strings.CutPrefix(path, mod.Name+"/"); ok {
+				target = pkgProjs[filepath.Join(mod.RootDir, filepath.FromSlash(rel))]
+			}
+
+			if target == nil || target == proj {
+				continue
+			}
+
+			dep := proj.GetOrCreateDependency(target)
+			dep.SetData("imports", strconv.Itoa(count))
+		}
+
+		if proj == mod {
+			continue
+		}
+
+		pkgDir := proj.GetDirectory(".")
+		pkgDir.Type = model.SourceDir
+		pkgDir.SeenAt(time.Now())
+
+		filter, err := common.CreateFileFilter(proj.RootDir, opts.RespectGitignore,
+			func(path string) bool {
+				return strings.HasSuffix(path, ".go")
+			},
+			func(path string, isDir bool) bool {
+				name := filepath.Base(path)
+				if strings.HasPrefix(name, ".") || name == "node_modules" {
+					return true
+				}
+
+				// Other packages own their files
+				if isDir {
+					_, ok := pkgs[path]
+					return ok
+				}
+
+				return false
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		err = common.MarkDeletedFilesAndUnmarkExistingOnes(filesDB, proj, pkgDir, filter)
+		if err != nil {
+			return err
+		}
+
+		err = common.AddFiles(filesDB, proj, pkgDir, filter)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
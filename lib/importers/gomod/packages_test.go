@@ -0,0 +1,83 @@
+package gomod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/model"
+)
+
+func TestPackages(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "go.mod"), `module example.com/svc
+
+go 1.21
+
+require (
+	github.com/pkg/errors v0.9.1
+	golang.org/x/sys v0.1.0 // indirect
+)
+`)
+	writeFile(t, filepath.Join(dir, "main.go"), `package main
+
+import (
+	"fmt"
+
+	"example.com/svc/api"
+)
+
+func main() { fmt.Println(api.X) }
+`)
+	writeFile(t, filepath.Join(dir, "api", "api.go"), `package api
+
+import "example.com/svc/internal/db"
+
+var X = db.Y
+`)
+	writeFile(t, filepath.Join(dir, "api", "handlers.go"), `package api
+
+import _ "example.com/svc/internal/db"
+`)
+	writeFile(t, filepath.Join(dir, "api", "api_test.go"), `package api
+
+import _ "example.com/svc"
+`)
+	writeFile(t, filepath.Join(dir, "internal", "db", "db.go"), "package db\n\nvar Y = 1\n")
+	writeFile(t, filepath.Join(dir, "other", "go.mod"), "module example.com/other\n")
+	writeFile(t, filepath.Join(dir, "other", "other.go"), "package other\n")
+
+	projsDB := model.NewProjects()
+	filesDB := model.NewFiles()
+	importer := NewImporter(consoles.NewStdOutConsole(), nil)
+	assert.Nil(t, importer.process(projsDB, filesDB, filepath.Join(dir, "go.mod"), &Options{Packages: true}))
+
+	mod := projsDB.GetOrCreate("example.com/svc")
+	assert.Equal(t, "1", mod.Dependencies["example.com/svc/api"].GetData("imports"))
+	assert.Equal(t, "", mod.Dependencies["github.com/pkg/errors"].GetData("indirect"))
+	assert.Equal(t, "true", mod.Dependencies["golang.org/x/sys"].GetData("indirect"))
+
+	api := projsDB.GetOrCreate("example.com/svc/api")
+	assert.Equal(t, model.CodeType, api.Type)
+	assert.Equal(t, []string{"example.com/svc"}, api.Groups)
+	assert.Equal(t, "2", api.Dependencies["example.com/svc/internal/db"].GetData("imports"))
+	assert.Nil(t, api.Dependencies["example.com/svc"])
+
+	db := projsDB.GetOrCreate("example.com/svc/internal/db")
+	assert.Empty(t, db.Dependencies)
+
+	assert.Len(t, projsDB.ListProjects(model.FilterAll), 5)
+	assert.Equal(t, api.ID, *filesDB.Get(filepath.Join(dir, "api", "api.go")).ProjectID)
+	assert.Equal(t, mod.ID, *filesDB.Get(filepath.Join(dir, "main.go")).ProjectID)
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+}
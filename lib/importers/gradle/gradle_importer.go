@@ -24,6 +24,12 @@ type Importer struct {
 	storage storages.Storage
 }
 
+type Options struct {
+	// Static parses settings and build scripts instead of running gradlew. It is a lot faster, but transitive
+	// dependencies are not imported.
+	Static bool
+}
+
 func NewImporter(console consoles.Console, storage storages.Storage) *Importer {
 	return &Importer{
 		console: console,
@@ -31,7 +37,7 @@ func NewImporter(console consoles.Console, storage storages.Storage) *Importer {
 	}
 }
 
-func (i *Importer) Import(rootDir string) error {
+func (i *Importer) Import(rootDir string, opts *Options) error {
 	projsDB, err := i.storage.LoadProjects()
 	if err != nil {
 		return err
@@ -44,7 +50,12 @@ func (i *Importer) Import(rootDir string) error {
 
 	fmt.Printf("Listing projects...\n")
 
-	queue, err := listProjects(rootDir)
+	var queue []string
+	if opts.Static {
+		queue, err = listProjectsStatic(rootDir)
+	} else {
+		queue, err = listProjects(rootDir)
+	}
 	if err != nil {
 		return err
 	}
@@ -79,18 +90,26 @@ func (i *Importer) Import(rootDir string) error {
 
 	fmt.Printf("Importing dependencies from %v projects...\n", len(queue))
 
-	bar = utils.NewProgressBar(len(queue))
-	block := 100
-	projsInsideRoot := lo.Associate(queue, func(s string) (string, bool) { return s, true })
-	for j := 0; j < len(queue); j += block {
-		piece := utils.Take(queue[j:], block)
-
-		err = i.loadDependencies(rootDir, projsDB, piece, rootProj, projsInsideRoot)
+	if opts.Static {
+		err = i.loadDependenciesStatic(rootDir, projsDB, queue, rootProj)
 		if err != nil {
 			return err
 		}
 
-		_ = bar.Add(len(piece))
+	} else {
+		bar = utils.NewProgressBar(len(queue))
+		block := 100
+		projsInsideRoot := lo.Associate(queue, func(s string) (string, bool) { return s, true })
+		for j := 0; j < len(queue); j += block {
+			piece := utils.Take(queue[j:], block)
+
+			err = i.loadDependencies(rootDir, projsDB, piece, rootProj, projsInsideRoot)
+			if err != nil {
+				return err
+			}
+
+			_ = bar.Add(len(piece))
+		}
 	}
 
 	for _, p := range queue {
@@ -243,7 +262,7 @@ func (i *Importer) getProjectFile(rootDir, projName string) (string, error) {
 	}
 
 	for _, e := range entries {
-		if strings.HasPrefix(e.Name(), "build.gradle.") {
+		if e.Name() == "build.gradle" || e.Name() == "build.gradle.kts" {
 			return utils.PathAbs(dir, e.Name())
 		}
 	}
@@ -284,6 +303,98 @@ func (i *Importer) loadDependencies(rootDir string, projs *model.Projects, projN
 	return nil
 }
 
+func (i *Importer) loadDependenciesStatic(rootDir string, projs *model.Projects, projNamesToImport []string, rootProj string) error {
+	catalog, err := loadVersionCatalog(rootDir)
+	if err != nil {
+		return err
+	}
+
+	rootVars := map[string]string{}
+	catalog.AddVersionsTo(rootVars)
+
+	data, err := os.ReadFile(filepath.Join(rootDir, "gradle.properties"))
+	if err == nil {
+		parseProperties(rootVars, string(data))
+	}
+
+	rootFile := projs.GetOrCreate(rootProj).ProjectFile
+	if rootFile != "" {
+		data, err = os.ReadFile(rootFile)
+		if err != nil {
+			return err
+		}
+
+		parseVariables(rootVars, string(data))
+	}
+
+	accessors := createProjectAccessors(projNamesToImport)
+
+	bar := utils.NewProgressBar(len(projNamesToImport))
+	for _, projName := range projNamesToImport {
+		proj := projs.GetOrCreate(projName)
+		proj.Dependencies = make(map[string]*model.ProjectDependency)
+
+		if proj.ProjectFile != "" {
+			data, err = os.ReadFile(proj.ProjectFile)
+			if err != nil {
+				return err
+			}
+
+			vars := lo.Assign(rootVars)
+			parseVariables(vars, string(data))
+
+			for _, sd := range parseBuildDependencies(string(data)) {
+				switch {
+				case sd.Project != "":
+					name := utils.IIf(sd.Project == ":", rootProj, sd.Project)
+					proj.GetOrCreateDependency(projs.GetOrCreate(name))
+
+				case sd.Accessor != "":
+					name, ok := accessors[strings.ToLower(sd.Accessor)]
+					if !ok {
+						fmt.Printf("Unknown project accessor in %v: projects.%v\n", proj.ProjectFile, sd.Accessor)
+						continue
+					}
+
+					proj.GetOrCreateDependency(projs.GetOrCreate(name))
+
+				case sd.Catalog != "":
+					libs := catalog.Get(sd.Catalog)
+					if len(libs) == 0 {
+						fmt.Printf("Unknown version catalog entry in %v: libs.%v\n", proj.ProjectFile, sd.Catalog)
+						continue
+					}
+
+					for _, lib := range libs {
+						i.addStaticLibDep(projs, proj, vars, lib.Module, lib.Version)
+					}
+
+				default:
+					i.addStaticLibDep(projs, proj, vars, sd.Module, sd.Version)
+				}
+			}
+		}
+
+		_ = bar.Add(1)
+	}
+
+	return nil
+}
+
+func (i *Importer) addStaticLibDep(projs *model.Projects, proj *model.Project, vars map[string]string, module string, version string) {
+	module = resolveVariables(vars, module)
+	if strings.HasPrefix(module, ":") || strings.HasSuffix(module, ":") {
+		return
+	}
+
+	dep := proj.GetOrCreateDependency(projs.GetOrCreate(module))
+
+	version = resolveVariables(vars, version)
+	if version != "" && !strings.Contains(version, "$") {
+		dep.Versions.Insert(version)
+	}
+}
+
 func splitOutputPerTarget(output string) map[string]string {
 	result := map[string]string{}
 
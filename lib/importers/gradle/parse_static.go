@@ -0,0 +1,442 @@
+package gradle
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/utils"
+)
+
+// compileClasspathConfigurations are the configurations that end up in compileClasspath, which is the one used when
+// loading dependencies from gradlew
+var compileClasspathConfigurations = map[string]bool{
+	"api":            true,
+	"implementation": true,
+	"compileOnly":    true,
+	"compileOnlyApi": true,
+	"compile":        true,
+}
+
+func listProjectsStatic(rootDir string) ([]string, error) {
+	var content string
+	for _, name := range []string{"settings.gradle.kts", "settings.gradle"} {
+		data, err := os.ReadFile(filepath.Join(rootDir, name))
+		if err == nil {
+			content = string(data)
+			break
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	rootName, includes := parseSettings(content)
+	if rootName == "" {
+		rootName = filepath.Base(rootDir)
+	}
+
+	return append([]string{rootName}, includes...), nil
+}
+
+func parseSettings(content string) (string, []string) {
+	content = removeComments(content)
+
+	rootName := ""
+	m := regexp.MustCompile(`rootProject\.name\s*=\s*["']([^"']+)["']`).FindStringSubmatch(content)
+	if m != nil {
+		rootName = m[1]
+	}
+
+	includeRE := regexp.MustCompile(`(?s)\binclude\s*\(([^)]*)\)|\binclude\s+([^\n]+)`)
+	stringRE := regexp.MustCompile(`["']([^"']+)["']`)
+
+	projs := map[string]bool{}
+	for _, im := range includeRE.FindAllStringSubmatch(content, -1) {
+		for _, sm := range stringRE.FindAllStringSubmatch(im[1]+im[2], -1) {
+			name := sm[1]
+			if !strings.HasPrefix(name, ":") {
+				name = ":" + name
+			}
+
+			// Including :a:b also creates :a
+			parts := strings.Split(name[1:], ":")
+			for i := range parts {
+				projs[":"+strings.Join(parts[:i+1], ":")] = true
+			}
+		}
+	}
+
+	includes := lo.Keys(projs)
+	sort.Strings(includes)
+
+	return rootName, includes
+}
+
+type staticDependency struct {
+	Configuration string
+	Project       string
+	Accessor      string
+	Catalog       string
+	Module        string
+	Version       string
+}
+
+func parseBuildDependencies(content string) []*staticDependency {
+	content = removeComments(content)
+
+	statementRE := regexp.MustCompile(`^\s*(\w+)\s*[( ]\s*(.*)$`)
+	projectRE := regexp.MustCompile(`\bproject\s*\(\s*(?:path\s*[:=]\s*)?["']([^"']+)["']`)
+	accessorRE := regexp.MustCompile(`\bprojects\.([\w.]+)`)
+	catalogRE := regexp.MustCompile(`\blibs\.([\w.]+)`)
+	kotlinRE := regexp.MustCompile(`\bkotlin\s*\(\s*["']([^"']+)["']\s*(?:,\s*["']([^"']+)["'])?`)
+	moduleRE := regexp.MustCompile(`["']([^"':\s]+):([^"':\s]+)(?::([^"'\s@:]+))?(?::[^"'\s@]+)?(?:@\w+)?["']`)
+	groupRE := regexp.MustCompile(`\bgroup\s*[:=]\s*["']([^"']+)["']`)
+	nameRE := regexp.MustCompile(`\bname\s*[:=]\s*["']([^"']+)["']`)
+	versionRE := regexp.MustCompile(`\bversion\s*[:=]\s*["']([^"']+)["']`)
+
+	var result []*staticDependency
+
+	for _, statement := range splitStatements(content) {
+		lm := statementRE.FindStringSubmatch(statement)
+		if lm == nil {
+			continue
+		}
+
+		conf := lm[1]
+		args := lm[2]
+
+		if !compileClasspathConfigurations[conf] {
+			continue
+		}
+
+		dep := &staticDependency{Configuration: conf}
+
+		if m := projectRE.FindStringSubmatch(args); m != nil {
+			dep.Project = m[1]
+
+		} else if m := accessorRE.FindStringSubmatch(args); m != nil {
+			dep.Accessor = m[1]
+
+		} else if m := kotlinRE.FindStringSubmatch(args); m != nil {
+			dep.Module = "org.jetbrains.kotlin:kotlin-" + m[1]
+			dep.Version = m[2]
+
+		} else if m := moduleRE.FindStringSubmatch(args); m != nil {
+			dep.Module = m[1] + ":" + m[2]
+			dep.Version = m[3]
+
+		} else if m := catalogRE.FindStringSubmatch(args); m != nil {
+			dep.Catalog = m[1]
+
+		} else if g, n := groupRE.FindStringSubmatch(args), nameRE.FindStringSubmatch(args); g != nil && n != nil {
+			dep.Module = g[1] + ":" + n[1]
+			if v := versionRE.FindStringSubmatch(args); v != nil {
+				dep.Version = v[1]
+			}
+
+		} else {
+			continue
+		}
+
+		result = append(result, dep)
+	}
+
+	return result
+}
+
+// splitStatements splits the content in lines, ; and blocks, ignoring the ones inside strings
+func splitStatements(content string) []string {
+	var result []string
+
+	start := 0
+	var quote byte
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote || c == '\n' {
+				quote = 0
+			}
+
+		case c == '"' || c == '\'':
+			quote = c
+
+		case c == '\n' || c == ';' || c == '{' || c == '}':
+			result = append(result, content[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(result, content[start:])
+}
+
+func parseVariables(vars map[string]string, content string) {
+	content = removeComments(content)
+
+	assignRE := regexp.MustCompile(`(?m)^\s*(?:val\s+|var\s+|def\s+|ext\.|extra\.)?(\w+)\s*(?::\s*\w+)?\s*=\s*["']([^"'$]*)["']`)
+	extraRE := regexp.MustCompile(`(?m)^\s*extra\[\s*"(\w+)"\s*]\s*=\s*"([^"$]*)"`)
+
+	for _, m := range assignRE.FindAllStringSubmatch(content, -1) {
+		vars[m[1]] = m[2]
+	}
+	for _, m := range extraRE.FindAllStringSubmatch(content, -1) {
+		vars[m[1]] = m[2]
+	}
+}
+
+func parseProperties(vars map[string]string, content string) {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		vars[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+}
+
+var variableRE = regexp.MustCompile(`\$\{?([\w.]+)}?`)
+
+func resolveVariables(vars map[string]string, s string) string {
+	return variableRE.ReplaceAllStringFunc(s, func(m string) string {
+		name := variableRE.FindStringSubmatch(m)[1]
+		name = strings.TrimPrefix(name, "project.")
+		name = strings.TrimPrefix(name, "rootProject.")
+		name = strings.TrimSuffix(name, ".get")
+		if strings.HasPrefix(name, "libs.") {
+			name = normalizeCatalogAlias(name)
+		}
+
+		v, ok := vars[name]
+		if !ok {
+			return m
+		}
+		return v
+	})
+}
+
+// removeComments removes // and /* */ comments, ignoring the ones inside strings
+func removeComments(content string) string {
+	var sb strings.Builder
+
+	var quote byte
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+
+		switch {
+		case quote != 0:
+			if c == '\\' && i+1 < len(content) {
+				sb.WriteByte(c)
+				i++
+				c = content[i]
+			} else if c == quote || c == '\n' {
+				quote = 0
+			}
+
+		case c == '"' || c == '\'':
+			quote = c
+
+		case c == '/' && i+1 < len(content) && content[i+1] == '/':
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+			if i < len(content) {
+				sb.WriteByte('\n')
+			}
+			continue
+
+		case c == '/' && i+1 < len(content) && content[i+1] == '*':
+			end := strings.Index(content[i+2:], "*/")
+			if end == -1 {
+				return sb.String()
+			}
+			sb.WriteString(strings.Repeat("\n", strings.Count(content[i:i+2+end], "\n")))
+			i += 2 + end + 1
+			continue
+		}
+
+		sb.WriteByte(c)
+	}
+
+	return sb.String()
+}
+
+type versionCatalog struct {
+	versions  map[string]string
+	libraries map[string]*catalogLibrary
+	bundles   map[string][]string
+}
+
+type catalogLibrary struct {
+	Module  string
+	Version string
+}
+
+func newVersionCatalog() *versionCatalog {
+	return &versionCatalog{
+		versions:  map[string]string{},
+		libraries: map[string]*catalogLibrary{},
+		bundles:   map[string][]string{},
+	}
+}
+
+func loadVersionCatalog(rootDir string) (*versionCatalog, error) {
+	data, err := os.ReadFile(filepath.Join(rootDir, "gradle", "libs.versions.toml"))
+	if os.IsNotExist(err) {
+		return newVersionCatalog(), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return parseVersionCatalog(data)
+}
+
+func parseVersionCatalog(data []byte) (*versionCatalog, error) {
+	var content struct {
+		Versions  map[string]any      `toml:"versions"`
+		Libraries map[string]any      `toml:"libraries"`
+		Bundles   map[string][]string `toml:"bundles"`
+	}
+
+	err := toml.Unmarshal(data, &content)
+	if err != nil {
+		return nil, err
+	}
+
+	result := newVersionCatalog()
+
+	for k, v := range content.Versions {
+		result.versions[k] = catalogVersion(nil, v)
+	}
+
+	for alias, v := range content.Libraries {
+		lib := &catalogLibrary{}
+
+		switch v := v.(type) {
+		case string:
+			parts := strings.Split(v, ":")
+			if len(parts) < 2 {
+				continue
+			}
+
+			lib.Module = parts[0] + ":" + parts[1]
+			if len(parts) > 2 {
+				lib.Version = parts[2]
+			}
+
+		case map[string]any:
+			if module, ok := v["module"].(string); ok {
+				lib.Module = module
+			} else {
+				group, _ := v["group"].(string)
+				name, _ := v["name"].(string)
+				lib.Module = group + ":" + name
+			}
+
+			lib.Version = catalogVersion(result.versions, v["version"])
+
+		default:
+			continue
+		}
+
+		result.libraries[normalizeCatalogAlias(alias)] = lib
+	}
+
+	for alias, libs := range content.Bundles {
+		result.bundles[normalizeCatalogAlias(alias)] = lo.Map(libs, func(l string, _ int) string {
+			return normalizeCatalogAlias(l)
+		})
+	}
+
+	return result, nil
+}
+
+func catalogVersion(versions map[string]string, v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case map[string]any:
+		if ref, ok := v["ref"].(string); ok {
+			return versions[ref]
+		}
+		for _, k := range []string{"strictly", "require", "prefer"} {
+			if s, ok := v[k].(string); ok {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// Get returns the libraries referenced by an accessor, like libs.some.lib or libs.bundles.some.bundle
+func (c *versionCatalog) Get(accessor string) []*catalogLibrary {
+	accessor = normalizeCatalogAlias(strings.TrimSuffix(accessor, ".get"))
+
+	if strings.HasPrefix(accessor, "bundles.") {
+		return lo.FilterMap(c.bundles[strings.TrimPrefix(accessor, "bundles.")], func(alias string, _ int) (*catalogLibrary, bool) {
+			lib, ok := c.libraries[alias]
+			return lib, ok
+		})
+	}
+
+	lib, ok := c.libraries[accessor]
+	if !ok {
+		return nil
+	}
+
+	return []*catalogLibrary{lib}
+}
+
+// AddVersionsTo adds the catalog versions as variables, so they can be referenced as libs.versions.name
+func (c *versionCatalog) AddVersionsTo(vars map[string]string) {
+	for k, v := range c.versions {
+		vars["libs.versions."+normalizeCatalogAlias(k)] = v
+	}
+}
+
+func normalizeCatalogAlias(alias string) string {
+	alias = strings.ReplaceAll(alias, "-", ".")
+	alias = strings.ReplaceAll(alias, "_", ".")
+	return strings.ToLower(alias)
+}
+
+// createProjectAccessors returns the type-safe project accessors (projects.someProject.child) of each project
+func createProjectAccessors(projNames []string) map[string]string {
+	result := map[string]string{}
+
+	for _, name := range projNames {
+		if !strings.HasPrefix(name, ":") {
+			continue
+		}
+
+		parts := strings.Split(name[1:], ":")
+		for i, p := range parts {
+			parts[i] = toCamelCase(p)
+		}
+
+		result[strings.ToLower(strings.Join(parts, "."))] = name
+	}
+
+	return result
+}
+
+func toCamelCase(s string) string {
+	parts := strings.FieldsFunc(s, func(r rune) bool { return r == '-' || r == '_' })
+	for i := 1; i < len(parts); i++ {
+		parts[i] = utils.FirstUpper(parts[i])
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,174 @@
+package gradle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/model"
+)
+
+func TestParseSettings(t *testing.T) {
+	t.Parallel()
+
+	root, includes := parseSettings(`
+rootProject.name = "my-root"
+
+// include(":commented")
+include(":a", ":b:c")
+include 'd'
+include(
+    ":e",
+    ":f"
+)
+`)
+
+	assert.Equal(t, "my-root", root)
+	assert.Equal(t, []string{":a", ":b", ":b:c", ":d", ":e", ":f"}, includes)
+}
+
+func TestParseBuildDependencies(t *testing.T) {
+	t.Parallel()
+
+	deps := parseBuildDependencies(`
+dependencies {
+    implementation(project(":a"))
+    api project(path: ':b')
+    implementation(projects.someLib)
+    implementation(libs.guava)
+    compileOnly("org.slf4j:slf4j-api:$slf4jVersion")
+    implementation group: 'com.x', name: 'y', version: '1.0'
+    implementation(kotlin("stdlib"))
+    testImplementation("junit:junit:4.13")
+    // implementation("commented:out:1.0")
+}
+`)
+
+	assert.Len(t, deps, 7)
+	assert.Equal(t, ":a", deps[0].Project)
+	assert.Equal(t, ":b", deps[1].Project)
+	assert.Equal(t, "someLib", deps[2].Accessor)
+	assert.Equal(t, "guava", deps[3].Catalog)
+	assert.Equal(t, "org.slf4j:slf4j-api", deps[4].Module)
+	assert.Equal(t, "$slf4jVersion", deps[4].Version)
+	assert.Equal(t, "com.x:y", deps[5].Module)
+	assert.Equal(t, "1.0", deps[5].Version)
+	assert.Equal(t, "org.jetbrains.kotlin:kotlin-stdlib", deps[6].Module)
+}
+
+func TestParseBuildDependenciesInSameLine(t *testing.T) {
+	t.Parallel()
+
+	deps := parseBuildDependencies(`
+dependencies { implementation(project(":a")) }
+dependencies {
+    api(project(":b")); implementation("com.x:y:1.0") { exclude(group = "com.z") }; compileOnly(libs.lombok)
+    implementation("com.x:z:${zVersion}")
+}
+`)
+
+	assert.Len(t, deps, 5)
+	assert.Equal(t, ":a", deps[0].Project)
+	assert.Equal(t, ":b", deps[1].Project)
+	assert.Equal(t, "com.x:y", deps[2].Module)
+	assert.Equal(t, "1.0", deps[2].Version)
+	assert.Equal(t, "lombok", deps[3].Catalog)
+	assert.Equal(t, "com.x:z", deps[4].Module)
+	assert.Equal(t, "${zVersion}", deps[4].Version)
+}
+
+func TestParseVersionCatalog(t *testing.T) {
+	t.Parallel()
+
+	catalog, err := parseVersionCatalog([]byte(`
+[versions]
+guava = "32.0.0"
+
+[libraries]
+guava = { module = "com.google.guava:guava", version.ref = "guava" }
+slf4j-api = "org.slf4j:slf4j-api:2.0.7"
+jackson-core = { group = "com.fasterxml.jackson.core", name = "jackson-core", version = "2.15.0" }
+
+[bundles]
+logging = ["slf4j-api"]
+`))
+	assert.Nil(t, err)
+
+	libs := catalog.Get("guava")
+	assert.Len(t, libs, 1)
+	assert.Equal(t, "com.google.guava:guava", libs[0].Module)
+	assert.Equal(t, "32.0.0", libs[0].Version)
+
+	libs = catalog.Get("jackson.core")
+	assert.Len(t, libs, 1)
+	assert.Equal(t, "com.fasterxml.jackson.core:jackson-core", libs[0].Module)
+
+	libs = catalog.Get("bundles.logging")
+	assert.Len(t, libs, 1)
+	assert.Equal(t, "2.0.7", libs[0].Version)
+}
+
+func TestCreateProjectAccessors(t *testing.T) {
+	t.Parallel()
+
+	accessors := createProjectAccessors([]string{"root", ":some-lib", ":a:b_c"})
+
+	assert.Equal(t, ":some-lib", accessors["somelib"])
+	assert.Equal(t, ":a:b_c", accessors["a.bc"])
+}
+
+func TestImportStaticGroovy(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "settings.gradle"), `
+rootProject.name = 'root'
+include 'a', 'b'
+`)
+	writeFile(t, filepath.Join(dir, "build.gradle"), `
+ext.guavaVersion = '32.0.0'
+`)
+	writeFile(t, filepath.Join(dir, "a", "build.gradle"), `
+dependencies {
+    implementation project(':b')
+    implementation "com.google.guava:guava:$guavaVersion"
+}
+`)
+	writeFile(t, filepath.Join(dir, "b", "build.gradle.kts"), `
+dependencies {
+    implementation("org.slf4j:slf4j-api:2.0.7")
+}
+`)
+
+	queue, err := listProjectsStatic(dir)
+	assert.Nil(t, err)
+
+	projsDB := model.NewProjects()
+	filesDB := model.NewFiles()
+	importer := NewImporter(consoles.NewStdOutConsole(), nil)
+	for _, p := range queue {
+		assert.Nil(t, importer.importBasicInfo(dir, projsDB, filesDB, p, queue[0]))
+	}
+
+	a := projsDB.GetOrCreate(":a")
+	assert.Equal(t, filepath.Join(dir, "a", "build.gradle"), a.ProjectFile)
+	assert.Equal(t, filepath.Join(dir, "b", "build.gradle.kts"), projsDB.GetOrCreate(":b").ProjectFile)
+
+	stale := a.GetOrCreateDependency(projsDB.GetOrCreate("removed:lib"))
+	assert.NotNil(t, stale)
+
+	assert.Nil(t, importer.loadDependenciesStatic(dir, projsDB, queue, queue[0]))
+
+	assert.NotNil(t, a.Dependencies[":b"])
+	assert.Equal(t, []string{"32.0.0"}, a.Dependencies["com.google.guava:guava"].Versions.Slice())
+	assert.Nil(t, a.Dependencies["removed:lib"])
+	assert.NotNil(t, projsDB.GetOrCreate(":b").Dependencies["org.slf4j:slf4j-api"])
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+}
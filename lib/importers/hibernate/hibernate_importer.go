@@ -75,7 +75,7 @@ func (i *Importer) Import(rootDirs, globs []string, opts *Options) error {
 			if strings.Contains(path, "/.idea/") {
 				return nil
 			}
-			if !strings.HasSuffix(path, ".kt") {
+			if !strings.HasSuffix(path, ".kt") && !strings.HasSuffix(path, ".java") {
 				return nil
 			}
 
@@ -103,7 +103,35 @@ func (i *Importer) Import(rootDirs, globs []string, opts *Options) error {
 
 	fmt.Printf("Importing tables from hibernate from %v files...\n", len(files))
 
-	err = kotlin.ProcessFiles(lo.Keys(files),
+	var kotlinFiles []string
+	for path, file := range files {
+		if strings.HasSuffix(path, ".kt") {
+			kotlinFiles = append(kotlinFiles, path)
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			file.file.Exists = false
+			continue
+		} else if err != nil {
+			i.console.Printf("Error reading file %v: %v\n", path, err)
+			continue
+		}
+
+		l := newTreeListener(file.fileName, file.root)
+		l.IncreasePrefix()
+
+		walkJava(string(content), l)
+
+		l.DecreasePrefix()
+
+		file.classes = l.Classes
+		file.errors = l.Errors
+		file.file.Data[lastModifiedKey] = file.modTime
+	}
+
+	err = kotlin.ProcessFiles(kotlinFiles,
 		func(path string, content kotlin_parser.IKotlinFileContext) error {
 			file := files[path]
 
@@ -153,6 +181,8 @@ func (i *Importer) Import(rootDirs, globs []string, opts *Options) error {
 				ov.Paths = append(ov.Paths, nv.Paths...)
 				ov.Tables = append(ov.Tables, nv.Tables...)
 				ov.Dependencies = append(ov.Dependencies, nv.Dependencies...)
+				ov.Columns = append(ov.Columns, nv.Columns...)
+				ov.ChildColumns = append(ov.ChildColumns, nv.ChildColumns...)
 			}
 		}
 
@@ -180,6 +210,14 @@ func (i *Importer) Import(rootDirs, globs []string, opts *Options) error {
 		proj.Type = model.DatabaseType
 		proj.ProjectFile = c.Paths[0]
 
+		proj.ClearCodeColumns()
+		for _, ci := range c.Columns {
+			col := proj.GetColumn(ci.Name)
+			col.InCode = true
+			col.CodeType = ci.Type
+			col.CodeNullable = ci.Nullable
+		}
+
 		if root.Dir != nil {
 			proj.RootDir = *root.Dir
 		} else {
@@ -218,6 +256,25 @@ func (i *Importer) Import(rootDirs, globs []string, opts *Options) error {
 		}
 	}
 
+	// Done after all tables had their code columns cleared
+	for _, c := range classes {
+		for _, cc := range c.ChildColumns {
+			dc, ok := classes[cc.ClassName]
+			if !ok || len(dc.Tables) != 1 {
+				fmt.Printf("ERROR: %v has a join column in %v, which is not associated with one table. Ignoring!\n", c.Name, cc.ClassName)
+				continue
+			}
+
+			dp := projectsDB.GetOrCreate(dc.Tables[0])
+			dbProjs[dp] = true
+
+			col := dp.GetColumn(cc.Column.Name)
+			col.InCode = true
+			col.CodeType = cc.Column.Type
+			col.CodeNullable = cc.Column.Nullable
+		}
+	}
+
 	common.CreateTableNameParts(lo.Keys(dbProjs))
 
 	return nil
@@ -229,12 +286,21 @@ type treeListener struct {
 	currentPath      string
 	currentClassName []string
 	currentClass     []*classInfo
+	implicitTable    *classInfo // Class with a table named after the @Entity, replaced by a later @Table
 	insideFunction   int
 	insideProperty   bool
 
-	hasColumnAnnotation bool
-	hasLazyAnnotation   bool
-	currentVariableType string
+	hasColumnAnnotation   bool
+	hasLazyAnnotation     bool
+	hasRelationAnnotation bool
+	hasOneToMany          bool
+	isTransient           bool
+	currentVariableType   string
+
+	hasDataColumn  bool
+	columnName     string
+	columnNullable bool
+	columnID       bool
 
 	sb     strings.Builder
 	prefix string
@@ -249,6 +315,8 @@ type classInfo struct {
 	Paths        []string
 	Tables       []string
 	Dependencies []*dependencyInfo
+	Columns      []*columnInfo
+	ChildColumns []*childColumnInfo
 }
 
 type dependencyInfo struct {
@@ -256,8 +324,22 @@ type dependencyInfo struct {
 	Lazy      bool
 }
 
+type columnInfo struct {
+	Name     string
+	Type     string
+	Nullable bool
+}
+
+// childColumnInfo is a join column of a @OneToMany relation, which lives in the table of the child class
+type childColumnInfo struct {
+	ClassName string
+	Column    *columnInfo
+}
+
 var tableRE = regexp.MustCompile(`name\s*=\s*"([^'"]+)"`)
 var genericContainerRE = regexp.MustCompile(`Of<([^>]+)>\(`)
+var lazyRE = regexp.MustCompile(`fetch\s*=\s*(?:FetchType\.)?LAZY\b`)
+var notNullableRE = regexp.MustCompile(`nullable\s*=\s*false\b`)
 
 func newTreeListener(path string, root common.RootDir) *treeListener {
 	return &treeListener{
@@ -280,7 +362,24 @@ func (l *treeListener) DecreasePrefix() {
 }
 
 func (l *treeListener) EnterClassDeclaration(ctx *kotlin_parser.ClassDeclarationContext) {
-	name := ctx.SimpleIdentifier().GetText()
+	l.enterClass(ctx.SimpleIdentifier().GetText())
+}
+
+func (l *treeListener) ExitClassDeclaration(_ *kotlin_parser.ClassDeclarationContext) {
+	l.exitClass()
+}
+
+// Companion objects are handled as classes, so their properties are not taken as columns of the entity
+
+func (l *treeListener) EnterCompanionObject(_ *kotlin_parser.CompanionObjectContext) {
+	l.enterClass("Companion")
+}
+
+func (l *treeListener) ExitCompanionObject(_ *kotlin_parser.CompanionObjectContext) {
+	l.exitClass()
+}
+
+func (l *treeListener) enterClass(name string) {
 	if len(l.currentClassName) > 0 {
 		name = utils.Last(l.currentClassName) + "." + name
 	}
@@ -292,7 +391,7 @@ func (l *treeListener) EnterClassDeclaration(ctx *kotlin_parser.ClassDeclaration
 	l.IncreasePrefix()
 }
 
-func (l *treeListener) ExitClassDeclaration(_ *kotlin_parser.ClassDeclarationContext) {
+func (l *treeListener) exitClass() {
 	l.DecreasePrefix()
 
 	l.currentClassName = utils.RemoveLast(l.currentClassName)
@@ -308,14 +407,13 @@ func (l *treeListener) ExitFunctionDeclaration(_ *kotlin_parser.FunctionDeclarat
 }
 
 func (l *treeListener) EnterPropertyDeclaration(ctx *kotlin_parser.PropertyDeclarationContext) {
-	if l.insideFunction > 0 || len(l.currentClass) == 0 || utils.Last(l.currentClass) == nil {
+	if l.insideFunction > 0 {
 		return
 	}
 
-	l.insideProperty = true
-	l.hasColumnAnnotation = false
-	l.hasLazyAnnotation = false
-	l.currentVariableType = ""
+	if !l.enterProperty() {
+		return
+	}
 
 	if ctx.VariableDeclaration() == nil {
 		panic(fmt.Sprintf("Only supported one variable per property declaration (in %v %v)",
@@ -327,11 +425,6 @@ func (l *treeListener) ExitPropertyDeclaration(ctx *kotlin_parser.PropertyDeclar
 	if !l.insideProperty {
 		return
 	}
-	l.insideProperty = false
-
-	if !l.hasColumnAnnotation {
-		return
-	}
 
 	if l.currentVariableType == "" {
 		if ctx.Expression() != nil {
@@ -343,17 +436,69 @@ func (l *treeListener) ExitPropertyDeclaration(ctx *kotlin_parser.PropertyDeclar
 		}
 	}
 
-	l.currentVariableType = cleanTypeName(l.currentVariableType)
+	l.exitProperty(ctx.VariableDeclaration().GetText())
+}
 
-	varDecl := ctx.VariableDeclaration()
+// enterProperty returns false if the property is not inside an entity class
+func (l *treeListener) enterProperty() bool {
+	if len(l.currentClass) == 0 || utils.Last(l.currentClass) == nil {
+		return false
+	}
 
-	l.printfln("found field %v", varDecl.GetText())
+	l.insideProperty = true
+	l.hasColumnAnnotation = false
+	l.hasLazyAnnotation = false
+	l.hasRelationAnnotation = false
+	l.hasOneToMany = false
+	l.isTransient = false
+	l.currentVariableType = ""
+	l.hasDataColumn = false
+	l.columnName = ""
+	l.columnNullable = true
+	l.columnID = false
+
+	return true
+}
+
+func (l *treeListener) exitProperty(varDecl string) {
+	l.insideProperty = false
+
+	if l.isTransient {
+		return
+	}
+
+	fieldName := strings.Trim(strings.SplitN(varDecl, ":", 2)[0], "`")
+
+	if l.hasDataColumn {
+		name := l.columnName
+		if name == "" {
+			name = fieldName
+		}
+
+		if l.hasOneToMany && l.hasColumnAnnotation {
+			l.addChildColumn(cleanTypeName(l.currentVariableType), name, l.columnNullable)
+		} else {
+			l.addColumn(name, l.currentVariableType, l.columnNullable && !l.columnID)
+		}
+
+	} else if !l.hasRelationAnnotation {
+		// Fields without annotations are mapped to columns with the same name
+		l.addColumn(fieldName, l.currentVariableType, true)
+	}
+
+	if !l.hasColumnAnnotation {
+		return
+	}
+
+	l.currentVariableType = cleanTypeName(l.currentVariableType)
+
+	l.printfln("found field %v", varDecl)
 	l.IncreasePrefix()
 
 	if l.currentVariableType == "" {
 		l.printfln("could not find type of field")
 		l.Errors = append(l.Errors, fmt.Sprintf("Could not find type of field %v %v %v",
-			l.currentPath, utils.Last(l.currentClassName), varDecl.GetText()))
+			l.currentPath, utils.Last(l.currentClassName), varDecl))
 
 	} else {
 		l.addDependency(l.currentVariableType, l.hasLazyAnnotation)
@@ -375,20 +520,63 @@ func (l *treeListener) EnterUnescapedAnnotation(ctx *kotlin_parser.UnescapedAnno
 		parts = append(parts, "")
 	}
 
-	if parts[0] == "Table" {
-		ms := tableRE.FindStringSubmatch(parts[1])
+	l.onAnnotation(parts[0], parts[1])
+}
+
+func (l *treeListener) onAnnotation(name string, args string) {
+	if name == "Entity" {
+		l.addEntity(args)
+
+	} else if name == "Table" {
+		ms := tableRE.FindStringSubmatch(args)
 		if ms != nil {
-			l.addTable(ms[1])
+			if cls := l.implicitTable; cls != nil && cls == utils.Last(l.currentClass) {
+				l.printfln("replacing table: %v", ms[1])
+				cls.Tables[len(cls.Tables)-1] = ms[1]
+				l.implicitTable = nil
+			} else {
+				l.addTable(ms[1])
+			}
+		}
+
+	} else if name == "Column" || name == "JoinColumn" {
+		l.hasColumnAnnotation = l.hasColumnAnnotation || name == "JoinColumn"
+		l.hasDataColumn = true
+
+		if ms := tableRE.FindStringSubmatch(args); ms != nil {
+			l.columnName = ms[1]
 		}
+		l.columnNullable = !notNullableRE.MatchString(args)
 
-	} else if parts[0] == "JoinColumn" {
-		l.hasColumnAnnotation = true
+	} else if name == "Id" {
+		l.hasDataColumn = true
+		l.columnID = true
 
-	} else if parts[0] == "ManyToOne" || parts[0] == "OneToMany" || parts[0] == "OneToOne" {
-		l.hasLazyAnnotation = strings.Index(parts[1], "FetchType.LAZY") >= 0
+	} else if name == "ManyToOne" || name == "OneToMany" || name == "OneToOne" || name == "ManyToMany" {
+		l.hasRelationAnnotation = true
+		l.hasOneToMany = l.hasOneToMany || name == "OneToMany"
+		l.hasLazyAnnotation = lazyRE.MatchString(args)
+
+	} else if name == "Transient" {
+		l.isTransient = true
 	}
 }
 
+// addEntity adds the table of an entity without @Table, which is named after the entity
+func (l *treeListener) addEntity(args string) {
+	if len(l.currentClass) == 0 || utils.Last(l.currentClass) != nil {
+		return
+	}
+
+	name := utils.Last(strings.Split(utils.Last(l.currentClassName), "."))
+	if ms := tableRE.FindStringSubmatch(args); ms != nil {
+		name = ms[1]
+	}
+
+	l.addTable(name)
+	l.implicitTable = utils.Last(l.currentClass)
+}
+
 func (l *treeListener) addTable(tableName string) {
 	l.printfln("adding table: %v", tableName)
 
@@ -410,6 +598,33 @@ func (l *treeListener) addDependency(dependencyTypeName string, lazy bool) {
 	})
 }
 
+func (l *treeListener) addColumn(name string, typeName string, nullable bool) {
+	l.printfln("adding column: %v %v%v", name, typeName, utils.IIf(nullable, "", " not null"))
+
+	cls := utils.Last(l.currentClass)
+	cls.Columns = append(cls.Columns, &columnInfo{
+		Name:     name,
+		Type:     typeName,
+		Nullable: nullable,
+	})
+}
+
+func (l *treeListener) addChildColumn(className string, name string, nullable bool) {
+	typeName := utils.Last(strings.Split(utils.Last(l.currentClassName), "."))
+
+	l.printfln("adding column to %v: %v %v%v", className, name, typeName, utils.IIf(nullable, "", " not null"))
+
+	cls := utils.Last(l.currentClass)
+	cls.ChildColumns = append(cls.ChildColumns, &childColumnInfo{
+		ClassName: className,
+		Column: &columnInfo{
+			Name:     name,
+			Type:     typeName,
+			Nullable: nullable,
+		},
+	})
+}
+
 func (l *treeListener) getClass(name string) *classInfo {
 	result, ok := l.Classes[name]
 
@@ -437,7 +652,7 @@ func cleanTypeName(t string) string {
 		t1 := matches[1]
 		t2 := matches[2]
 
-		if t1 == "MutableList" || t1 == "ListRepositories" || t1 == "MutableSet" || t1 == "Set" {
+		if t1 == "MutableList" || t1 == "ListRepositories" || t1 == "MutableSet" || t1 == "Set" || t1 == "List" || t1 == "Collection" {
 			t = t2
 
 		} else {
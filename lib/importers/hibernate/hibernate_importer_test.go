@@ -3,7 +3,11 @@ package hibernate
 import (
 	"testing"
 
+	"github.com/antlr/antlr4/runtime/Go/antlr/v4"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/common"
+	"github.com/pescuma/archer/lib/languages/kotlin_parser"
 )
 
 func TestCleanTypeName(t *testing.T) {
@@ -23,4 +27,82 @@ func TestCleanTypeName(t *testing.T) {
 	assert.Equal(t, "Abc", cleanTypeName("MutableList<Abc?>"))
 	assert.Equal(t, "Abc", cleanTypeName("Set<Abc?>"))
 	assert.Equal(t, "Abc", cleanTypeName("MutableSet<Abc?>"))
+	assert.Equal(t, "Abc", cleanTypeName("List<Abc>"))
+	assert.Equal(t, "Abc", cleanTypeName("Collection<Abc>"))
+}
+
+func TestEntityTablesJavaAndKotlin(t *testing.T) {
+	t.Parallel()
+
+	tables := func(l *treeListener) map[string][]string {
+		result := map[string][]string{}
+		for name, c := range l.Classes {
+			result[name] = c.Tables
+		}
+		return result
+	}
+
+	java := newTreeListener("Model.java", common.RootDir{})
+	walkJava(`
+package com.example;
+
+@Entity
+public class Customer {
+    private String name;
+}
+
+@Entity(name = "orders")
+public class Order {
+}
+
+@Entity
+@Table(name = "invoice")
+public class Invoice {
+}
+
+@Table(name = "payment")
+@Entity
+public class Payment {
+}
+
+public class NotAnEntity {
+    private String name;
+}
+`, java)
+
+	kotlin := newTreeListener("Model.kt", common.RootDir{})
+	input := antlr.NewInputStream(`
+package com.example
+
+@Entity
+class Customer {
+    var name: String? = null
+}
+
+@Entity(name = "orders")
+class Order
+
+@Entity
+@Table(name = "invoice")
+class Invoice
+
+@Table(name = "payment")
+@Entity
+class Payment
+
+class NotAnEntity {
+    var name: String? = null
+}
+`)
+	parser := kotlin_parser.NewKotlinParser(antlr.NewCommonTokenStream(kotlin_parser.NewKotlinLexer(input), 0))
+	antlr.NewParseTreeWalker().Walk(kotlin, parser.KotlinFile())
+
+	expected := map[string][]string{
+		"Customer": {"Customer"},
+		"Order":    {"orders"},
+		"Invoice":  {"invoice"},
+		"Payment":  {"payment"},
+	}
+	assert.Equal(t, expected, tables(java))
+	assert.Equal(t, expected, tables(kotlin))
 }
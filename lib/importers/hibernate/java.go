@@ -0,0 +1,260 @@
+package hibernate
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/samber/lo"
+)
+
+type javaToken struct {
+	text string
+	pos  int
+}
+
+// walkJava feeds the declarations of a Java file to the listener. It is not a full parser: it only understands
+// classes, annotations and fields, which is all that is needed to find the entities and their relations.
+func walkJava(content string, l *treeListener) {
+	tokens := tokenizeJava(content)
+
+	type annotation struct {
+		name string
+		args string
+	}
+
+	var pending []annotation
+	var classDepths []int
+	var member []string
+	sawParen := false
+	sawEquals := false
+	depth := 0
+
+	atClassLevel := func() bool {
+		if len(classDepths) == 0 {
+			return depth == 0
+		}
+		return depth == classDepths[len(classDepths)-1]
+	}
+	resetMember := func() {
+		member = nil
+		sawParen = false
+		sawEquals = false
+		pending = nil
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i].text
+
+		switch {
+		case t == "{":
+			if atClassLevel() && !sawEquals {
+				resetMember()
+			}
+			depth++
+
+		case t == "}":
+			if len(classDepths) > 0 && classDepths[len(classDepths)-1] == depth {
+				classDepths = classDepths[:len(classDepths)-1]
+				l.exitClass()
+			}
+			depth--
+			if atClassLevel() {
+				resetMember()
+			}
+
+		case !atClassLevel() || sawEquals && t != ";":
+			// Inside methods or field initializers
+
+		case t == "@" && i+1 < len(tokens) && tokens[i+1].text != "interface":
+			name := tokens[i+1].text
+			i += 2
+			for i+1 < len(tokens) && tokens[i].text == "." {
+				name = tokens[i+1].text
+				i += 2
+			}
+
+			args := ""
+			if i < len(tokens) && tokens[i].text == "(" {
+				end := matchingParen(tokens, i)
+				if end < len(tokens) {
+					args = content[tokens[i].pos+1 : tokens[end].pos]
+				}
+				i = end
+			} else {
+				i--
+			}
+
+			pending = append(pending, annotation{name, args})
+
+		case isJavaClassKeyword(t) && i+1 < len(tokens) && isJavaIdentifier(tokens[i+1].text) &&
+			(i == 0 || tokens[i-1].text != "."):
+			name := tokens[i+1].text
+			l.enterClass(name)
+
+			for _, a := range pending {
+				l.onAnnotation(a.name, a.args)
+			}
+
+			// Skip to the class body
+			i += 2
+			for i < len(tokens) && tokens[i].text != "{" {
+				i++
+			}
+			depth++
+			classDepths = append(classDepths, depth)
+			resetMember()
+
+		case t == "=":
+			sawEquals = true
+
+		case t == ";":
+			// Static and transient fields are not persisted
+			if len(member) > 1 && !sawParen && !lo.Contains(member, "static") && !lo.Contains(member, "transient") {
+				if l.enterProperty() {
+					for _, a := range pending {
+						l.onAnnotation(a.name, a.args)
+					}
+					l.currentVariableType = javaFieldType(member[:len(member)-1])
+					l.exitProperty(member[len(member)-1])
+				}
+			}
+			resetMember()
+
+		default:
+			if t == "(" {
+				sawParen = true
+			}
+			member = append(member, t)
+		}
+	}
+}
+
+var javaModifiers = map[string]bool{
+	"public":    true,
+	"protected": true,
+	"private":   true,
+	"static":    true,
+	"final":     true,
+	"transient": true,
+	"volatile":  true,
+}
+
+var javaPackagePrefixRE = regexp.MustCompile(`(?:\b[a-z_$][\w$]*\.)+`)
+
+// javaFieldType removes the modifiers, package names and array markers from a field type
+func javaFieldType(tokens []string) string {
+	var sb strings.Builder
+	for _, t := range tokens {
+		if javaModifiers[t] {
+			continue
+		}
+		sb.WriteString(t)
+	}
+
+	result := javaPackagePrefixRE.ReplaceAllString(sb.String(), "")
+	result = strings.ReplaceAll(result, "[]", "")
+	return result
+}
+
+func isJavaClassKeyword(t string) bool {
+	return t == "class" || t == "interface" || t == "enum" || t == "record"
+}
+
+func isJavaIdentifier(t string) bool {
+	if t == "" {
+		return false
+	}
+	for _, r := range t {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '$' {
+			return false
+		}
+	}
+	return !unicode.IsDigit(rune(t[0]))
+}
+
+func matchingParen(tokens []javaToken, start int) int {
+	level := 0
+	for i := start; i < len(tokens); i++ {
+		switch tokens[i].text {
+		case "(":
+			level++
+		case ")":
+			level--
+			if level == 0 {
+				return i
+			}
+		}
+	}
+	return len(tokens)
+}
+
+// tokenizeJava splits the content in identifiers, literals and symbols, ignoring whitespace and comments
+func tokenizeJava(content string) []javaToken {
+	var result []javaToken
+
+	for i := 0; i < len(content); {
+		c := content[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			i++
+
+		case strings.HasPrefix(content[i:], "//"):
+			end := strings.IndexByte(content[i:], '\n')
+			if end == -1 {
+				i = len(content)
+			} else {
+				i += end + 1
+			}
+
+		case strings.HasPrefix(content[i:], "/*"):
+			end := strings.Index(content[i+2:], "*/")
+			if end == -1 {
+				i = len(content)
+			} else {
+				i += 2 + end + 2
+			}
+
+		case strings.HasPrefix(content[i:], `"""`):
+			end := strings.Index(content[i+3:], `"""`)
+			start := i
+			if end == -1 {
+				i = len(content)
+			} else {
+				i += 3 + end + 3
+			}
+			result = append(result, javaToken{content[start:i], start})
+
+		case c == '"' || c == '\'':
+			start := i
+			i++
+			for i < len(content) && content[i] != c && content[i] != '\n' {
+				if content[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			i = min(i+1, len(content))
+			result = append(result, javaToken{content[start:i], start})
+
+		case c == '_' || c == '$' || c >= 0x80 || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c)):
+			start := i
+			for i < len(content) {
+				c = content[i]
+				if c == '_' || c == '$' || c >= 0x80 || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c)) {
+					i++
+				} else {
+					break
+				}
+			}
+			result = append(result, javaToken{content[start:i], start})
+
+		default:
+			result = append(result, javaToken{content[i : i+1], i})
+			i++
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,98 @@
+package hibernate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/common"
+)
+
+func TestWalkJava(t *testing.T) {
+	t.Parallel()
+
+	l := newTreeListener("Order.java", common.RootDir{})
+	l.IncreasePrefix()
+
+	walkJava(`
+package com.example;
+
+import javax.persistence.*;
+import static javax.persistence.FetchType.LAZY;
+
+/** Orders { of a customer */
+@Entity
+@Table(name = "orders")
+public class Order {
+    private static final long serialVersionUID = 1L;
+
+    @Id
+    private Long id;
+
+    private String code;
+
+    private transient int hash;
+
+    @Transient
+    private String display;
+
+    @OneToMany(mappedBy = "order")
+    private Set<Payment> payments;
+
+    @ManyToOne(fetch = LAZY)
+    @JoinColumn(name = "customer_id")
+    private Customer customer;
+
+    @OneToMany
+    @JoinColumn(name = "order_id", nullable = false)
+    private java.util.List<com.example.OrderItem> items = new ArrayList<>();
+
+    @Column(name = "note", nullable = false)
+    private String note = "}";
+
+    public Customer getCustomer() {
+        Customer c = customer;
+        return c;
+    }
+
+    @Entity
+    public static class Audit {
+        @ManyToOne(fetch = FetchType.EAGER)
+        @JoinColumn(name = "order_id")
+        private Order order;
+    }
+}
+`, l)
+
+	l.DecreasePrefix()
+
+	order := l.Classes["Order"]
+	if assert.NotNil(t, order) {
+		assert.Equal(t, []string{"orders"}, order.Tables)
+		assert.Equal(t, []string{"Order.java"}, order.Paths)
+		assert.Equal(t, []*dependencyInfo{
+			{ClassName: "Customer", Lazy: true},
+			{ClassName: "OrderItem", Lazy: false},
+		}, order.Dependencies)
+		assert.Equal(t, []*columnInfo{
+			{Name: "id", Type: "Long", Nullable: false},
+			{Name: "code", Type: "String", Nullable: true},
+			{Name: "customer_id", Type: "Customer", Nullable: true},
+			{Name: "note", Type: "String", Nullable: false},
+		}, order.Columns)
+		assert.Equal(t, []*childColumnInfo{
+			{ClassName: "OrderItem", Column: &columnInfo{Name: "order_id", Type: "Order", Nullable: false}},
+		}, order.ChildColumns)
+	}
+
+	audit := l.Classes["Order.Audit"]
+	if assert.NotNil(t, audit) {
+		assert.Equal(t, []string{"Audit"}, audit.Tables)
+		assert.Equal(t, []*dependencyInfo{
+			{ClassName: "Order", Lazy: false},
+		}, audit.Dependencies)
+	}
+
+	assert.Len(t, l.Classes, 2)
+	assert.Empty(t, l.Errors)
+}
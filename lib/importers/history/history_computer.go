@@ -101,7 +101,8 @@ func (c *Computer) Compute() error {
 		files := make(map[*model.File]bool)
 
 		for _, commit := range repo.ListCommits() {
-			if commit.Ignore {
+			// Commits only in other branches could be cherry-picked to the main one, so they are not counted
+			if commit.Ignore || !repo.InMainBranch(commit) || c.authorsIgnored(peopleDB, commit) {
 				continue
 			}
 
@@ -209,3 +210,18 @@ func (c *Computer) Compute() error {
 
 	return nil
 }
+
+// authorsIgnored returns true if all the authors of the commit are ignored (for ex. bots)
+func (c *Computer) authorsIgnored(peopleDB *model.People, commit *model.RepositoryCommit) bool {
+	if len(commit.AuthorIDs) == 0 {
+		return false
+	}
+
+	for _, a := range commit.AuthorIDs {
+		if !peopleDB.GetPersonByID(a).Ignore {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,294 @@
+package imports
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/samber/lo"
+	"github.com/schollz/progressbar/v3"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/languages/kotlin"
+	"github.com/pescuma/archer/lib/languages/kotlin_parser"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages"
+)
+
+type Computer struct {
+	console consoles.Console
+	storage storages.Storage
+}
+
+func NewComputer(console consoles.Console, storage storages.Storage) *Computer {
+	return &Computer{
+		console: console,
+		storage: storage,
+	}
+}
+
+// sourceFile has the imports found in one file
+type sourceFile struct {
+	file     *model.File
+	proj     *model.Project
+	language string
+	pkg      string
+	imports  []string
+}
+
+type usage struct {
+	files      map[model.ID]bool
+	statements int
+}
+
+func (c *Computer) Compute() error {
+	projectsDB, err := c.storage.LoadProjects()
+	if err != nil {
+		return err
+	}
+
+	filesDB, err := c.storage.LoadFiles()
+	if err != nil {
+		return err
+	}
+
+	sources, err := c.loadSources(projectsDB, filesDB)
+	if err != nil {
+		return err
+	}
+
+	c.console.Printf("Computing dependencies usage from %v files...\n", len(sources))
+
+	r := computeUsages(projectsDB, sources)
+	r.Print(c.console)
+
+	return nil
+}
+
+// computeUsages stores the usage counts in the dependencies and returns the ones that are not declared or not used
+func computeUsages(projectsDB *model.Projects, sources []*sourceFile) *report {
+	r := newResolver(projectsDB, sources)
+
+	usages := map[*model.Project]map[*model.Project]*usage{}
+	for _, s := range sources {
+		for _, imp := range s.imports {
+			for _, target := range r.Resolve(s, imp) {
+				source := r.DeclaringProject(s.proj, target)
+				if source == target {
+					continue
+				}
+
+				us, ok := usages[source]
+				if !ok {
+					us = map[*model.Project]*usage{}
+					usages[source] = us
+				}
+
+				u, ok := us[target]
+				if !ok {
+					u = &usage{files: map[model.ID]bool{}}
+					us[target] = u
+				}
+
+				u.files[s.file.ID] = true
+				u.statements++
+			}
+		}
+	}
+
+	report := newReport()
+
+	for _, proj := range projectsDB.ListProjects(model.FilterExcludeExternal) {
+		if !r.HasSources(proj) {
+			continue
+		}
+
+		us := usages[proj]
+
+		for _, dep := range proj.Dependencies {
+			if !r.CanResolve(dep) {
+				continue
+			}
+
+			u, ok := us[dep.Target]
+			if !ok {
+				u = &usage{}
+			}
+
+			dep.SetData("imports:files", strconv.Itoa(len(u.files)))
+			dep.SetData("imports:statements", strconv.Itoa(u.statements))
+
+			if u.statements == 0 {
+				report.Unused = append(report.Unused, dep)
+			}
+		}
+
+		for target, u := range us {
+			if _, ok := proj.Dependencies[target.Name]; ok {
+				continue
+			}
+
+			report.Undeclared = append(report.Undeclared, &undeclaredDependency{
+				Source:     proj,
+				Target:     target,
+				Files:      len(u.files),
+				Statements: u.statements,
+				Transitive: isTransitiveDependency(proj, target),
+			})
+		}
+	}
+
+	return report
+}
+
+func (c *Computer) loadSources(projectsDB *model.Projects, filesDB *model.Files) ([]*sourceFile, error) {
+	var result []*sourceFile
+	var kotlinFiles []string
+	byPath := map[string]*sourceFile{}
+
+	for _, file := range filesDB.List() {
+		if !file.Exists || file.ProjectID == nil {
+			continue
+		}
+
+		proj := projectsDB.GetByID(*file.ProjectID)
+		if proj == nil || !proj.IsCode() {
+			continue
+		}
+
+		s := &sourceFile{
+			file: file,
+			proj: proj,
+		}
+
+		var err error
+		switch filepath.Ext(file.Path) {
+		case ".kt":
+			s.language = "kotlin"
+			kotlinFiles = append(kotlinFiles, file.Path)
+		case ".java":
+			s.language = "java"
+			err = parseJava(s)
+		case ".go":
+			s.language = "go"
+			err = parseGo(s)
+		default:
+			continue
+		}
+		if err != nil {
+			c.console.Printf("Error processing file %v: %v\n", file.Path, err)
+			continue
+		}
+
+		result = append(result, s)
+		byPath[file.Path] = s
+	}
+
+	if len(kotlinFiles) == 0 {
+		return result, nil
+	}
+
+	c.console.Printf("Parsing %v kotlin files...\n", len(kotlinFiles))
+
+	var mutex sync.Mutex
+	err := kotlin.ProcessFiles(kotlinFiles,
+		func(path string, content kotlin_parser.IKotlinFileContext) error {
+			pkg, imports := parseKotlin(content)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			s := byPath[path]
+			s.pkg = pkg
+			s.imports = imports
+
+			return nil
+		},
+		func(bar *progressbar.ProgressBar, index int, path string) error {
+			return nil
+		},
+		func(bar *progressbar.ProgressBar, index int, path string, err error) error {
+			_ = bar.Clear()
+			c.console.Printf("Error processing file %v: %v\n", path, err)
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func isTransitiveDependency(source *model.Project, target *model.Project) bool {
+	visited := map[*model.Project]bool{source: true}
+	queue := []*model.Project{source}
+
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+
+		for _, d := range p.Dependencies {
+			if d.Target == target {
+				return true
+			}
+
+			if !visited[d.Target] {
+				visited[d.Target] = true
+				queue = append(queue, d.Target)
+			}
+		}
+	}
+
+	return false
+}
+
+type undeclaredDependency struct {
+	Source     *model.Project
+	Target     *model.Project
+	Files      int
+	Statements int
+	Transitive bool
+}
+
+type report struct {
+	Unused     []*model.ProjectDependency
+	Undeclared []*undeclaredDependency
+}
+
+func newReport() *report {
+	return &report{}
+}
+
+func (r *report) Print(console consoles.Console) {
+	sort.Slice(r.Unused, func(i, j int) bool {
+		return r.Unused[i].String() < r.Unused[j].String()
+	})
+	sort.Slice(r.Undeclared, func(i, j int) bool {
+		a, b := r.Undeclared[i], r.Undeclared[j]
+		if a.Source.Name != b.Source.Name {
+			return a.Source.Name < b.Source.Name
+		}
+		return a.Target.Name < b.Target.Name
+	})
+
+	if len(r.Unused) > 0 {
+		console.Printf("Declared but unused dependencies:\n")
+		for _, d := range r.Unused {
+			console.Printf("   %v -> %v\n", d.Source.Name, d.Target.Name)
+		}
+	}
+
+	if len(r.Undeclared) > 0 {
+		console.Printf("Used but undeclared dependencies:\n")
+		for _, d := range r.Undeclared {
+			console.Printf("   %v -> %v (%v files, %v imports%v)\n", d.Source.Name, d.Target.Name, d.Files, d.Statements,
+				lo.Ternary(d.Transitive, ", transitive", ""))
+		}
+	}
+
+	if len(r.Unused) == 0 && len(r.Undeclared) == 0 {
+		console.Printf("All dependencies are declared and used.\n")
+	}
+}
@@ -0,0 +1,102 @@
+package imports
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+func TestComputeUsages(t *testing.T) {
+	t.Parallel()
+
+	projectsDB := model.NewProjects()
+	filesDB := model.NewFiles()
+
+	app := createProject(projectsDB, "app")
+	core := createProject(projectsDB, "core")
+	util := createProject(projectsDB, "util")
+	unused := createProject(projectsDB, "unused")
+	guava := projectsDB.GetOrCreate("com.google.guava:guava")
+
+	app.GetOrCreateDependency(core)
+	app.GetOrCreateDependency(unused)
+	app.GetOrCreateDependency(guava)
+	core.GetOrCreateDependency(util)
+
+	sources := []*sourceFile{
+		createSource(filesDB, app, "kotlin", "com.app", "com.core.Service", "com.util.*", "com.google.common.collect.ImmutableList"),
+		createSource(filesDB, app, "java", "com.app", "com.core.Other"),
+		createSource(filesDB, core, "kotlin", "com.core", "com.util.Strings"),
+		createSource(filesDB, util, "java", "com.util"),
+		createSource(filesDB, unused, "java", "com.unused"),
+	}
+
+	r := computeUsages(projectsDB, sources)
+
+	dep := app.Dependencies["core"]
+	assert.Equal(t, "2", dep.GetData("imports:files"))
+	assert.Equal(t, "2", dep.GetData("imports:statements"))
+	assert.Equal(t, "0", app.Dependencies["unused"].GetData("imports:statements"))
+	assert.Equal(t, "", app.Dependencies["com.google.guava:guava"].GetData("imports:statements"))
+
+	assert.Len(t, r.Unused, 1)
+	assert.Equal(t, unused, r.Unused[0].Target)
+
+	assert.Len(t, r.Undeclared, 1)
+	assert.Equal(t, app, r.Undeclared[0].Source)
+	assert.Equal(t, util, r.Undeclared[0].Target)
+	assert.True(t, r.Undeclared[0].Transitive)
+}
+
+func TestGoPackages(t *testing.T) {
+	t.Parallel()
+
+	projectsDB := model.NewProjects()
+	filesDB := model.NewFiles()
+
+	mod := createProject(projectsDB, "example.com/svc")
+	mod.ProjectFile = "/svc/go.mod"
+	api := createProject(projectsDB, "example.com/svc/api")
+	api.Groups = []string{"example.com/svc"}
+	api.ProjectFile = mod.ProjectFile
+	lib := projectsDB.GetOrCreate("github.com/pkg/errors")
+	mod.GetOrCreateDependency(lib)
+	mod.GetOrCreateDependency(api)
+	mod.GetOrCreateDependency(projectsDB.GetOrCreate("golang.org/x/sys")).SetData("indirect", "true")
+	mod.GetOrCreateDependency(projectsDB.GetOrCreate("com.google.guava:guava"))
+
+	sources := []*sourceFile{
+		createSource(filesDB, mod, "go", "", "fmt", "example.com/svc/api"),
+		createSource(filesDB, api, "go", "", "github.com/pkg/errors"),
+	}
+
+	r := computeUsages(projectsDB, sources)
+
+	assert.Equal(t, "1", mod.Dependencies["github.com/pkg/errors"].GetData("imports:statements"))
+	assert.Equal(t, "1", mod.Dependencies["example.com/svc/api"].GetData("imports:statements"))
+	assert.Equal(t, "", mod.Dependencies["golang.org/x/sys"].GetData("imports:statements"))
+	assert.Equal(t, "", mod.Dependencies["com.google.guava:guava"].GetData("imports:statements"))
+	assert.Empty(t, r.Unused)
+	assert.Empty(t, r.Undeclared)
+}
+
+func createProject(projectsDB *model.Projects, name string) *model.Project {
+	result := projectsDB.GetOrCreate(name)
+	result.Type = model.CodeType
+	return result
+}
+
+func createSource(filesDB *model.Files, proj *model.Project, language string, pkg string, imports ...string) *sourceFile {
+	file := filesDB.GetOrCreate("/" + proj.Name + "/" + language + "/" + pkg + "/" + string(rune('a'+len(filesDB.List()))))
+	file.ProjectID = &proj.ID
+
+	return &sourceFile{
+		file:     file,
+		proj:     proj,
+		language: language,
+		pkg:      pkg,
+		imports:  imports,
+	}
+}
@@ -0,0 +1,196 @@
+package imports
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pescuma/archer/lib/languages/kotlin_parser"
+	"github.com/pescuma/archer/lib/model"
+)
+
+// resolver finds the projects that own imported packages. Java and Kotlin packages are owned by the projects with
+// sources declaring them. Go packages are owned by the project with the longest name that is a prefix of the import
+// path, so external modules are found too.
+type resolver struct {
+	byName      map[string]*model.Project
+	jvmPackages map[string][]*model.Project
+	languages   map[*model.Project]map[string]bool
+}
+
+func newResolver(projectsDB *model.Projects, sources []*sourceFile) *resolver {
+	result := &resolver{
+		byName:      map[string]*model.Project{},
+		jvmPackages: map[string][]*model.Project{},
+		languages:   map[*model.Project]map[string]bool{},
+	}
+
+	for _, p := range projectsDB.ListProjects(model.FilterAll) {
+		result.byName[p.Name] = p
+	}
+
+	for _, s := range sources {
+		for _, p := range []*model.Project{s.proj, result.module(s.proj)} {
+			if p == nil {
+				continue
+			}
+
+			ls, ok := result.languages[p]
+			if !ok {
+				ls = map[string]bool{}
+				result.languages[p] = ls
+			}
+			ls[s.language] = true
+		}
+
+		if s.language != "go" && s.pkg != "" {
+			owners := result.jvmPackages[s.pkg]
+			if !containsProject(owners, s.proj) {
+				result.jvmPackages[s.pkg] = append(owners, s.proj)
+			}
+		}
+	}
+
+	for _, owners := range result.jvmPackages {
+		sort.Slice(owners, func(i, j int) bool { return owners[i].Name < owners[j].Name })
+	}
+
+	return result
+}
+
+func (r *resolver) Resolve(s *sourceFile, imp string) []*model.Project {
+	if s.language == "go" {
+		parts := strings.Split(imp, "/")
+		for i := len(parts); i > 0; i-- {
+			if p, ok := r.byName[strings.Join(parts[:i], "/")]; ok {
+				return []*model.Project{p}
+			}
+		}
+		return nil
+	}
+
+	parts := strings.Split(imp, ".")
+	for i := len(parts); i > 0; i-- {
+		if owners, ok := r.jvmPackages[strings.Join(parts[:i], ".")]; ok {
+			return owners
+		}
+	}
+	return nil
+}
+
+// DeclaringProject returns the project that should declare a dependency from owner to target. Go package projects
+// use the dependencies declared by their module.
+func (r *resolver) DeclaringProject(owner *model.Project, target *model.Project) *model.Project {
+	mod := r.module(owner)
+	if mod == nil || target == mod || r.module(target) == mod {
+		return owner
+	}
+
+	return mod
+}
+
+// module returns the module of a Go package project, or nil if it is not one
+func (r *resolver) module(proj *model.Project) *model.Project {
+	if len(proj.Groups) == 0 {
+		return nil
+	}
+
+	mod, ok := r.byName[proj.Groups[len(proj.Groups)-1]]
+	if !ok || mod == proj || mod.ProjectFile != proj.ProjectFile || !strings.HasPrefix(proj.Name, mod.Name+"/") {
+		return nil
+	}
+
+	return mod
+}
+
+func (r *resolver) HasSources(proj *model.Project) bool {
+	return len(r.languages[proj]) > 0
+}
+
+// CanResolve returns true if imports from the dependency source to its target would be found. Indirect Go
+// requirements are not imported by the sources, and libraries without sources can't be mapped to JVM packages.
+func (r *resolver) CanResolve(dep *model.ProjectDependency) bool {
+	if r.languages[dep.Source]["go"] {
+		return dep.GetData("indirect") != "true" && isGoImportPath(dep.Target.Name)
+	}
+
+	return r.languages[dep.Target]["java"] || r.languages[dep.Target]["kotlin"]
+}
+
+// isGoImportPath returns true if the name can be the path of a Go module, and not a maven or npm library name
+func isGoImportPath(name string) bool {
+	return !strings.ContainsAny(name, ":@ ")
+}
+
+func containsProject(ps []*model.Project, p *model.Project) bool {
+	for _, o := range ps {
+		if o == p {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	javaPackageRE = regexp.MustCompile(`(?m)^\s*package\s+([\w.]+)\s*;`)
+	javaImportRE  = regexp.MustCompile(`(?m)^\s*import\s+(?:static\s+)?([\w.]+)(?:\s*\.\s*\*)?\s*;`)
+)
+
+func parseJava(s *sourceFile) error {
+	data, err := os.ReadFile(s.file.Path)
+	if err != nil {
+		return err
+	}
+
+	content := string(data)
+
+	if m := javaPackageRE.FindStringSubmatch(content); m != nil {
+		s.pkg = m[1]
+	}
+
+	for _, m := range javaImportRE.FindAllStringSubmatch(content, -1) {
+		s.imports = append(s.imports, m[1])
+	}
+
+	return nil
+}
+
+func parseGo(s *sourceFile) error {
+	ast, err := parser.ParseFile(token.NewFileSet(), s.file.Path, nil, parser.ImportsOnly)
+	if err != nil {
+		return err
+	}
+
+	for _, imp := range ast.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		s.imports = append(s.imports, path)
+	}
+
+	return nil
+}
+
+func parseKotlin(content kotlin_parser.IKotlinFileContext) (string, []string) {
+	pkg := ""
+	if h := content.PackageHeader(); h != nil && h.Identifier() != nil {
+		pkg = h.Identifier().GetText()
+	}
+
+	var imports []string
+	if l := content.ImportList(); l != nil {
+		for _, h := range l.AllImportHeader() {
+			if h.Identifier() != nil {
+				imports = append(imports, h.Identifier().GetText())
+			}
+		}
+	}
+
+	return pkg, imports
+}
@@ -0,0 +1,188 @@
+package levels
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/importers/cycles"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages"
+)
+
+type Computer struct {
+	console consoles.Console
+	storage storages.Storage
+}
+
+func NewComputer(console consoles.Console, storage storages.Storage) *Computer {
+	return &Computer{
+		console: console,
+		storage: storage,
+	}
+}
+
+// Result has the Lakos metrics of the whole graph
+type Result struct {
+	Projects []*model.Project
+	// CCD is the cumulative component dependency: the sum of DependsOn of all projects
+	CCD int
+	// ACD is the average component dependency
+	ACD float64
+	// NCCD is the CCD normalized by the CCD of a balanced binary tree with the same number of projects
+	NCCD float64
+}
+
+func (c *Computer) Compute() error {
+	projectsDB, err := c.storage.LoadProjects()
+	if err != nil {
+		return err
+	}
+
+	r := computeLevels(projectsDB.ListProjects(model.FilterExcludeExternal))
+
+	c.print(r)
+
+	return nil
+}
+
+// computeLevels stores the level, fan in, fan out and depends on of each code project in its data
+func computeLevels(all []*model.Project) *Result {
+	var ps []*model.Project
+	for _, p := range all {
+		if p.IsCode() {
+			ps = append(ps, p)
+		}
+	}
+
+	internalDeps := func(p *model.Project) []*model.Project {
+		var result []*model.Project
+		for _, d := range p.ListDependencies(model.FilterExcludeExternal) {
+			if d.Target.IsCode() && d.Target != p {
+				result = append(result, d.Target)
+			}
+		}
+		return result
+	}
+
+	// Projects in a cycle share the same node
+	component := map[*model.Project]string{}
+	byName := map[string]*model.Project{}
+	for _, p := range ps {
+		component[p] = p.Name
+		byName[p.Name] = p
+	}
+	for _, cycle := range cycles.FindCycles(ps, func(p *model.Project) string { return p.Name }) {
+		for _, n := range cycle.Nodes {
+			if p, ok := byName[n]; ok {
+				component[p] = cycle.Nodes[0]
+			}
+		}
+	}
+
+	members := map[string][]*model.Project{}
+	for _, p := range ps {
+		members[component[p]] = append(members[component[p]], p)
+	}
+
+	levels := map[string]int{}
+	var computeLevel func(n string) int
+	computeLevel = func(n string) int {
+		if l, ok := levels[n]; ok {
+			return l
+		}
+
+		levels[n] = 0
+		level := 0
+		for _, p := range members[n] {
+			for _, d := range internalDeps(p) {
+				if component[d] != n {
+					level = max(level, computeLevel(component[d])+1)
+				}
+			}
+		}
+
+		levels[n] = level
+		return level
+	}
+
+	fanIn := map[*model.Project]int{}
+	for _, p := range ps {
+		for _, d := range internalDeps(p) {
+			fanIn[d]++
+		}
+	}
+
+	result := &Result{}
+
+	for _, p := range ps {
+		dependsOn := countReachable(p, internalDeps)
+		result.CCD += dependsOn
+
+		p.SetData("levels:level", strconv.Itoa(computeLevel(component[p])))
+		p.SetData("levels:fan-in", strconv.Itoa(fanIn[p]))
+		p.SetData("levels:fan-out", strconv.Itoa(len(internalDeps(p))))
+		p.SetData("levels:depends-on", strconv.Itoa(dependsOn))
+	}
+
+	result.Projects = ps
+	sort.SliceStable(result.Projects, func(i, j int) bool {
+		return GetLevel(result.Projects[i]) < GetLevel(result.Projects[j])
+	})
+
+	if n := len(ps); n > 0 {
+		result.ACD = float64(result.CCD) / float64(n)
+		result.NCCD = float64(result.CCD) / balancedTreeCCD(n)
+	}
+
+	return result
+}
+
+// countReachable returns the number of projects reachable from p, including itself
+func countReachable(p *model.Project, deps func(*model.Project) []*model.Project) int {
+	visited := map[*model.Project]bool{p: true}
+	queue := []*model.Project{p}
+
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+
+		for _, d := range deps(c) {
+			if !visited[d] {
+				visited[d] = true
+				queue = append(queue, d)
+			}
+		}
+	}
+
+	return len(visited)
+}
+
+func balancedTreeCCD(n int) float64 {
+	return float64(n+1)*math.Log2(float64(n+1)) - float64(n)
+}
+
+// GetLevel returns the level stored in the project data, or -1 if it was not computed
+func GetLevel(p *model.Project) int {
+	l, err := strconv.Atoi(p.GetData("levels:level"))
+	if err != nil {
+		return -1
+	}
+	return l
+}
+
+func (c *Computer) print(r *Result) {
+	level := -1
+	for _, p := range r.Projects {
+		if l := GetLevel(p); l != level {
+			level = l
+			c.console.Printf("Level %v:\n", level)
+		}
+
+		c.console.Printf("   %v (fan in %v, fan out %v, depends on %v)\n", p.Name,
+			p.GetData("levels:fan-in"), p.GetData("levels:fan-out"), p.GetData("levels:depends-on"))
+	}
+
+	c.console.Printf("CCD: %v  ACD: %.2f  NCCD: %.2f\n", r.CCD, r.ACD, r.NCCD)
+}
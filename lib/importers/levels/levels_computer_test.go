@@ -0,0 +1,52 @@
+package levels
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+func TestComputeLevels(t *testing.T) {
+	t.Parallel()
+
+	projs := model.NewProjects()
+
+	add := func(from, to string) {
+		f := projs.GetOrCreate(from)
+		f.Type = model.CodeType
+		tp := projs.GetOrCreate(to)
+		if tp.Type == model.Library {
+			tp.Type = model.CodeType
+		}
+		f.GetOrCreateDependency(tp)
+	}
+
+	add("app", "service")
+	add("service", "model")
+	add("service", "repo")
+	add("repo", "model")
+	add("repo", "dao")
+	add("dao", "repo")
+	projs.GetOrCreate("model").GetOrCreateDependency(projs.GetOrCreate("guava"))
+
+	r := computeLevels(projs.ListProjects(model.FilterExcludeExternal))
+
+	level := func(name string) int { return GetLevel(projs.GetOrCreate(name)) }
+	assert.Equal(t, 0, level("model"))
+	assert.Equal(t, 1, level("repo"))
+	assert.Equal(t, 1, level("dao"))
+	assert.Equal(t, 2, level("service"))
+	assert.Equal(t, 3, level("app"))
+	assert.Equal(t, -1, level("guava"))
+
+	assert.Equal(t, "2", projs.GetOrCreate("model").GetData("levels:fan-in"))
+	assert.Equal(t, "2", projs.GetOrCreate("repo").GetData("levels:fan-out"))
+	assert.Equal(t, "5", projs.GetOrCreate("app").GetData("levels:depends-on"))
+
+	// 5 + 4 + 3 + 3 + 1
+	assert.Equal(t, 16, r.CCD)
+	assert.InDelta(t, 3.2, r.ACD, 0.001)
+	assert.InDelta(t, 16/(6*2.585-5), r.NCCD, 0.01)
+}
@@ -0,0 +1,192 @@
+package maven
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/importers/common"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages"
+	"github.com/pescuma/archer/lib/utils"
+)
+
+type Importer struct {
+	console consoles.Console
+	storage storages.Storage
+}
+
+type Options struct {
+	Groups           []string
+	RespectGitignore bool
+}
+
+func NewImporter(console consoles.Console, storage storages.Storage) *Importer {
+	return &Importer{
+		console: console,
+		storage: storage,
+	}
+}
+
+func (i *Importer) Import(dirs []string, opts *Options) error {
+	projsDB, err := i.storage.LoadProjects()
+	if err != nil {
+		return err
+	}
+
+	filesDB, err := i.storage.LoadFiles()
+	if err != nil {
+		return err
+	}
+
+	ps := newPoms()
+
+	err = common.FindAndImportFiles(i.console, "pom files", dirs,
+		func(name string) bool {
+			return name == "pom.xml"
+		},
+		func(path string) error {
+			_, err := ps.Load(path)
+			return err
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = ps.Resolve()
+	if err != nil {
+		return err
+	}
+
+	queue := lo.Map(ps.List(), func(p *pom, _ int) string { return p.path })
+	sort.Strings(queue)
+
+	i.console.Printf("Importing %v projects...\n", len(queue))
+
+	return common.ImportFiles(queue, func(path string) error {
+		return i.process(projsDB, filesDB, ps.Get(path), opts)
+	})
+}
+
+func (i *Importer) process(projsDB *model.Projects, filesDB *model.Files, p *pom, opts *Options) error {
+	if p.GroupID() == "" || p.ArtifactID() == "" {
+		i.console.Printf("Ignoring %v because of empty groupId or artifactId\n", p.path)
+		return nil
+	}
+
+	proj := projsDB.GetOrCreate(p.Name())
+	proj.Groups = i.computeGroups(p, opts)
+	proj.Type = model.CodeType
+	proj.RootDir = p.RootDir()
+	proj.ProjectFile = p.path
+	proj.Dependencies = make(map[string]*model.ProjectDependency)
+	proj.SeenAt(time.Now())
+
+	dir := proj.GetDirectory(".")
+	dir.Type = model.SourceDir
+	dir.SeenAt(time.Now())
+
+	projFile := filesDB.GetOrCreate(p.path)
+	projFile.ProjectID = &proj.ID
+	projFile.ProjectDirectoryID = &dir.ID
+	projFile.SeenAt(time.Now())
+
+	if projFile.RepositoryID != nil {
+		proj.RepositoryID = projFile.RepositoryID
+	}
+
+	for _, d := range p.xml.Dependencies.Dependencies {
+		i.addDep(projsDB, proj, p, d)
+	}
+
+	filter, err := common.CreateFileFilter(proj.RootDir, opts.RespectGitignore,
+		func(path string) bool {
+			name := filepath.Base(path)
+			ext := filepath.Ext(name)
+			return name == "pom.xml" || utils.In(ext, ".java", ".kt", ".kts", ".groovy", ".scala")
+		},
+		func(path string, isDir bool) bool {
+			name := filepath.Base(path)
+			if strings.HasPrefix(name, ".") || name == "node_modules" || name == "target" {
+				return true
+			}
+
+			// Sub-modules own their files
+			if isDir && path != proj.RootDir {
+				exists, _ := utils.FileExists(filepath.Join(path, "pom.xml"))
+				return exists
+			}
+
+			return false
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = common.MarkDeletedFilesAndUnmarkExistingOnes(filesDB, proj, dir, filter)
+	if err != nil {
+		return err
+	}
+
+	err = common.AddFiles(filesDB, proj, dir, filter)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *Importer) computeGroups(p *pom, opts *Options) []string {
+	result := lo.Filter(opts.Groups, func(g string, _ int) bool { return g != "" })
+
+	chain := p.AggregatorChain()
+	if len(chain) == 0 {
+		return append(result, p.ArtifactID())
+	}
+
+	for _, a := range chain {
+		result = append(result, a.ArtifactID())
+	}
+
+	return result
+}
+
+func (i *Importer) addDep(projsDB *model.Projects, proj *model.Project, p *pom, d xmlDependency) {
+	groupID := p.Interpolate(d.GroupID)
+	artifactID := p.Interpolate(d.ArtifactID)
+	if groupID == "" || artifactID == "" {
+		return
+	}
+
+	version := p.Interpolate(d.Version)
+	scope := p.Interpolate(d.Scope)
+
+	if version == "" || scope == "" {
+		managed := p.ManagedDependency(groupID, artifactID)
+		if managed != nil {
+			version = utils.Coalesce(version, managed.Version)
+			scope = utils.Coalesce(scope, managed.Scope)
+		}
+	}
+
+	scope = utils.Coalesce(scope, "compile")
+
+	dp := projsDB.GetOrCreate(groupID + ":" + artifactID)
+
+	dep := proj.GetOrCreateDependency(dp)
+	if version != "" && !strings.Contains(version, "${") {
+		dep.Versions.Insert(version)
+	}
+
+	dep.SetData("scope", scope)
+
+	if utils.IsTrue(p.Interpolate(d.Optional)) {
+		dep.SetData("optional", "true")
+	}
+}
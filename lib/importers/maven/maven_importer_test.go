@@ -0,0 +1,136 @@
+package maven
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/model"
+)
+
+func TestMultiModuleWithParent(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "pom.xml"), `
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+	<groupId>com.example</groupId>
+	<artifactId>root</artifactId>
+	<version>1.2.3</version>
+	<packaging>pom</packaging>
+	<modules>
+		<module>api</module>
+		<module>impl</module>
+	</modules>
+	<properties>
+		<guava.version>32.0.0</guava.version>
+	</properties>
+	<dependencyManagement>
+		<dependencies>
+			<dependency>
+				<groupId>com.google.guava</groupId>
+				<artifactId>guava</artifactId>
+				<version>${guava.version}</version>
+			</dependency>
+		</dependencies>
+	</dependencyManagement>
+</project>`)
+	writeFile(t, filepath.Join(dir, "api", "pom.xml"), `
+<project>
+	<parent>
+		<groupId>com.example</groupId>
+		<artifactId>root</artifactId>
+		<version>1.2.3</version>
+	</parent>
+	<artifactId>api</artifactId>
+	<dependencies>
+		<dependency>
+			<groupId>com.google.guava</groupId>
+			<artifactId>guava</artifactId>
+		</dependency>
+		<dependency>
+			<groupId>junit</groupId>
+			<artifactId>junit</artifactId>
+			<version>4.13</version>
+			<scope>test</scope>
+		</dependency>
+	</dependencies>
+</project>`)
+	writeFile(t, filepath.Join(dir, "impl", "pom.xml"), `
+<project>
+	<parent>
+		<groupId>com.example</groupId>
+		<artifactId>root</artifactId>
+		<version>1.2.3</version>
+	</parent>
+	<artifactId>impl</artifactId>
+	<dependencies>
+		<dependency>
+			<groupId>${project.groupId}</groupId>
+			<artifactId>api</artifactId>
+			<version>${project.version}</version>
+		</dependency>
+	</dependencies>
+</project>`)
+
+	ps := newPoms()
+	_, err := ps.Load(filepath.Join(dir, "pom.xml"))
+	assert.Nil(t, err)
+	assert.Nil(t, ps.Resolve())
+
+	projsDB := model.NewProjects()
+	filesDB := model.NewFiles()
+	importer := NewImporter(consoles.NewStdOutConsole(), nil)
+	for _, p := range ps.List() {
+		assert.Nil(t, importer.process(projsDB, filesDB, p, &Options{}))
+	}
+
+	api := projsDB.GetOrCreate("com.example:api")
+	assert.Equal(t, model.CodeType, api.Type)
+	assert.Equal(t, []string{"root"}, api.Groups)
+
+	guava := api.Dependencies["com.google.guava:guava"]
+	assert.NotNil(t, guava)
+	assert.Equal(t, []string{"32.0.0"}, guava.Versions.Slice())
+	assert.Equal(t, "compile", guava.GetData("scope"))
+	assert.Equal(t, "test", api.Dependencies["junit:junit"].GetData("scope"))
+
+	impl := projsDB.GetOrCreate("com.example:impl")
+	assert.Equal(t, []string{"1.2.3"}, impl.Dependencies["com.example:api"].Versions.Slice())
+	assert.True(t, impl.Dependencies["com.example:api"].Target.IsCode())
+}
+
+func TestSelfReferencingProperties(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "pom.xml"), `
+<project>
+	<groupId>${project.groupId}</groupId>
+	<artifactId>root</artifactId>
+	<version>${a}</version>
+	<properties>
+		<a>${b}</a>
+		<b>${a}</b>
+	</properties>
+</project>`)
+
+	ps := newPoms()
+	p, err := ps.Load(filepath.Join(dir, "pom.xml"))
+	assert.Nil(t, err)
+	assert.Nil(t, ps.Resolve())
+
+	assert.Equal(t, "${project.groupId}", p.GroupID())
+	assert.Equal(t, "root", p.ArtifactID())
+	assert.Contains(t, p.Version(), "${")
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+}
@@ -0,0 +1,392 @@
+package maven
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pescuma/archer/lib/utils"
+)
+
+type pom struct {
+	path       string
+	xml        *xmlProject
+	parent     *pom
+	aggregator *pom
+	poms       *poms
+}
+
+func (p *pom) GroupID() string {
+	return p.groupID(map[pomProperty]bool{})
+}
+
+func (p *pom) groupID(resolving map[pomProperty]bool) string {
+	if p.xml.GroupID != "" {
+		return p.interpolate(p.xml.GroupID, resolving)
+	}
+
+	if p.parent != nil {
+		return p.parent.groupID(resolving)
+	}
+
+	return p.interpolate(p.xml.Parent.GroupID, resolving)
+}
+
+func (p *pom) ArtifactID() string {
+	return p.artifactID(map[pomProperty]bool{})
+}
+
+func (p *pom) artifactID(resolving map[pomProperty]bool) string {
+	return p.interpolate(p.xml.ArtifactID, resolving)
+}
+
+func (p *pom) Version() string {
+	return p.version(map[pomProperty]bool{})
+}
+
+func (p *pom) version(resolving map[pomProperty]bool) string {
+	if p.xml.Version != "" {
+		return p.interpolate(p.xml.Version, resolving)
+	}
+
+	if p.parent != nil {
+		return p.parent.version(resolving)
+	}
+
+	return p.interpolate(p.xml.Parent.Version, resolving)
+}
+
+func (p *pom) Name() string {
+	return p.GroupID() + ":" + p.ArtifactID()
+}
+
+func (p *pom) RootDir() string {
+	return filepath.Dir(p.path)
+}
+
+func (p *pom) property(name string, resolving map[pomProperty]bool) (string, bool) {
+	switch name {
+	case "project.groupId", "pom.groupId", "groupId":
+		return p.groupID(resolving), true
+	case "project.artifactId", "pom.artifactId", "artifactId":
+		return p.artifactID(resolving), true
+	case "project.version", "pom.version", "version":
+		return p.version(resolving), true
+	case "project.basedir", "basedir":
+		return p.RootDir(), true
+	case "project.parent.groupId":
+		return p.xml.Parent.GroupID, true
+	case "project.parent.version":
+		return p.xml.Parent.Version, true
+	}
+
+	for _, prop := range p.xml.Properties.Entries {
+		if prop.XMLName.Local == name {
+			return strings.TrimSpace(prop.Value), true
+		}
+	}
+
+	if p.parent != nil {
+		return p.parent.property(name, resolving)
+	}
+
+	return "", false
+}
+
+// pomProperty identifies a property being resolved, to detect self-referencing definitions
+type pomProperty struct {
+	pom  *pom
+	name string
+}
+
+var propertyRE = regexp.MustCompile(`\$\{([^}]+)}`)
+
+// Interpolate replaces ${...} references using the pom properties and the ones inherited from its parents.
+// Unknown properties, and the ones that reference themselves, are kept as is.
+func (p *pom) Interpolate(s string) string {
+	return p.interpolate(s, map[pomProperty]bool{})
+}
+
+func (p *pom) interpolate(s string, resolving map[pomProperty]bool) string {
+	s = strings.TrimSpace(s)
+
+	// Limit the number of passes to avoid looping forever in recursive definitions
+	for i := 0; i < 10 && strings.Contains(s, "${"); i++ {
+		changed := false
+
+		s = propertyRE.ReplaceAllStringFunc(s, func(m string) string {
+			key := pomProperty{p, m[2 : len(m)-1]}
+			if resolving[key] {
+				return m
+			}
+
+			resolving[key] = true
+			v, ok := p.property(key.name, resolving)
+			delete(resolving, key)
+
+			if !ok || v == m {
+				return m
+			}
+
+			changed = true
+			return v
+		})
+
+		if !changed {
+			break
+		}
+	}
+
+	return s
+}
+
+// ManagedDependency returns the dependency declared in a dependencyManagement section of this pom, its parents or
+// imported BOMs, or nil if none is found.
+func (p *pom) ManagedDependency(groupID, artifactID string) *managedDependency {
+	return p.findManagedDependency(groupID, artifactID, map[*pom]bool{})
+}
+
+func (p *pom) findManagedDependency(groupID, artifactID string, visited map[*pom]bool) *managedDependency {
+	if visited[p] {
+		return nil
+	}
+	visited[p] = true
+
+	for _, d := range p.xml.DependencyManagement.Dependencies.Dependencies {
+		if p.Interpolate(d.GroupID) == groupID && p.Interpolate(d.ArtifactID) == artifactID {
+			return &managedDependency{
+				Version: p.Interpolate(d.Version),
+				Scope:   p.Interpolate(d.Scope),
+			}
+		}
+	}
+
+	for _, d := range p.xml.DependencyManagement.Dependencies.Dependencies {
+		if p.Interpolate(d.Scope) != "import" {
+			continue
+		}
+
+		bom := p.poms.GetByName(p.Interpolate(d.GroupID) + ":" + p.Interpolate(d.ArtifactID))
+		if bom == nil {
+			continue
+		}
+
+		result := bom.findManagedDependency(groupID, artifactID, visited)
+		if result != nil {
+			return result
+		}
+	}
+
+	if p.parent != nil {
+		return p.parent.findManagedDependency(groupID, artifactID, visited)
+	}
+
+	return nil
+}
+
+// AggregatorChain returns the poms that include this one as a module, starting from the outermost one.
+func (p *pom) AggregatorChain() []*pom {
+	var result []*pom
+
+	visited := map[*pom]bool{p: true}
+	for a := p.aggregator; a != nil && !visited[a]; a = a.aggregator {
+		visited[a] = true
+		result = append([]*pom{a}, result...)
+	}
+
+	return result
+}
+
+type managedDependency struct {
+	Version string
+	Scope   string
+}
+
+type poms struct {
+	byPath map[string]*pom
+	byName map[string]*pom
+}
+
+func newPoms() *poms {
+	return &poms{
+		byPath: map[string]*pom{},
+		byName: map[string]*pom{},
+	}
+}
+
+func (ps *poms) Load(path string) (*pom, error) {
+	path, err := utils.PathAbs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if p, ok := ps.byPath[path]; ok {
+		return p, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var xmlProj xmlProject
+	err = xml.Unmarshal(data, &xmlProj)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &pom{
+		path: path,
+		xml:  &xmlProj,
+		poms: ps,
+	}
+	ps.byPath[path] = p
+
+	return p, nil
+}
+
+func (ps *poms) Get(path string) *pom {
+	return ps.byPath[path]
+}
+
+func (ps *poms) GetByName(name string) *pom {
+	return ps.byName[name]
+}
+
+func (ps *poms) List() []*pom {
+	result := make([]*pom, 0, len(ps.byPath))
+	for _, p := range ps.byPath {
+		result = append(result, p)
+	}
+	return result
+}
+
+// Resolve links the loaded poms to their parents and aggregators. Modules that are referenced but were not loaded yet
+// are loaded too.
+func (ps *poms) Resolve() error {
+	queue := ps.List()
+
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+
+		for _, module := range p.xml.Modules.Modules {
+			module = strings.TrimSpace(module)
+			if module == "" {
+				continue
+			}
+
+			path := filepath.Join(p.RootDir(), filepath.FromSlash(module))
+			if !strings.HasSuffix(path, ".xml") {
+				path = filepath.Join(path, "pom.xml")
+			}
+
+			exists, err := utils.FileExists(path)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				continue
+			}
+
+			_, loaded := ps.byPath[path]
+
+			m, err := ps.Load(path)
+			if err != nil {
+				return err
+			}
+
+			m.aggregator = p
+
+			if !loaded {
+				queue = append(queue, m)
+			}
+		}
+	}
+
+	for _, p := range ps.byPath {
+		if p.xml.Parent.ArtifactID == "" {
+			continue
+		}
+
+		relativePath := p.xml.Parent.RelativePath
+		if relativePath == "" {
+			relativePath = "../pom.xml"
+		}
+
+		path := filepath.Join(p.RootDir(), filepath.FromSlash(relativePath))
+		if !strings.HasSuffix(path, ".xml") {
+			path = filepath.Join(path, "pom.xml")
+		}
+
+		parent, ok := ps.byPath[path]
+		if ok && parent.xml.ArtifactID == p.xml.Parent.ArtifactID {
+			p.parent = parent
+		}
+	}
+
+	// Names depend on the parents, so can only be computed after they are all resolved
+	for _, p := range ps.byPath {
+		ps.byName[p.Name()] = p
+	}
+
+	for _, p := range ps.byPath {
+		if p.parent == nil && p.xml.Parent.ArtifactID != "" {
+			p.parent = ps.byName[p.xml.Parent.GroupID+":"+p.xml.Parent.ArtifactID]
+		}
+	}
+
+	return nil
+}
+
+type xmlProject struct {
+	XMLName              xml.Name                `xml:"project"`
+	Parent               xmlParent               `xml:"parent"`
+	GroupID              string                  `xml:"groupId"`
+	ArtifactID           string                  `xml:"artifactId"`
+	Version              string                  `xml:"version"`
+	Packaging            string                  `xml:"packaging"`
+	Modules              xmlModules              `xml:"modules"`
+	Properties           xmlProperties           `xml:"properties"`
+	DependencyManagement xmlDependencyManagement `xml:"dependencyManagement"`
+	Dependencies         xmlDependencies         `xml:"dependencies"`
+}
+
+type xmlParent struct {
+	GroupID      string `xml:"groupId"`
+	ArtifactID   string `xml:"artifactId"`
+	Version      string `xml:"version"`
+	RelativePath string `xml:"relativePath"`
+}
+
+type xmlModules struct {
+	Modules []string `xml:"module"`
+}
+
+type xmlProperties struct {
+	Entries []xmlProperty `xml:",any"`
+}
+
+type xmlProperty struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type xmlDependencyManagement struct {
+	Dependencies xmlDependencies `xml:"dependencies"`
+}
+
+type xmlDependencies struct {
+	Dependencies []xmlDependency `xml:"dependency"`
+}
+
+type xmlDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Scope      string `xml:"scope"`
+	Type       string `xml:"type"`
+	Optional   string `xml:"optional"`
+}
@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"math"
+
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/utils"
+)
+
+// MaxGroupLevel returns the highest level that creates different groups. Project.LevelSimpleName returns the same
+// names for all levels above it.
+func MaxGroupLevel(ps []*model.Project) int {
+	return lo.Max(lo.Map(ps, func(p *model.Project, _ int) int { return len(p.Groups) })) + 1
+}
+
+// ComputeCoupling computes the coupling metrics of the nodes created by grouping projects using getName.
+// External dependencies are ignored.
+func ComputeCoupling(ps []*model.Project, getName func(*model.Project) string) map[string]*model.Coupling {
+	afferent := map[string]map[string]bool{}
+	efferent := map[string]map[string]bool{}
+	types := map[string]*model.Metrics{}
+
+	get := func(m map[string]map[string]bool, name string) map[string]bool {
+		r, ok := m[name]
+		if !ok {
+			r = map[string]bool{}
+			m[name] = r
+		}
+		return r
+	}
+
+	for _, p := range ps {
+		if p.IsExternalDependency() {
+			continue
+		}
+
+		name := getName(p)
+
+		m, ok := types[name]
+		if !ok {
+			m = model.NewMetrics()
+			types[name] = m
+		}
+		m.Add(p.Metrics)
+
+		get(afferent, name)
+		get(efferent, name)
+
+		for _, d := range p.ListDependencies(model.FilterExcludeExternal) {
+			target := getName(d.Target)
+			if target == name {
+				continue
+			}
+
+			get(efferent, name)[target] = true
+			get(afferent, target)[name] = true
+		}
+	}
+
+	result := map[string]*model.Coupling{}
+	for name, m := range types {
+		c := model.NewCoupling()
+		c.Afferent = len(afferent[name])
+		c.Efferent = len(efferent[name])
+
+		if c.Afferent+c.Efferent > 0 {
+			c.Instability = float64(c.Efferent) / float64(c.Afferent+c.Efferent)
+		}
+
+		if m.Types > 0 {
+			c.Abstractness = float64(utils.Max(m.AbstractTypes, 0)) / float64(m.Types)
+		}
+
+		if c.Instability >= 0 && c.Abstractness >= 0 {
+			c.Distance = math.Abs(c.Abstractness + c.Instability - 1)
+		}
+
+		result[name] = c
+	}
+
+	return result
+}
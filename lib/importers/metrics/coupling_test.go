@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+func TestComputeCoupling(t *testing.T) {
+	t.Parallel()
+
+	projs := model.NewProjects()
+
+	add := func(from, to string) {
+		f := projs.GetOrCreate(from)
+		f.Type = model.CodeType
+		tp := projs.GetOrCreate(to)
+		if tp.Type == model.Library {
+			tp.Type = model.CodeType
+		}
+		f.GetOrCreateDependency(tp)
+	}
+
+	add("app", "api")
+	add("app", "impl")
+	add("impl", "api")
+	projs.GetOrCreate("impl").GetOrCreateDependency(projs.GetOrCreate("guava"))
+
+	api := projs.GetOrCreate("api")
+	api.Metrics.Types = 4
+	api.Metrics.AbstractTypes = 3
+
+	impl := projs.GetOrCreate("impl")
+	impl.Metrics.Types = 2
+	impl.Metrics.AbstractTypes = 0
+
+	r := ComputeCoupling(projs.ListProjects(model.FilterExcludeExternal), func(p *model.Project) string { return p.Name })
+
+	assert.Len(t, r, 3)
+
+	assert.Equal(t, 2, r["api"].Afferent)
+	assert.Equal(t, 0, r["api"].Efferent)
+	assert.InDelta(t, 0, r["api"].Instability, 0.001)
+	assert.InDelta(t, 0.75, r["api"].Abstractness, 0.001)
+	assert.InDelta(t, 0.25, r["api"].Distance, 0.001)
+
+	assert.Equal(t, 1, r["impl"].Afferent)
+	assert.Equal(t, 1, r["impl"].Efferent)
+	assert.InDelta(t, 0.5, r["impl"].Instability, 0.001)
+	assert.InDelta(t, 0, r["impl"].Abstractness, 0.001)
+	assert.InDelta(t, 0.5, r["impl"].Distance, 0.001)
+
+	assert.Equal(t, 0, r["app"].Afferent)
+	assert.Equal(t, 2, r["app"].Efferent)
+	assert.InDelta(t, 1, r["app"].Instability, 0.001)
+	assert.Equal(t, -1., r["app"].Abstractness)
+	assert.Equal(t, -1., r["app"].Distance)
+}
+
+func TestMaxGroupLevel(t *testing.T) {
+	t.Parallel()
+
+	projs := model.NewProjects()
+	projs.GetOrCreate("a").Groups = []string{"billing", "core"}
+	projs.GetOrCreate("b").Groups = []string{"billing"}
+	projs.GetOrCreate("c")
+
+	ps := projs.ListProjects(model.FilterAll)
+
+	assert.Equal(t, 3, MaxGroupLevel(ps))
+	assert.Equal(t, "billing:core:a", projs.GetOrCreate("a").LevelSimpleName(MaxGroupLevel(ps)))
+}
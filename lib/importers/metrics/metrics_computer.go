@@ -71,6 +71,24 @@ func (c *Computer) Compute() error {
 		}
 	}
 
+	c.console.Printf("Computing coupling for projects...\n")
+
+	ps := projectsDB.ListProjects(model.FilterExcludeExternal)
+	coupling := ComputeCoupling(ps, func(p *model.Project) string { return p.Name })
+	for _, p := range ps {
+		p.Coupling = coupling[p.Name]
+	}
+
+	c.console.Printf("Computing coupling for groups...\n")
+
+	projectsDB.ClearGroupCouplings()
+	for level := 0; level <= MaxGroupLevel(ps); level++ {
+		coupling = ComputeCoupling(ps, func(p *model.Project) string { return p.LevelSimpleName(level) })
+		for name, cp := range coupling {
+			projectsDB.SetGroupCoupling(level, name, cp)
+		}
+	}
+
 	return nil
 }
 
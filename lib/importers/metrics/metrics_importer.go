@@ -119,6 +119,7 @@ func (i *Importer) Import(filter []string, opts *Options) error {
 
 			file.Metrics.GuiceDependencies = dependencies.ComputeKotlinGuiceDependencies(file.Path, structure, content)
 			file.Metrics.Abstracts = dependencies.ComputeKotlinAbstracts(file.Path, structure, content)
+			file.Metrics.Types, file.Metrics.AbstractTypes = dependencies.ComputeKotlinTypes(content)
 
 			c := complexity.ComputeKotlinComplexity(file.Path, content)
 			file.Metrics.CyclomaticComplexity = c.CyclomaticComplexity
@@ -0,0 +1,357 @@
+package migrations
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/common"
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages"
+	"github.com/pescuma/archer/lib/utils"
+)
+
+type Importer struct {
+	console consoles.Console
+	storage storages.Storage
+}
+
+type Options struct {
+	Groups []string
+}
+
+func NewImporter(console consoles.Console, storage storages.Storage) *Importer {
+	return &Importer{
+		console: console,
+		storage: storage,
+	}
+}
+
+func (i *Importer) Import(dirs []string, opts *Options) error {
+	projectsDB, err := i.storage.LoadProjects()
+	if err != nil {
+		return err
+	}
+
+	i.console.Printf("Finding migration files...\n")
+
+	var files []string
+	for _, dir := range dirs {
+		dir, err := utils.PathAbs(dir)
+		if err != nil {
+			return err
+		}
+
+		fs, err := utils.ListFilesRecursive(dir, func(name string) bool {
+			return strings.HasSuffix(strings.ToLower(name), ".sql")
+		})
+		if err != nil {
+			return err
+		}
+
+		files = append(files, fs...)
+	}
+
+	files = sortMigrations(files)
+
+	i.console.Printf("Replaying %v migration files...\n", len(files))
+
+	s := newSchema()
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		s.Apply(file, string(content))
+	}
+
+	tables := s.ListTables()
+
+	commits, err := newCommitFinder().LastCommits(lo.Map(tables, func(t *table, _ int) string { return t.LastFile }))
+	if err != nil {
+		return err
+	}
+
+	var projs []*model.Project
+	names := map[string]bool{}
+	for _, t := range tables {
+		i.console.Printf("Importing table %v (%v)\n", t.Name, filepath.Base(t.LastFile))
+
+		proj := projectsDB.GetOrCreate(t.Name)
+		proj.Type = model.DatabaseType
+		proj.Dependencies = make(map[string]*model.ProjectDependency)
+
+		for _, r := range t.ListReferencedTables() {
+			if r == t.Name {
+				continue
+			}
+
+			proj.GetOrCreateDependency(projectsDB.GetOrCreate(r))
+		}
+
+		proj.SetData("migration:file", t.LastFile)
+		proj.SetData("migration:commit", commits[t.LastFile])
+		proj.SetData("migration:dropped", "")
+
+		projs = append(projs, proj)
+		names[t.Name] = true
+	}
+
+	common.CreateTableNameParts(projs)
+
+	groups := lo.Filter(opts.Groups, func(g string, _ int) bool { return g != "" })
+	for _, proj := range projs {
+		proj.Groups = append(slices.Clone(groups), proj.Groups...)
+	}
+
+	// Tables imported before that don't exist anymore were dropped (or renamed) by a newer migration
+	for _, proj := range projectsDB.ListProjects(model.FilterAll) {
+		if proj.GetData("migration:file") == "" || names[proj.Name] {
+			continue
+		}
+
+		i.console.Printf("Marking table %v as dropped\n", proj.Name)
+
+		proj.Dependencies = make(map[string]*model.ProjectDependency)
+		proj.SetData("migration:dropped", "true")
+	}
+
+	return nil
+}
+
+var flywayRE = regexp.MustCompile(`^([VUR])([\d._]*)__.*\.sql$`)
+
+// sortMigrations orders the files the way they are applied: Flyway versioned migrations by version, then other SQL
+// files by path, then Flyway repeatable migrations. Flyway undo migrations are ignored.
+func sortMigrations(files []string) []string {
+	type migration struct {
+		path    string
+		order   int
+		version []int
+	}
+
+	var ms []*migration
+	for _, f := range files {
+		m := &migration{path: f, order: 1}
+
+		if fm := flywayRE.FindStringSubmatch(filepath.Base(f)); fm != nil {
+			switch fm[1] {
+			case "U":
+				continue
+			case "V":
+				m.order = 0
+				m.version = parseVersion(fm[2])
+			case "R":
+				m.order = 2
+			}
+		}
+
+		ms = append(ms, m)
+	}
+
+	sort.SliceStable(ms, func(i, j int) bool {
+		a, b := ms[i], ms[j]
+		if a.order != b.order {
+			return a.order < b.order
+		}
+
+		for k := 0; k < len(a.version) && k < len(b.version); k++ {
+			if a.version[k] != b.version[k] {
+				return a.version[k] < b.version[k]
+			}
+		}
+		if len(a.version) != len(b.version) {
+			return len(a.version) < len(b.version)
+		}
+
+		return a.path < b.path
+	})
+
+	return lo.Map(ms, func(m *migration, _ int) string { return m.path })
+}
+
+func parseVersion(v string) []int {
+	parts := strings.FieldsFunc(v, func(r rune) bool { return r == '.' || r == '_' })
+	return lo.Map(parts, func(p string, _ int) int {
+		i, _ := strconv.Atoi(p)
+		return i
+	})
+}
+
+// commitFinder finds the last commits that changed files, walking the history of each repository only once
+type commitFinder struct {
+	repos map[string]*git.Repository
+}
+
+func newCommitFinder() *commitFinder {
+	return &commitFinder{
+		repos: map[string]*git.Repository{},
+	}
+}
+
+// LastCommits returns the hash of the last commit that changed each of the paths. Files outside a git repository or
+// not committed yet are not returned.
+func (f *commitFinder) LastCommits(paths []string) (map[string]string, error) {
+	result := map[string]string{}
+
+	repos := map[string]*git.Repository{}
+	wantedByRoot := map[string]map[string]string{}
+	for _, path := range paths {
+		repo, err := f.openRepo(filepath.Dir(path))
+		if err != nil {
+			return nil, err
+		}
+		if repo == nil {
+			continue
+		}
+
+		wt, err := repo.Worktree()
+		if err != nil {
+			continue
+		}
+
+		root := wt.Filesystem.Root()
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil, err
+		}
+
+		wanted, ok := wantedByRoot[root]
+		if !ok {
+			wanted = map[string]string{}
+			wantedByRoot[root] = wanted
+			repos[root] = repo
+		}
+		wanted[filepath.ToSlash(rel)] = path
+	}
+
+	for root, wanted := range wantedByRoot {
+		err := f.findLastCommits(repos[root], wanted, result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (f *commitFinder) openRepo(dir string) (*git.Repository, error) {
+	repo, ok := f.repos[dir]
+	if ok {
+		return repo, nil
+	}
+
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		repo = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	f.repos[dir] = repo
+	return repo, nil
+}
+
+func (f *commitFinder) findLastCommits(repo *git.Repository, wanted map[string]string, result map[string]string) error {
+	commits, err := repo.Log(&git.LogOptions{
+		Order: git.LogOrderCommitterTime,
+	})
+	if err != nil {
+		// Empty repository
+		return nil
+	}
+	defer commits.Close()
+
+	err = commits.ForEach(func(commit *object.Commit) error {
+		changed, err := changedFiles(commit)
+		if err != nil {
+			return err
+		}
+
+		for name := range changed {
+			if path, ok := wanted[name]; ok {
+				result[path] = commit.Hash.String()
+				delete(wanted, name)
+			}
+		}
+
+		if len(wanted) == 0 {
+			return storer.ErrStop
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// changedFiles returns the files changed by a commit. For merges, only the files that are different from all
+// parents are returned, so the change is attributed to the commit that made it.
+func changedFiles(commit *object.Commit) (map[string]bool, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	if commit.NumParents() == 0 {
+		result := map[string]bool{}
+		err = tree.Files().ForEach(func(f *object.File) error {
+			result[f.Name] = true
+			return nil
+		})
+		return result, err
+	}
+
+	var result map[string]bool
+	err = commit.Parents().ForEach(func(parent *object.Commit) error {
+		parentTree, err := parent.Tree()
+		if err != nil {
+			return err
+		}
+
+		changes, err := object.DiffTree(parentTree, tree)
+		if err != nil {
+			return err
+		}
+
+		names := map[string]bool{}
+		for _, c := range changes {
+			names[c.From.Name] = true
+			names[c.To.Name] = true
+		}
+		delete(names, "")
+
+		if result == nil {
+			result = names
+		} else {
+			for name := range result {
+				if !names[name] {
+					delete(result, name)
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
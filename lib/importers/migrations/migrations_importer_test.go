@@ -0,0 +1,92 @@
+package migrations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/storages/orm"
+)
+
+func TestLastCommit(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	repo, err := git.PlainInit(dir, false)
+	assert.Nil(t, err)
+	wt, err := repo.Worktree()
+	assert.Nil(t, err)
+
+	commit := func(name string, content string) string {
+		assert.Nil(t, os.MkdirAll(filepath.Join(dir, "db"), 0o700))
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, "db", name), []byte(content), 0o600))
+		_, err := wt.Add("db/" + name)
+		assert.Nil(t, err)
+
+		hash, err := wt.Commit(name, &git.CommitOptions{
+			Author: &object.Signature{Name: "a", Email: "a@a.com", When: time.Now()},
+		})
+		assert.Nil(t, err)
+		return hash.String()
+	}
+
+	first := commit("V1__a.sql", "create table a (id int);")
+	second := commit("V2__b.sql", "create table b (id int);")
+	third := commit("V1__a.sql", "create table a (id bigint);")
+	assert.NotEqual(t, first, third)
+
+	f := newCommitFinder()
+
+	hashes, err := f.LastCommits([]string{
+		filepath.Join(dir, "db", "V1__a.sql"),
+		filepath.Join(dir, "db", "V2__b.sql"),
+		filepath.Join(t.TempDir(), "V1__a.sql"),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		filepath.Join(dir, "db", "V1__a.sql"): third,
+		filepath.Join(dir, "db", "V2__b.sql"): second,
+	}, hashes)
+}
+
+func TestImport(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name string, content string) {
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	storage, err := orm.NewGormStorage(orm.WithSqliteInMemory(), consoles.NewStdOutConsole())
+	assert.Nil(t, err)
+
+	importer := NewImporter(consoles.NewStdOutConsole(), storage)
+
+	write("V1__create.sql", `
+create table customer (id int primary key);
+create table customer_order (id int primary key, customer_id int references customer(id));
+create table audit (id int);
+`)
+	assert.Nil(t, importer.Import([]string{dir}, &Options{Groups: []string{"shop"}}))
+
+	write("V2__drop.sql", `drop table audit;`)
+	assert.Nil(t, importer.Import([]string{dir}, &Options{Groups: []string{"shop"}}))
+
+	projs, err := storage.LoadProjects()
+	assert.Nil(t, err)
+
+	order := projs.GetOrCreate("customer_order")
+	assert.Equal(t, []string{"shop", "customer", "customer_order"}, order.Groups)
+	assert.NotNil(t, order.Dependencies["customer"])
+	assert.Equal(t, "", order.GetData("migration:dropped"))
+
+	audit := projs.GetOrCreate("audit")
+	assert.Equal(t, "true", audit.GetData("migration:dropped"))
+}
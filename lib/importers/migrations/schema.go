@@ -0,0 +1,297 @@
+package migrations
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// schema is the result of replaying the DDL statements of the migrations
+type schema struct {
+	tables map[string]*table
+}
+
+type table struct {
+	Name string
+	// FKs maps constraint names to referenced tables. Unnamed constraints get a generated name.
+	FKs      map[string]string
+	LastFile string
+}
+
+func newSchema() *schema {
+	return &schema{
+		tables: map[string]*table{},
+	}
+}
+
+func (s *schema) ListTables() []*table {
+	result := make([]*table, 0, len(s.tables))
+	for _, t := range s.tables {
+		result = append(result, t)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+func (t *table) ListReferencedTables() []string {
+	set := map[string]bool{}
+	for _, r := range t.FKs {
+		set[r] = true
+	}
+
+	result := make([]string, 0, len(set))
+	for r := range set {
+		result = append(result, r)
+	}
+	sort.Strings(result)
+	return result
+}
+
+const identifier = "(?:`[^`]+`|\"[^\"]+\"|\\[[^\\]]+\\]|[\\w$]+)"
+const qualifiedIdentifier = identifier + `(?:\s*\.\s*` + identifier + `)*`
+
+var (
+	createTableRE = regexp.MustCompile(`(?is)^create\s+(?:(?:global\s+|local\s+)?(?:temporary|temp)\s+|unlogged\s+)?table\s+(?:if\s+not\s+exists\s+)?(` + qualifiedIdentifier + `)\s*\((.*)\)`)
+	alterTableRE  = regexp.MustCompile(`(?is)^alter\s+table\s+(?:if\s+exists\s+)?(?:only\s+)?(` + qualifiedIdentifier + `)\s+(.*)$`)
+	dropTableRE   = regexp.MustCompile(`(?is)^drop\s+table\s+(?:if\s+exists\s+)?(.*?)(?:\s+(?:cascade|restrict))?$`)
+	renameTableRE = regexp.MustCompile(`(?is)^rename\s+table\s+(.*)$`)
+
+	constraintRE = regexp.MustCompile(`(?is)^constraint\s+(` + identifier + `)\s+(.*)$`)
+	foreignKeyRE = regexp.MustCompile(`(?is)^foreign\s+key\s*(?:` + identifier + `\s*)?\([^)]*\)\s*references\s+(` + qualifiedIdentifier + `)`)
+	referencesRE = regexp.MustCompile(`(?is)\breferences\s+(` + qualifiedIdentifier + `)`)
+	addRE        = regexp.MustCompile(`(?is)^add\s+(?:column\s+)?(?:if\s+not\s+exists\s+)?(.*)$`)
+	dropFKRE     = regexp.MustCompile(`(?is)^drop\s+(?:foreign\s+key|constraint)\s+(?:if\s+exists\s+)?(` + identifier + `)`)
+	renameToRE   = regexp.MustCompile(`(?is)^rename\s+to\s+(` + qualifiedIdentifier + `)`)
+	columnNameRE = regexp.MustCompile(`(?is)^(` + identifier + `)`)
+)
+
+// Apply replays the statements of a migration file
+func (s *schema) Apply(file string, content string) {
+	for _, stmt := range splitStatements(content) {
+		s.applyStatement(file, stmt)
+	}
+}
+
+func (s *schema) applyStatement(file string, stmt string) {
+	if m := createTableRE.FindStringSubmatch(stmt); m != nil {
+		t := &table{
+			Name:     tableName(m[1]),
+			FKs:      map[string]string{},
+			LastFile: file,
+		}
+		s.tables[t.Name] = t
+
+		for _, def := range splitTopLevel(m[2], ',') {
+			t.addDefinition(def)
+		}
+
+	} else if m := alterTableRE.FindStringSubmatch(stmt); m != nil {
+		t, ok := s.tables[tableName(m[1])]
+		if !ok {
+			return
+		}
+		t.LastFile = file
+
+		for _, action := range splitTopLevel(m[2], ',') {
+			if rm := renameToRE.FindStringSubmatch(action); rm != nil {
+				s.rename(t.Name, tableName(rm[1]))
+
+			} else if dm := dropFKRE.FindStringSubmatch(action); dm != nil {
+				delete(t.FKs, unquote(dm[1]))
+
+			} else if am := addRE.FindStringSubmatch(action); am != nil {
+				t.addDefinition(am[1])
+			}
+		}
+
+	} else if m := dropTableRE.FindStringSubmatch(stmt); m != nil {
+		for _, name := range splitTopLevel(m[1], ',') {
+			s.drop(tableName(name))
+		}
+
+	} else if m := renameTableRE.FindStringSubmatch(stmt); m != nil {
+		for _, pair := range splitTopLevel(m[1], ',') {
+			parts := regexp.MustCompile(`(?i)\s+to\s+`).Split(pair, 2)
+			if len(parts) == 2 {
+				if t, ok := s.tables[tableName(parts[0])]; ok {
+					t.LastFile = file
+					s.rename(t.Name, tableName(parts[1]))
+				}
+			}
+		}
+	}
+}
+
+// addDefinition handles a column or constraint definition
+func (t *table) addDefinition(def string) {
+	name := ""
+	if m := constraintRE.FindStringSubmatch(def); m != nil {
+		name = unquote(m[1])
+		def = m[2]
+	}
+
+	if m := foreignKeyRE.FindStringSubmatch(def); m != nil {
+		t.addFK(name, tableName(m[1]))
+		return
+	}
+
+	// Column with inline reference
+	if m := referencesRE.FindStringSubmatch(def); m != nil {
+		if name == "" {
+			if cm := columnNameRE.FindStringSubmatch(def); cm != nil {
+				name = t.Name + "_" + unquote(cm[1]) + "_fkey"
+			}
+		}
+		t.addFK(name, tableName(m[1]))
+	}
+}
+
+func (t *table) addFK(name string, target string) {
+	if name == "" {
+		name = "fk_" + strconv.Itoa(len(t.FKs)+1)
+		for t.FKs[name] != "" {
+			name += "_"
+		}
+	}
+
+	t.FKs[name] = target
+}
+
+func (s *schema) drop(name string) {
+	delete(s.tables, name)
+
+	for _, t := range s.tables {
+		for k, v := range t.FKs {
+			if v == name {
+				delete(t.FKs, k)
+			}
+		}
+	}
+}
+
+func (s *schema) rename(from string, to string) {
+	t, ok := s.tables[from]
+	if !ok || from == to {
+		return
+	}
+
+	delete(s.tables, from)
+	t.Name = to
+	s.tables[to] = t
+
+	for _, o := range s.tables {
+		for k, v := range o.FKs {
+			if v == from {
+				o.FKs[k] = to
+			}
+		}
+	}
+}
+
+// tableName removes quotes and schema from a table name
+func tableName(name string) string {
+	parts := splitTopLevel(strings.TrimSpace(name), '.')
+	return unquote(strings.TrimSpace(parts[len(parts)-1]))
+}
+
+func unquote(name string) string {
+	name = strings.TrimSpace(name)
+	if len(name) >= 2 {
+		switch {
+		case name[0] == '`' && name[len(name)-1] == '`',
+			name[0] == '"' && name[len(name)-1] == '"',
+			name[0] == '[' && name[len(name)-1] == ']':
+			return name[1 : len(name)-1]
+		}
+	}
+	return name
+}
+
+// splitStatements removes comments and splits the content in statements, ignoring separators inside strings
+func splitStatements(content string) []string {
+	var result []string
+	var sb strings.Builder
+
+	add := func() {
+		stmt := strings.Join(strings.Fields(sb.String()), " ")
+		if stmt != "" {
+			result = append(result, stmt)
+		}
+		sb.Reset()
+	}
+
+	var quote byte
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+
+		case c == '-' && i+1 < len(content) && content[i+1] == '-':
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+			sb.WriteByte('\n')
+			continue
+
+		case c == '/' && i+1 < len(content) && content[i+1] == '*':
+			end := strings.Index(content[i+2:], "*/")
+			if end == -1 {
+				i = len(content)
+			} else {
+				i += 2 + end + 1
+			}
+			sb.WriteByte(' ')
+			continue
+
+		case c == ';':
+			add()
+			continue
+		}
+
+		sb.WriteByte(c)
+	}
+
+	add()
+
+	return result
+}
+
+// splitTopLevel splits on sep, ignoring the ones inside parenthesis or quotes
+func splitTopLevel(s string, sep byte) []string {
+	var result []string
+
+	depth := 0
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case quote != 0:
+			if c == quote || (quote == '[' && c == ']') {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`' || c == '[':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == sep && depth == 0:
+			result = append(result, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+
+	result = append(result, strings.TrimSpace(s[start:]))
+
+	return result
+}
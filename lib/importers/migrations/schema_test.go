@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+
+	s := newSchema()
+	s.Apply("V1__init.sql", `
+-- liquibase formatted sql
+-- changeset someone:1
+CREATE TABLE customer (
+    id BIGINT PRIMARY KEY,
+    name VARCHAR(100) DEFAULT 'a;b'
+);
+
+create table if not exists public."order" (
+    id bigint primary key,
+    customer_id bigint not null references customer(id),
+    amount numeric(10, 2)
+);
+
+/* comment; with separator */
+CREATE TABLE `+"`order_item`"+` (
+    id BIGINT PRIMARY KEY,
+    order_id BIGINT,
+    CONSTRAINT fk_item_order FOREIGN KEY (order_id) REFERENCES `+"`order`"+` (id)
+);
+CREATE TABLE old (id int);
+`)
+	s.Apply("V2__changes.sql", `
+ALTER TABLE order_item ADD COLUMN product_id BIGINT, ADD CONSTRAINT fk_item_product FOREIGN KEY (product_id) REFERENCES old (id);
+ALTER TABLE order_item DROP FOREIGN KEY fk_item_order;
+ALTER TABLE old RENAME TO product;
+DROP TABLE IF EXISTS unknown CASCADE;
+`)
+
+	tables := s.ListTables()
+	assert.Equal(t, []string{"customer", "order", "order_item", "product"}, []string{tables[0].Name, tables[1].Name, tables[2].Name, tables[3].Name})
+
+	assert.Equal(t, []string{"customer"}, s.tables["order"].ListReferencedTables())
+	assert.Equal(t, []string{"product"}, s.tables["order_item"].ListReferencedTables())
+	assert.Equal(t, "V2__changes.sql", s.tables["order_item"].LastFile)
+	assert.Equal(t, "V1__init.sql", s.tables["customer"].LastFile)
+	assert.Equal(t, "V2__changes.sql", s.tables["product"].LastFile)
+
+	s.Apply("V3__drop.sql", `drop table product;`)
+	assert.Empty(t, s.tables["order_item"].ListReferencedTables())
+	assert.Nil(t, s.tables["product"])
+}
+
+func TestSortMigrations(t *testing.T) {
+	t.Parallel()
+
+	files := sortMigrations([]string{
+		"/m/R__views.sql",
+		"/m/V10__c.sql",
+		"/m/V2__b.sql",
+		"/m/U2__b.sql",
+		"/m/V1.1__a.sql",
+		"/m/changelog.sql",
+	})
+
+	assert.Equal(t, []string{"/m/V1.1__a.sql", "/m/V2__b.sql", "/m/V10__c.sql", "/m/changelog.sql", "/m/R__views.sql"}, files)
+}
@@ -56,6 +56,21 @@ func (i *Importer) Import(connectionString string) error {
 		return err
 	}
 
+	err = i.importColumns(db, projects)
+	if err != nil {
+		return err
+	}
+
+	err = i.importFKColumns(db, projects)
+	if err != nil {
+		return err
+	}
+
+	err = i.importIndexes(db, projects)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -160,3 +175,156 @@ func (i *Importer) importFKs(db *sql.DB, projs *model.Projects) error {
 
 	return nil
 }
+
+func (i *Importer) importColumns(db *sql.DB, projs *model.Projects) error {
+	results, err := db.Query(`
+		select c.TABLE_SCHEMA schema_name,
+			   c.TABLE_NAME   table_name,
+			   c.COLUMN_NAME  column_name,
+			   c.COLUMN_TYPE  column_type,
+			   c.IS_NULLABLE  nullable,
+			   c.COLUMN_KEY   column_key
+		from information_schema.COLUMNS c
+			join information_schema.TABLES t on t.TABLE_SCHEMA = c.TABLE_SCHEMA and t.TABLE_NAME = c.TABLE_NAME
+		where t.TABLE_TYPE = 'BASE TABLE'
+		  and c.TABLE_SCHEMA <> 'information_schema'
+		order by c.TABLE_SCHEMA, c.TABLE_NAME, c.ORDINAL_POSITION
+		`)
+	if err != nil {
+		return errors.Wrap(err, "error querying database columns")
+	}
+
+	defer func() {
+		_ = results.Close()
+	}()
+
+	type columnInfo struct {
+		schemaName string
+		tableName  string
+		columnName string
+		columnType string
+		nullable   string
+		columnKey  string
+	}
+
+	cleared := map[*model.Project]bool{}
+	columns := 0
+
+	for results.Next() {
+		var col columnInfo
+
+		err = results.Scan(&col.schemaName, &col.tableName, &col.columnName, &col.columnType, &col.nullable, &col.columnKey)
+		if err != nil {
+			return errors.Wrap(err, "error querying database columns")
+		}
+
+		proj := projs.GetOrCreate(col.tableName)
+		if !cleared[proj] {
+			proj.ClearDatabaseColumns()
+			cleared[proj] = true
+		}
+
+		c := proj.GetColumn(col.columnName)
+		c.InDatabase = true
+		c.Type = col.columnType
+		c.Nullable = col.nullable == "YES"
+		c.PrimaryKey = col.columnKey == "PRI"
+
+		columns++
+	}
+
+	err = results.Err()
+	if err != nil {
+		return errors.Wrap(err, "error querying database columns")
+	}
+
+	i.console.Printf("Imported %v columns from %v tables\n", columns, len(cleared))
+
+	return nil
+}
+
+func (i *Importer) importFKColumns(db *sql.DB, projs *model.Projects) error {
+	results, err := db.Query(`
+		select TABLE_NAME            table_name,
+			   COLUMN_NAME           column_name,
+			   REFERENCED_TABLE_NAME referenced_table_name
+		from information_schema.KEY_COLUMN_USAGE
+		where REFERENCED_TABLE_NAME is not null
+		  and TABLE_SCHEMA <> 'information_schema'
+		`)
+	if err != nil {
+		return errors.Wrap(err, "error querying database FK columns")
+	}
+
+	defer func() {
+		_ = results.Close()
+	}()
+
+	for results.Next() {
+		var tableName, columnName, referencedTableName string
+
+		err = results.Scan(&tableName, &columnName, &referencedTableName)
+		if err != nil {
+			return errors.Wrap(err, "error querying database FK columns")
+		}
+
+		c, ok := projs.GetOrCreate(tableName).Columns[columnName]
+		if !ok {
+			continue
+		}
+
+		c.ForeignKey = referencedTableName
+	}
+
+	err = results.Err()
+	if err != nil {
+		return errors.Wrap(err, "error querying database FK columns")
+	}
+
+	return nil
+}
+
+// importIndexes stores in each column the indexes that start with it. Only these can be used to search by the
+// column alone.
+func (i *Importer) importIndexes(db *sql.DB, projs *model.Projects) error {
+	results, err := db.Query(`
+		select TABLE_NAME  table_name,
+			   INDEX_NAME  index_name,
+			   COLUMN_NAME column_name
+		from information_schema.STATISTICS
+		where TABLE_SCHEMA <> 'information_schema'
+		  and COLUMN_NAME is not null
+		  and SEQ_IN_INDEX = 1
+		order by TABLE_NAME, INDEX_NAME
+		`)
+	if err != nil {
+		return errors.Wrap(err, "error querying database indexes")
+	}
+
+	defer func() {
+		_ = results.Close()
+	}()
+
+	for results.Next() {
+		var tableName, indexName, columnName string
+
+		err = results.Scan(&tableName, &indexName, &columnName)
+		if err != nil {
+			return errors.Wrap(err, "error querying database indexes")
+		}
+
+		c, ok := projs.GetOrCreate(tableName).Columns[columnName]
+		if !ok {
+			continue
+		}
+
+		c.Indexes = append(c.Indexes, indexName)
+	}
+
+	err = results.Err()
+	if err != nil {
+		return errors.Wrap(err, "error querying database indexes")
+	}
+
+	return nil
+}
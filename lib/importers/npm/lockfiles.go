@@ -0,0 +1,231 @@
+package npm
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/pescuma/archer/lib/utils"
+)
+
+// lockfile knows the resolved versions of the dependencies of the packages below its folder
+type lockfile interface {
+	// Version returns the resolved version or "" if unknown. pkgDir is relative to the lockfile folder, using '/'.
+	Version(pkgDir string, name string, spec string) string
+}
+
+func loadLockfile(dir string) (lockfile, error) {
+	loaders := []struct {
+		name string
+		load func([]byte) (lockfile, error)
+	}{
+		{"package-lock.json", parsePackageLock},
+		{"npm-shrinkwrap.json", parsePackageLock},
+		{"yarn.lock", parseYarnLock},
+		{"pnpm-lock.yaml", parsePnpmLock},
+	}
+
+	for _, l := range loaders {
+		file := filepath.Join(dir, l.name)
+
+		exists, err := utils.FileExists(file)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		return l.load(data)
+	}
+
+	return nil, nil
+}
+
+type packageLock struct {
+	versions map[string]string
+}
+
+func parsePackageLock(data []byte) (lockfile, error) {
+	var content struct {
+		Packages map[string]struct {
+			Version string `json:"version"`
+		} `json:"packages"`
+		Dependencies map[string]struct {
+			Version string `json:"version"`
+		} `json:"dependencies"`
+	}
+
+	err := json.Unmarshal(data, &content)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &packageLock{
+		versions: map[string]string{},
+	}
+
+	// lockfileVersion 1
+	for name, d := range content.Dependencies {
+		result.versions["node_modules/"+name] = d.Version
+	}
+
+	// lockfileVersion 2 and 3
+	for p, d := range content.Packages {
+		if d.Version != "" {
+			result.versions[p] = d.Version
+		}
+	}
+
+	return result, nil
+}
+
+func (l *packageLock) Version(pkgDir string, name string, _ string) string {
+	if pkgDir != "" && pkgDir != "." {
+		if v, ok := l.versions[path.Join(pkgDir, "node_modules", name)]; ok {
+			return v
+		}
+	}
+
+	return l.versions["node_modules/"+name]
+}
+
+type yarnLock struct {
+	versions map[string]string
+}
+
+var yarnVersionRE = regexp.MustCompile(`^\s+version:?\s+"?([^"\s]+)"?`)
+
+func parseYarnLock(data []byte) (lockfile, error) {
+	result := &yarnLock{
+		versions: map[string]string{},
+	}
+
+	var keys []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+
+		case !strings.HasPrefix(line, " "):
+			keys = nil
+			for _, k := range strings.Split(strings.TrimSuffix(line, ":"), ",") {
+				k = strings.Trim(strings.TrimSpace(k), `"`)
+				if k != "" {
+					keys = append(keys, k)
+				}
+			}
+
+		default:
+			m := yarnVersionRE.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+
+			for _, k := range keys {
+				result.versions[k] = m[1]
+			}
+			keys = nil
+		}
+	}
+
+	return result, nil
+}
+
+func (l *yarnLock) Version(_ string, name string, spec string) string {
+	if v, ok := l.versions[name+"@"+spec]; ok {
+		return v
+	}
+
+	// yarn berry adds the protocol to the key
+	return l.versions[name+"@npm:"+spec]
+}
+
+type pnpmLock struct {
+	importers map[string]map[string]string
+}
+
+func parsePnpmLock(data []byte) (lockfile, error) {
+	type importer struct {
+		Dependencies         map[string]any `yaml:"dependencies"`
+		DevDependencies      map[string]any `yaml:"devDependencies"`
+		OptionalDependencies map[string]any `yaml:"optionalDependencies"`
+	}
+
+	// Older lockfiles without workspaces have the dependencies at the root
+	var content struct {
+		Dependencies         map[string]any      `yaml:"dependencies"`
+		DevDependencies      map[string]any      `yaml:"devDependencies"`
+		OptionalDependencies map[string]any      `yaml:"optionalDependencies"`
+		Importers            map[string]importer `yaml:"importers"`
+	}
+
+	err := yaml.Unmarshal(data, &content)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &pnpmLock{
+		importers: map[string]map[string]string{},
+	}
+
+	add := func(dir string, imp importer) {
+		versions := map[string]string{}
+
+		for _, ds := range []map[string]any{imp.Dependencies, imp.DevDependencies, imp.OptionalDependencies} {
+			for name, d := range ds {
+				var version string
+
+				switch d := d.(type) {
+				case string:
+					version = d
+				case map[string]any:
+					version, _ = d["version"].(string)
+				}
+
+				// Remove peer dependencies suffix
+				if i := strings.IndexAny(version, "(_"); i > 0 {
+					version = version[:i]
+				}
+
+				versions[name] = version
+			}
+		}
+
+		result.importers[dir] = versions
+	}
+
+	add(".", importer{content.Dependencies, content.DevDependencies, content.OptionalDependencies})
+	for dir, imp := range content.Importers {
+		add(dir, imp)
+	}
+
+	return result, nil
+}
+
+func (l *pnpmLock) Version(pkgDir string, name string, _ string) string {
+	if pkgDir == "" {
+		pkgDir = "."
+	}
+
+	v := l.importers[pkgDir][name]
+
+	// Links to other packages are not versions
+	if strings.HasPrefix(v, "link:") || strings.HasPrefix(v, "file:") {
+		return ""
+	}
+
+	return v
+}
@@ -0,0 +1,387 @@
+package npm
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/importers/common"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages"
+	"github.com/pescuma/archer/lib/utils"
+)
+
+type Importer struct {
+	console consoles.Console
+	storage storages.Storage
+}
+
+type Options struct {
+	Groups           []string
+	RespectGitignore bool
+}
+
+func NewImporter(console consoles.Console, storage storages.Storage) *Importer {
+	return &Importer{
+		console: console,
+		storage: storage,
+	}
+}
+
+func (i *Importer) Import(dirs []string, opts *Options) error {
+	projsDB, err := i.storage.LoadProjects()
+	if err != nil {
+		return err
+	}
+
+	filesDB, err := i.storage.LoadFiles()
+	if err != nil {
+		return err
+	}
+
+	pkgs := map[string]*packageInfo{}
+
+	err = common.FindAndImportFiles(i.console, "package.json files", dirs,
+		func(name string) bool {
+			return name == "package.json"
+		},
+		func(path string) error {
+			pkg, err := loadPackage(path)
+			if err != nil {
+				return err
+			}
+
+			pkgs[path] = pkg
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = resolveWorkspaces(pkgs)
+	if err != nil {
+		return err
+	}
+
+	queue := lo.Keys(pkgs)
+	sort.Strings(queue)
+
+	i.console.Printf("Importing %v projects...\n", len(queue))
+
+	lockfiles := map[string]lockfile{}
+
+	return common.ImportFiles(queue, func(path string) error {
+		return i.process(projsDB, filesDB, pkgs, lockfiles, pkgs[path], opts)
+	})
+}
+
+func (i *Importer) process(projsDB *model.Projects, filesDB *model.Files, pkgs map[string]*packageInfo, lockfiles map[string]lockfile,
+	pkg *packageInfo, opts *Options,
+) error {
+	name := pkg.Name()
+	if name == "" {
+		i.console.Printf("Ignoring %v because of empty package name\n", pkg.path)
+		return nil
+	}
+
+	proj := projsDB.GetOrCreate(name)
+	proj.Groups = i.computeGroups(pkg, opts)
+	proj.Type = model.CodeType
+	proj.RootDir = pkg.RootDir()
+	proj.ProjectFile = pkg.path
+	proj.Dependencies = make(map[string]*model.ProjectDependency)
+	proj.SeenAt(time.Now())
+
+	dir := proj.GetDirectory(".")
+	dir.Type = model.SourceDir
+	dir.SeenAt(time.Now())
+
+	projFile := filesDB.GetOrCreate(pkg.path)
+	projFile.ProjectID = &proj.ID
+	projFile.ProjectDirectoryID = &dir.ID
+	projFile.SeenAt(time.Now())
+
+	if projFile.RepositoryID != nil {
+		proj.RepositoryID = projFile.RepositoryID
+	}
+
+	lockRoot := pkg
+	if pkg.workspace != nil {
+		lockRoot = pkg.workspace
+	}
+
+	lock, ok := lockfiles[lockRoot.RootDir()]
+	if !ok {
+		var err error
+		lock, err = loadLockfile(lockRoot.RootDir())
+		if err != nil {
+			return err
+		}
+
+		lockfiles[lockRoot.RootDir()] = lock
+	}
+
+	pkgDir, err := filepath.Rel(lockRoot.RootDir(), pkg.RootDir())
+	if err != nil {
+		return err
+	}
+	pkgDir = filepath.ToSlash(pkgDir)
+
+	// A workspace root also depends on its members as code
+	wsRoot := pkg.workspace
+	if wsRoot == nil && len(pkg.patterns) > 0 {
+		wsRoot = pkg
+	}
+
+	workspacePkgs := map[string]bool{}
+	if wsRoot != nil {
+		for _, m := range pkgs {
+			if m.workspace == wsRoot || m == wsRoot {
+				workspacePkgs[m.Name()] = true
+			}
+		}
+	}
+
+	scopes := []struct {
+		scope string
+		deps  map[string]string
+	}{
+		{"prod", pkg.json.Dependencies},
+		{"dev", pkg.json.DevDependencies},
+		{"peer", pkg.json.PeerDependencies},
+		{"optional", pkg.json.OptionalDependencies},
+	}
+	for _, s := range scopes {
+		for depName, spec := range s.deps {
+			if depName == "" {
+				continue
+			}
+
+			dp := projsDB.GetOrCreate(depName)
+
+			dep := proj.GetOrCreateDependency(dp)
+			if dep.GetData("scope") == "" {
+				dep.SetData("scope", s.scope)
+			}
+
+			if workspacePkgs[depName] || strings.HasPrefix(spec, "workspace:") {
+				continue
+			}
+
+			version := ""
+			if lock != nil {
+				version = lock.Version(pkgDir, depName, spec)
+			}
+			if version == "" && isExactVersion(spec) {
+				version = spec
+			}
+
+			if version != "" {
+				dep.Versions.Insert(version)
+			}
+		}
+	}
+
+	filter, err := common.CreateFileFilter(proj.RootDir, opts.RespectGitignore,
+		func(path string) bool {
+			name := filepath.Base(path)
+			ext := filepath.Ext(name)
+			return name == "package.json" || utils.In(ext, ".js", ".jsx", ".mjs", ".cjs", ".ts", ".tsx", ".mts", ".cts", ".vue", ".svelte")
+		},
+		func(path string, isDir bool) bool {
+			name := filepath.Base(path)
+			if strings.HasPrefix(name, ".") || name == "node_modules" {
+				return true
+			}
+
+			// Other packages own their files
+			if isDir && path != proj.RootDir {
+				exists, _ := utils.FileExists(filepath.Join(path, "package.json"))
+				return exists
+			}
+
+			return false
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = common.MarkDeletedFilesAndUnmarkExistingOnes(filesDB, proj, dir, filter)
+	if err != nil {
+		return err
+	}
+
+	err = common.AddFiles(filesDB, proj, dir, filter)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *Importer) computeGroups(pkg *packageInfo, opts *Options) []string {
+	result := lo.Filter(opts.Groups, func(g string, _ int) bool { return g != "" })
+
+	if pkg.workspace != nil {
+		result = append(result, pkg.workspace.Name())
+	} else {
+		result = append(result, pkg.Name())
+	}
+
+	return result
+}
+
+func isExactVersion(spec string) bool {
+	if spec == "" {
+		return false
+	}
+
+	c := spec[0]
+	return c >= '0' && c <= '9' && !strings.ContainsAny(spec, " <>|*x")
+}
+
+type packageInfo struct {
+	path      string
+	json      *packageJson
+	patterns  []string
+	workspace *packageInfo
+}
+
+func (p *packageInfo) RootDir() string {
+	return filepath.Dir(p.path)
+}
+
+func (p *packageInfo) Name() string {
+	if p.json.Name != "" {
+		return p.json.Name
+	}
+
+	// Workspace roots usually don't have a name
+	if len(p.patterns) > 0 {
+		return filepath.Base(p.RootDir())
+	}
+
+	return ""
+}
+
+func loadPackage(path string) (*packageInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var pj packageJson
+	err = json.Unmarshal(data, &pj)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &packageInfo{
+		path: path,
+		json: &pj,
+	}
+
+	if len(pj.Workspaces) > 0 {
+		var patterns []string
+		if err := json.Unmarshal(pj.Workspaces, &patterns); err == nil {
+			result.patterns = patterns
+
+		} else {
+			var ws struct {
+				Packages []string `json:"packages"`
+			}
+			if err := json.Unmarshal(pj.Workspaces, &ws); err == nil {
+				result.patterns = ws.Packages
+			}
+		}
+	}
+
+	pnpmWorkspace := filepath.Join(result.RootDir(), "pnpm-workspace.yaml")
+	if data, err := os.ReadFile(pnpmWorkspace); err == nil {
+		var ws struct {
+			Packages []string `yaml:"packages"`
+		}
+
+		err = yaml.Unmarshal(data, &ws)
+		if err != nil {
+			return nil, err
+		}
+
+		result.patterns = append(result.patterns, ws.Packages...)
+	}
+
+	return result, nil
+}
+
+// resolveWorkspaces links each package to the innermost workspace root that includes it
+func resolveWorkspaces(pkgs map[string]*packageInfo) error {
+	for _, pkg := range pkgs {
+		pkg.workspace = nil
+
+		for _, root := range pkgs {
+			if root == pkg || len(root.patterns) == 0 {
+				continue
+			}
+
+			included, err := root.includes(pkg)
+			if err != nil {
+				return err
+			}
+			if !included {
+				continue
+			}
+
+			if pkg.workspace == nil || len(root.RootDir()) > len(pkg.workspace.RootDir()) {
+				pkg.workspace = root
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *packageInfo) includes(pkg *packageInfo) (bool, error) {
+	rel, err := filepath.Rel(p.RootDir(), pkg.RootDir())
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return false, nil
+	}
+	rel = filepath.ToSlash(rel)
+
+	included := false
+	for _, pattern := range p.patterns {
+		exclude := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimPrefix(strings.TrimPrefix(pattern, "!"), "./")
+		pattern = strings.TrimSuffix(pattern, "/")
+
+		m, err := doublestar.Match(pattern, rel)
+		if err != nil {
+			return false, err
+		}
+
+		if m {
+			included = !exclude
+		}
+	}
+
+	return included, nil
+}
+
+type packageJson struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Workspaces           json.RawMessage   `json:"workspaces"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+}
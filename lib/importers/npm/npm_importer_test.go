@@ -0,0 +1,182 @@
+package npm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/model"
+)
+
+func TestWorkspace(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "package.json"), `{
+	"name": "mono",
+	"private": true,
+	"workspaces": ["packages/*", "!packages/ignored"]
+}`)
+	writeFile(t, filepath.Join(dir, "packages", "a", "package.json"), `{
+	"name": "@mono/a",
+	"dependencies": { "@mono/b": "workspace:*", "lodash": "^4.17.0" },
+	"devDependencies": { "jest": "29.0.0" }
+}`)
+	writeFile(t, filepath.Join(dir, "packages", "b", "package.json"), `{
+	"name": "@mono/b"
+}`)
+	writeFile(t, filepath.Join(dir, "packages", "ignored", "package.json"), `{
+	"name": "ignored"
+}`)
+	writeFile(t, filepath.Join(dir, "package-lock.json"), `{
+	"lockfileVersion": 3,
+	"packages": {
+		"node_modules/lodash": { "version": "4.17.21" }
+	}
+}`)
+
+	pkgs := map[string]*packageInfo{}
+	for _, p := range []string{"package.json", "packages/a/package.json", "packages/b/package.json", "packages/ignored/package.json"} {
+		pkg, err := loadPackage(filepath.Join(dir, p))
+		assert.Nil(t, err)
+		pkgs[pkg.path] = pkg
+	}
+	assert.Nil(t, resolveWorkspaces(pkgs))
+
+	projsDB := model.NewProjects()
+	filesDB := model.NewFiles()
+	importer := NewImporter(consoles.NewStdOutConsole(), nil)
+	lockfiles := map[string]lockfile{}
+	for _, pkg := range pkgs {
+		assert.Nil(t, importer.process(projsDB, filesDB, pkgs, lockfiles, pkg, &Options{}))
+	}
+
+	a := projsDB.GetOrCreate("@mono/a")
+	assert.Equal(t, model.CodeType, a.Type)
+	assert.Equal(t, []string{"mono"}, a.Groups)
+	assert.True(t, a.Dependencies["@mono/b"].Target.IsCode())
+	assert.Equal(t, 0, a.Dependencies["@mono/b"].Versions.Size())
+
+	lodash := a.Dependencies["lodash"]
+	assert.False(t, lodash.Target.IsCode())
+	assert.Equal(t, []string{"4.17.21"}, lodash.Versions.Slice())
+	assert.Equal(t, "prod", lodash.GetData("scope"))
+
+	jest := a.Dependencies["jest"]
+	assert.Equal(t, []string{"29.0.0"}, jest.Versions.Slice())
+	assert.Equal(t, "dev", jest.GetData("scope"))
+
+	assert.Equal(t, []string{"ignored"}, projsDB.GetOrCreate("ignored").Groups)
+}
+
+func TestYarnLock(t *testing.T) {
+	t.Parallel()
+
+	lock, err := parseYarnLock([]byte(`# yarn lockfile v1
+
+"lodash@^4.17.0", lodash@^4.17.20:
+  version "4.17.21"
+  resolved "https://registry.yarnpkg.com/lodash/-/lodash-4.17.21.tgz"
+
+"react@npm:^18.0.0":
+  version: 18.2.0
+`))
+	assert.Nil(t, err)
+
+	assert.Equal(t, "4.17.21", lock.Version(".", "lodash", "^4.17.0"))
+	assert.Equal(t, "18.2.0", lock.Version(".", "react", "^18.0.0"))
+	assert.Equal(t, "", lock.Version(".", "react", "^17.0.0"))
+}
+
+func TestPnpmLock(t *testing.T) {
+	t.Parallel()
+
+	lock, err := parsePnpmLock([]byte(`lockfileVersion: '6.0'
+importers:
+  .:
+    devDependencies:
+      typescript:
+        specifier: ^5.0.0
+        version: 5.1.6
+  packages/a:
+    dependencies:
+      '@mono/b':
+        specifier: workspace:*
+        version: link:../b
+      react-dom:
+        specifier: ^18.0.0
+        version: 18.2.0(react@18.2.0)
+`))
+	assert.Nil(t, err)
+
+	assert.Equal(t, "5.1.6", lock.Version(".", "typescript", "^5.0.0"))
+	assert.Equal(t, "18.2.0", lock.Version("packages/a", "react-dom", "^18.0.0"))
+	assert.Equal(t, "", lock.Version("packages/a", "@mono/b", "workspace:*"))
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestNestedWorkspace(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "package.json"), `{
+	"name": "mono",
+	"workspaces": ["packages/**"],
+	"devDependencies": { "@mono/a": "^1.0.0" }
+}`)
+	writeFile(t, filepath.Join(dir, "packages", "a", "package.json"), `{
+	"name": "@mono/a",
+	"version": "1.0.0"
+}`)
+	writeFile(t, filepath.Join(dir, "packages", "inner", "package.json"), `{
+	"name": "inner",
+	"workspaces": ["*"]
+}`)
+	writeFile(t, filepath.Join(dir, "packages", "inner", "c", "package.json"), `{
+	"name": "@inner/c"
+}`)
+	writeFile(t, filepath.Join(dir, "package-lock.json"), `{
+	"lockfileVersion": 3,
+	"packages": {
+		"node_modules/@mono/a": { "version": "1.0.0" }
+	}
+}`)
+
+	for j := 0; j < 10; j++ {
+		pkgs := map[string]*packageInfo{}
+		for _, p := range []string{"package.json", "packages/a/package.json", "packages/inner/package.json", "packages/inner/c/package.json"} {
+			pkg, err := loadPackage(filepath.Join(dir, p))
+			assert.Nil(t, err)
+			pkgs[pkg.path] = pkg
+		}
+		assert.Nil(t, resolveWorkspaces(pkgs))
+
+		root := pkgs[filepath.Join(dir, "package.json")]
+		inner := pkgs[filepath.Join(dir, "packages", "inner", "package.json")]
+		assert.Nil(t, root.workspace)
+		assert.Equal(t, root, inner.workspace)
+		assert.Equal(t, root, pkgs[filepath.Join(dir, "packages", "a", "package.json")].workspace)
+		assert.Equal(t, inner, pkgs[filepath.Join(dir, "packages", "inner", "c", "package.json")].workspace)
+
+		projsDB := model.NewProjects()
+		filesDB := model.NewFiles()
+		importer := NewImporter(consoles.NewStdOutConsole(), nil)
+		lockfiles := map[string]lockfile{}
+		for _, pkg := range pkgs {
+			assert.Nil(t, importer.process(projsDB, filesDB, pkgs, lockfiles, pkg, &Options{}))
+		}
+
+		a := projsDB.GetOrCreate("mono").Dependencies["@mono/a"]
+		assert.True(t, a.Target.IsCode())
+		assert.Equal(t, 0, a.Versions.Size())
+	}
+}
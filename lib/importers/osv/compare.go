@@ -0,0 +1,87 @@
+package osv
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/pescuma/archer/lib/utils"
+)
+
+// cleanVersion removes range operators used in package.json and similar files. Maven and NuGet ranges, like
+// [1.0,2.0), are not a version, so they return empty. The exact NuGet match [1.0] returns its version.
+func cleanVersion(v string) string {
+	v = strings.TrimSpace(v)
+
+	if strings.HasPrefix(v, "[") || strings.HasPrefix(v, "(") {
+		if strings.HasPrefix(v, "[") && strings.HasSuffix(v, "]") && !strings.Contains(v, ",") {
+			return strings.TrimSpace(v[1 : len(v)-1])
+		}
+		return ""
+	}
+
+	return strings.TrimLeft(v, "^~=>v ")
+}
+
+// compareVersions uses semver when possible, and compares segment by segment otherwise (for ex, maven 1.2.3.4)
+func compareVersions(a, b string) int {
+	a = cleanVersion(a)
+	b = cleanVersion(b)
+
+	av, aErr := semver.StrictNewVersion(a)
+	bv, bErr := semver.StrictNewVersion(b)
+	if aErr == nil && bErr == nil {
+		return av.Compare(bv)
+	}
+
+	as := splitVersion(a)
+	bs := splitVersion(b)
+
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		var ap, bp string
+		if i < len(as) {
+			ap = as[i]
+		}
+		if i < len(bs) {
+			bp = bs[i]
+		}
+
+		if c := compareSegment(ap, bp); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+func splitVersion(v string) []string {
+	return strings.FieldsFunc(v, func(r rune) bool {
+		return r == '.' || r == '-' || r == '+' || r == '_'
+	})
+}
+
+// compareSegment compares numbers numerically. A missing segment is smaller than a number and bigger than a
+// qualifier, so 1.0 < 1.0.1 and 1.0-rc1 < 1.0
+func compareSegment(a, b string) int {
+	an, aErr := strconv.Atoi(a)
+	bn, bErr := strconv.Atoi(b)
+
+	switch {
+	case a == b:
+		return 0
+	case aErr == nil && bErr == nil:
+		return cmp.Compare(an, bn)
+	case a == "":
+		return utils.IIf(bErr == nil, -1, 1)
+	case b == "":
+		return utils.IIf(aErr == nil, 1, -1)
+	case aErr == nil:
+		return 1
+	case bErr == nil:
+		return -1
+	default:
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}
+}
@@ -0,0 +1,277 @@
+package osv
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+// Advisory is the subset of the OSV schema used by archer. See https://ossf.github.io/osv-schema/
+type Advisory struct {
+	ID               string     `json:"id"`
+	Summary          string     `json:"summary"`
+	Details          string     `json:"details"`
+	Aliases          []string   `json:"aliases"`
+	Withdrawn        string     `json:"withdrawn"`
+	Affected         []Affected `json:"affected"`
+	DatabaseSpecific struct {
+		Severity string `json:"severity"`
+	} `json:"database_specific"`
+}
+
+type Affected struct {
+	Package struct {
+		Ecosystem string `json:"ecosystem"`
+		Name      string `json:"name"`
+	} `json:"package"`
+	Ranges   []Range  `json:"ranges"`
+	Versions []string `json:"versions"`
+}
+
+type Range struct {
+	Type   string  `json:"type"`
+	Events []Event `json:"events"`
+}
+
+type Event struct {
+	Introduced   string `json:"introduced"`
+	Fixed        string `json:"fixed"`
+	LastAffected string `json:"last_affected"`
+	Limit        string `json:"limit"`
+}
+
+var supportedEcosystems = map[string]bool{
+	"Go":    true,
+	"Maven": true,
+	"npm":   true,
+	"NuGet": true,
+}
+
+// loadAdvisories reads all json files inside a directory or a zip file, ignoring advisories of other ecosystems
+func loadAdvisories(path string) ([]*Advisory, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Advisory
+	add := func(r io.Reader) error {
+		a, err := parseAdvisory(r)
+		if err != nil {
+			return err
+		}
+
+		if a != nil {
+			result = append(result, a)
+		}
+		return nil
+	}
+
+	if stat.IsDir() {
+		err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+				return nil
+			}
+
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+
+			defer func() {
+				_ = f.Close()
+			}()
+
+			return add(f)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return result, nil
+	}
+
+	z, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = z.Close()
+	}()
+
+	for _, zf := range z.File {
+		if zf.FileInfo().IsDir() || !strings.HasSuffix(zf.Name, ".json") {
+			continue
+		}
+
+		f, err := zf.Open()
+		if err != nil {
+			return nil, err
+		}
+
+		err = add(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func parseAdvisory(r io.Reader) (*Advisory, error) {
+	var a Advisory
+	err := json.NewDecoder(r).Decode(&a)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.ID == "" || a.Withdrawn != "" {
+		return nil, nil
+	}
+
+	a.Affected = filterAffected(a.Affected)
+	if len(a.Affected) == 0 {
+		return nil, nil
+	}
+
+	return &a, nil
+}
+
+func filterAffected(as []Affected) []Affected {
+	var result []Affected
+	for _, a := range as {
+		if supportedEcosystems[ecosystemName(a.Package.Ecosystem)] {
+			result = append(result, a)
+		}
+	}
+	return result
+}
+
+// ecosystemName removes the suffix of ecosystems like Debian:11
+func ecosystemName(ecosystem string) string {
+	if i := strings.Index(ecosystem, ":"); i >= 0 {
+		return ecosystem[:i]
+	}
+	return ecosystem
+}
+
+// packageKey is used to match the library projects to the advisories. NuGet package names are case-insensitive.
+func packageKey(ecosystem, name string) string {
+	if ecosystem == "NuGet" {
+		name = strings.ToLower(name)
+	}
+	return ecosystem + "|" + name
+}
+
+// purlEcosystems maps the package url types stored by the SBOM importer to the OSV ecosystems
+var purlEcosystems = map[string]string{
+	"golang": "Go",
+	"maven":  "Maven",
+	"npm":    "npm",
+	"nuget":  "NuGet",
+}
+
+// dependencyEcosystem returns the ecosystem of the library of a dependency, based on the manifest of the project
+// that declared it. Returns empty if it is unknown.
+func dependencyEcosystem(d *model.ProjectDependency) string {
+	if e := d.GetData("ecosystem"); e != "" {
+		return purlEcosystems[e]
+	}
+
+	name := filepath.Base(d.Source.ProjectFile)
+	switch {
+	case name == "pom.xml", name == "build.gradle", name == "build.gradle.kts":
+		return "Maven"
+	case name == "package.json":
+		return "npm"
+	case name == "go.mod":
+		return "Go"
+	case strings.HasSuffix(name, ".csproj"):
+		return "NuGet"
+	default:
+		return ""
+	}
+}
+
+// IsAffected checks if the version is affected, using the explicit versions and the ranges
+func (a *Affected) IsAffected(version string) bool {
+	for _, v := range a.Versions {
+		if v == version {
+			return true
+		}
+	}
+
+	for _, r := range a.Ranges {
+		if r.Type == "GIT" {
+			continue
+		}
+
+		if r.isAffected(version) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Range) isAffected(version string) bool {
+	for _, e := range r.Events {
+		if e.Introduced == "" {
+			continue
+		}
+		if e.Introduced != "0" && compareVersions(version, e.Introduced) < 0 {
+			continue
+		}
+
+		if !r.fixedBefore(version, e.Introduced) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fixedBefore checks if there is a fixed or last affected event between introduced and version
+func (r *Range) fixedBefore(version string, introduced string) bool {
+	for _, e := range r.Events {
+		switch {
+		case e.Fixed != "":
+			if (introduced == "0" || compareVersions(e.Fixed, introduced) > 0) && compareVersions(version, e.Fixed) >= 0 {
+				return true
+			}
+		case e.LastAffected != "":
+			if (introduced == "0" || compareVersions(e.LastAffected, introduced) >= 0) && compareVersions(version, e.LastAffected) > 0 {
+				return true
+			}
+		case e.Limit != "":
+			if compareVersions(version, e.Limit) >= 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// FixedVersions returns the versions that fix the advisory
+func (a *Affected) FixedVersions() []string {
+	var result []string
+	for _, r := range a.Ranges {
+		for _, e := range r.Events {
+			if e.Fixed != "" && r.Type != "GIT" {
+				result = append(result, e.Fixed)
+			}
+		}
+	}
+	return result
+}
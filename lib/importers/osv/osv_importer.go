@@ -0,0 +1,136 @@
+package osv
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-set/v2"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages"
+)
+
+type Importer struct {
+	console consoles.Console
+	storage storages.Storage
+}
+
+func NewImporter(console consoles.Console, storage storages.Storage) *Importer {
+	return &Importer{
+		console: console,
+		storage: storage,
+	}
+}
+
+type match struct {
+	advisory *Advisory
+	affected *Affected
+}
+
+func (i *Importer) Import(path string) error {
+	projsDB, err := i.storage.LoadProjects()
+	if err != nil {
+		return err
+	}
+
+	i.console.Printf("Loading advisories from %v...\n", path)
+
+	advisories, err := loadAdvisories(path)
+	if err != nil {
+		return err
+	}
+
+	i.console.Printf("Matching %v advisories with dependencies...\n", len(advisories))
+
+	found := importAdvisories(projsDB.ListProjects(model.FilterAll), advisories)
+
+	i.console.Printf("Found %v vulnerable dependencies\n", found)
+
+	return nil
+}
+
+// importAdvisories stores in the dependencies the advisories that affect them, returning the number of vulnerable
+// dependencies
+func importAdvisories(ps []*model.Project, advisories []*Advisory) int {
+	index := map[string][]*match{}
+	for _, a := range advisories {
+		for j := range a.Affected {
+			af := &a.Affected[j]
+			key := packageKey(ecosystemName(af.Package.Ecosystem), af.Package.Name)
+			index[key] = append(index[key], &match{a, af})
+		}
+	}
+
+	found := 0
+
+	for _, p := range ps {
+		for _, d := range p.Dependencies {
+			clearVulns(d)
+
+			if !d.Target.IsExternalDependency() {
+				continue
+			}
+
+			eco := dependencyEcosystem(d)
+			if eco == "" {
+				continue
+			}
+
+			vulns := map[string]string{}
+			for _, m := range index[packageKey(eco, d.Target.Name)] {
+				for _, v := range d.Versions.Slice() {
+					v = cleanVersion(v)
+					if v != "" && m.affected.IsAffected(v) {
+						vulns[m.advisory.ID] = describe(m)
+					}
+				}
+			}
+
+			if len(vulns) == 0 {
+				continue
+			}
+
+			ids := make([]string, 0, len(vulns))
+			for id, desc := range vulns {
+				ids = append(ids, id)
+				d.SetData("vuln:"+id, desc)
+			}
+			sort.Strings(ids)
+
+			d.SetData("vulns", strings.Join(ids, ","))
+			found++
+		}
+	}
+
+	return found
+}
+
+func clearVulns(d *model.ProjectDependency) {
+	for k := range d.Data {
+		if k == "vulns" || strings.HasPrefix(k, "vuln:") {
+			delete(d.Data, k)
+		}
+	}
+}
+
+func describe(m *match) string {
+	result := m.advisory.Summary
+	if result == "" {
+		result = m.advisory.ID
+	}
+
+	if s := m.advisory.DatabaseSpecific.Severity; s != "" {
+		result = fmt.Sprintf("[%v] %v", s, result)
+	}
+
+	fixed := set.From(m.affected.FixedVersions())
+	if fixed.Size() > 0 {
+		vs := fixed.Slice()
+		sort.Slice(vs, func(i, j int) bool { return compareVersions(vs[i], vs[j]) < 0 })
+		result = fmt.Sprintf("%v (fixed in %v)", result, strings.Join(vs, ", "))
+	}
+
+	return result
+}
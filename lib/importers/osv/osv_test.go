@@ -0,0 +1,186 @@
+package osv
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+const guavaAdvisory = `{
+  "id": "GHSA-1",
+  "summary": "Bad guava",
+  "database_specific": { "severity": "HIGH" },
+  "affected": [{
+    "package": { "ecosystem": "Maven", "name": "com.google.guava:guava" },
+    "ranges": [{
+      "type": "ECOSYSTEM",
+      "events": [ { "introduced": "0" }, { "fixed": "32.0.0-android" }, { "introduced": "33.0" }, { "last_affected": "33.1" } ]
+    }]
+  }]
+}`
+
+const leftPadAdvisory = `{
+  "id": "GHSA-2",
+  "affected": [{
+    "package": { "ecosystem": "npm", "name": "left-pad" },
+    "versions": [ "1.0.0" ]
+  }, {
+    "package": { "ecosystem": "PyPI", "name": "left-pad" },
+    "versions": [ "2.0.0" ]
+  }]
+}`
+
+func TestCompareVersions(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, -1, compareVersions("1.2.3", "1.10.0"))
+	assert.Equal(t, 1, compareVersions("2.13.4.1", "2.13.4"))
+	assert.Equal(t, -1, compareVersions("1.0-rc1", "1.0"))
+	assert.Equal(t, 0, compareVersions("^1.2.3", "1.2.3"))
+	assert.Equal(t, -1, compareVersions("31.1-jre", "32.0.0-android"))
+}
+
+func TestCleanVersion(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "1.2.3", cleanVersion("^1.2.3"))
+	assert.Equal(t, "1.2.3", cleanVersion(">= 1.2.3"))
+	assert.Equal(t, "1.2.3", cleanVersion("[1.2.3]"))
+	assert.Equal(t, "", cleanVersion("[1.0,2.0)"))
+	assert.Equal(t, "", cleanVersion("(,1.0]"))
+}
+
+func TestDependencyEcosystem(t *testing.T) {
+	t.Parallel()
+
+	projs := model.NewProjects()
+
+	add := func(from, to string, projectFile string) *model.ProjectDependency {
+		f := projs.GetOrCreate(from)
+		f.Type = model.CodeType
+		f.ProjectFile = projectFile
+		return f.GetOrCreateDependency(projs.GetOrCreate(to))
+	}
+	ecosystem := func(projectFile string, target string) string {
+		return dependencyEcosystem(add("p", target, projectFile))
+	}
+
+	assert.Equal(t, "Maven", ecosystem("/src/pom.xml", "com.google.guava:guava"))
+	assert.Equal(t, "Maven", ecosystem("/src/build.gradle.kts", "com.google.guava:guava"))
+	assert.Equal(t, "npm", ecosystem("/src/package.json", "left-pad"))
+	assert.Equal(t, "Go", ecosystem("/src/go.mod", "github.com/pkg/errors"))
+	assert.Equal(t, "NuGet", ecosystem("/src/App.csproj", "Newtonsoft.Json"))
+	assert.Equal(t, "", ecosystem("/src/Cargo.toml", "serde"))
+	assert.Equal(t, "", ecosystem("", "left-pad"))
+
+	d := add("s", "left-pad", "/src/bom.json")
+	d.SetData("ecosystem", "npm")
+	assert.Equal(t, "npm", dependencyEcosystem(d))
+}
+
+func TestIsAffected(t *testing.T) {
+	t.Parallel()
+
+	a, err := parseAdvisory(strings.NewReader(guavaAdvisory))
+	assert.Nil(t, err)
+
+	af := &a.Affected[0]
+	assert.True(t, af.IsAffected("31.1-jre"))
+	assert.False(t, af.IsAffected("32.1.2-jre"))
+	assert.True(t, af.IsAffected("33.0"))
+	assert.True(t, af.IsAffected("33.1"))
+	assert.False(t, af.IsAffected("33.2"))
+	assert.Equal(t, []string{"32.0.0-android"}, af.FixedVersions())
+}
+
+func TestLoadAdvisories(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "maven"), 0o755))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "maven", "GHSA-1.json"), []byte(guavaAdvisory), 0o644))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "GHSA-2.json"), []byte(leftPadAdvisory), 0o644))
+
+	as, err := loadAdvisories(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(as))
+
+	zipFile := filepath.Join(t.TempDir(), "all.zip")
+	f, err := os.Create(zipFile)
+	assert.Nil(t, err)
+	z := zip.NewWriter(f)
+	w, err := z.Create("GHSA-2.json")
+	assert.Nil(t, err)
+	_, err = w.Write([]byte(leftPadAdvisory))
+	assert.Nil(t, err)
+	assert.Nil(t, z.Close())
+	assert.Nil(t, f.Close())
+
+	as, err = loadAdvisories(zipFile)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(as))
+	assert.Equal(t, 1, len(as[0].Affected))
+}
+
+func TestImportAdvisories(t *testing.T) {
+	t.Parallel()
+
+	projs := model.NewProjects()
+
+	add := func(from, to string, versions ...string) {
+		f := projs.GetOrCreate(from)
+		f.Type = model.CodeType
+		d := f.GetOrCreateDependency(projs.GetOrCreate(to))
+		for _, v := range versions {
+			d.Versions.Insert(v)
+		}
+	}
+
+	add("core", "com.google.guava:guava", "31.1-jre")
+	add("web", "com.google.guava:guava", "32.1.2-jre")
+	add("web", "left-pad", "^1.0.0")
+	add("app", "core")
+	add("app", "web")
+	add("scripts", "left-pad", "1.0.0")
+	projs.GetOrCreate("core").ProjectFile = "/src/core/pom.xml"
+	projs.GetOrCreate("web").ProjectFile = "/src/web/package.json"
+
+	guava, err := parseAdvisory(strings.NewReader(guavaAdvisory))
+	assert.Nil(t, err)
+	leftPad, err := parseAdvisory(strings.NewReader(leftPadAdvisory))
+	assert.Nil(t, err)
+
+	ps := projs.ListProjects(model.FilterAll)
+	found := importAdvisories(ps, []*Advisory{guava, leftPad})
+
+	assert.Equal(t, 2, found)
+
+	d := projs.GetOrCreate("core").Dependencies["com.google.guava:guava"]
+	assert.Equal(t, "GHSA-1", d.GetData("vulns"))
+	assert.Equal(t, "[HIGH] Bad guava (fixed in 32.0.0-android)", d.GetData("vuln:GHSA-1"))
+	assert.Equal(t, "", projs.GetOrCreate("web").Dependencies["com.google.guava:guava"].GetData("vulns"))
+
+	paths := FindVulnerablePaths(ps)
+
+	texts := make([]string, 0, len(paths))
+	for _, p := range paths {
+		texts = append(texts, p.PathText())
+	}
+	assert.Equal(t, []string{
+		"app -> core -> com.google.guava:guava",
+		"app -> web -> left-pad",
+		"core -> com.google.guava:guava",
+		"web -> left-pad",
+	}, texts)
+
+	found = importAdvisories(ps, nil)
+
+	assert.Equal(t, 0, found)
+	assert.Equal(t, 0, len(d.Data))
+}
@@ -0,0 +1,99 @@
+package osv
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+type Vuln struct {
+	ID          string
+	Description string
+}
+
+// VulnerablePath goes from a code project to a library with known vulnerabilities
+type VulnerablePath struct {
+	Path []*model.Project
+	// Dependency is the last edge of the path, the one to the library
+	Dependency *model.ProjectDependency
+	Vulns      []*Vuln
+}
+
+func (v *VulnerablePath) PathText() string {
+	names := make([]string, 0, len(v.Path))
+	for _, p := range v.Path {
+		names = append(names, p.Name)
+	}
+	return strings.Join(names, " -> ")
+}
+
+// ListVulns returns the vulnerabilities stored in the dependency by the importer
+func ListVulns(d *model.ProjectDependency) []*Vuln {
+	ids := d.GetData("vulns")
+	if ids == "" {
+		return nil
+	}
+
+	var result []*Vuln
+	for _, id := range strings.Split(ids, ",") {
+		result = append(result, &Vuln{
+			ID:          id,
+			Description: d.GetData("vuln:" + id),
+		})
+	}
+	return result
+}
+
+// FindVulnerablePaths lists, for each code project, the shortest paths to the vulnerable libraries it depends on
+func FindVulnerablePaths(ps []*model.Project) []*VulnerablePath {
+	var result []*VulnerablePath
+
+	for _, p := range ps {
+		if !p.IsCode() {
+			continue
+		}
+
+		prev := map[*model.Project]*model.Project{p: nil}
+		queue := []*model.Project{p}
+		for len(queue) > 0 {
+			n := queue[0]
+			queue = queue[1:]
+
+			for _, d := range n.ListDependencies(model.FilterAll) {
+				if !d.Target.IsExternalDependency() {
+					if _, ok := prev[d.Target]; !ok {
+						prev[d.Target] = n
+						queue = append(queue, d.Target)
+					}
+					continue
+				}
+
+				vulns := ListVulns(d)
+				if len(vulns) == 0 {
+					continue
+				}
+
+				path := []*model.Project{d.Target}
+				for i := n; i != nil; i = prev[i] {
+					path = append([]*model.Project{i}, path...)
+				}
+
+				result = append(result, &VulnerablePath{
+					Path:       path,
+					Dependency: d,
+					Vulns:      vulns,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Path[0].Name != result[j].Path[0].Name {
+			return result[i].Path[0].Name < result[j].Path[0].Name
+		}
+		return len(result[i].Path) < len(result[j].Path)
+	})
+
+	return result
+}
@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	_ "github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/pescuma/archer/lib/common"
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages"
+)
+
+type Importer struct {
+	console consoles.Console
+	storage storages.Storage
+}
+
+func NewImporter(console consoles.Console, storage storages.Storage) *Importer {
+	return &Importer{
+		console: console,
+		storage: storage,
+	}
+}
+
+func (i *Importer) Import(connectionString string) error {
+	projects, err := i.storage.LoadProjects()
+	if err != nil {
+		return err
+	}
+
+	db, err := sql.Open("postgres", connectionString)
+	if err != nil {
+		return errors.Wrapf(err, "error connecting to PostgreSQL using %v", connectionString)
+	}
+
+	defer func() {
+		_ = db.Close()
+	}()
+
+	db.SetConnMaxLifetime(time.Minute)
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+
+	err = i.importTables(db, projects)
+	if err != nil {
+		return err
+	}
+
+	err = i.importFKs(db, projects)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *Importer) importTables(db *sql.DB, projs *model.Projects) error {
+	results, err := db.Query(`
+		select n.nspname                             schema_name,
+			   c.relname                             table_name,
+			   greatest(c.reltuples, 0)::bigint      rows,
+			   pg_table_size(c.oid)                  data_size,
+			   pg_indexes_size(c.oid)                index_size
+		from pg_class c
+			join pg_namespace n on n.oid = c.relnamespace
+		where c.relkind in ('r', 'p')
+		  and not c.relispartition
+		  and n.nspname not in ('pg_catalog', 'information_schema')
+		  and n.nspname not like 'pg_toast%'
+		`)
+	if err != nil {
+		return errors.Wrap(err, "error querying database tables")
+	}
+
+	defer func() {
+		_ = results.Close()
+	}()
+
+	type tableInfo struct {
+		schemaName string
+		tableName  string
+		rows       int
+		dataSize   int
+		indexSize  int
+	}
+
+	var changedProjs []*model.Project
+
+	for results.Next() {
+		var table tableInfo
+
+		err = results.Scan(&table.schemaName, &table.tableName, &table.rows, &table.dataSize, &table.indexSize)
+		if err != nil {
+			return errors.Wrap(err, "error querying database tables")
+		}
+
+		i.console.Printf("Importing table %v.%v (%v data, %v indexes)\n", table.schemaName, table.tableName,
+			humanize.Bytes(uint64(table.dataSize)), humanize.Bytes(uint64(table.indexSize)))
+
+		proj := projs.GetOrCreate(table.tableName)
+		proj.Groups = []string{table.schemaName}
+		proj.Type = model.DatabaseType
+
+		proj.AddSize("table", &model.Size{
+			Lines: table.rows,
+			Bytes: table.dataSize + table.indexSize,
+			Other: map[string]int{
+				"data":    table.dataSize,
+				"indexes": table.indexSize,
+			},
+		})
+
+		changedProjs = append(changedProjs, proj)
+	}
+
+	common.CreateTableNameParts(changedProjs)
+
+	return results.Err()
+}
+
+func (i *Importer) importFKs(db *sql.DB, projs *model.Projects) error {
+	results, err := db.Query(`
+		select distinct n.nspname  schema_name,
+			   c.relname           table_name,
+			   rn.nspname          referenced_schema_name,
+			   r.relname           referenced_table_name
+		from pg_constraint k
+			join pg_class c on c.oid = k.conrelid
+			join pg_namespace n on n.oid = c.relnamespace
+			join pg_class r on r.oid = k.confrelid
+			join pg_namespace rn on rn.oid = r.relnamespace
+		where k.contype = 'f'
+		  and not c.relispartition
+		`)
+	if err != nil {
+		return errors.Wrap(err, "error querying database FKs")
+	}
+
+	defer func() {
+		_ = results.Close()
+	}()
+
+	type fkInfo struct {
+		schemaName           string
+		tableName            string
+		referencedSchemaName string
+		referencedTableName  string
+	}
+
+	for results.Next() {
+		var fk fkInfo
+
+		err = results.Scan(&fk.schemaName, &fk.tableName, &fk.referencedSchemaName, &fk.referencedTableName)
+		if err != nil {
+			return errors.Wrap(err, "error querying database FKs")
+		}
+
+		i.console.Printf("Importing dependency %v.%v => %v.%v\n",
+			fk.schemaName, fk.tableName, fk.referencedSchemaName, fk.referencedTableName)
+
+		proj := projs.GetOrCreate(fk.tableName)
+
+		dep := projs.GetOrCreate(fk.referencedTableName)
+		proj.GetOrCreateDependency(dep)
+	}
+
+	return results.Err()
+}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/model"
+)
+
+func TestImportDB(t *testing.T) {
+	t.Parallel()
+
+	db := sql.OpenDB(&fakeConnector{results: map[string]*fakeRows{
+		"c.relkind in ('r', 'p')": {
+			columns: []string{"schema_name", "table_name", "rows", "data_size", "index_size"},
+			values: [][]driver.Value{
+				{"public", "customer", int64(2), int64(8192), int64(16384)},
+				{"sales", "customer_order", int64(10), int64(1000), int64(0)},
+			},
+		},
+		"k.contype = 'f'": {
+			columns: []string{"schema_name", "table_name", "referenced_schema_name", "referenced_table_name"},
+			values: [][]driver.Value{
+				{"sales", "customer_order", "public", "customer"},
+			},
+		},
+	}})
+	defer db.Close()
+
+	projs := model.NewProjects()
+	importer := NewImporter(consoles.NewStdOutConsole(), nil)
+	assert.Nil(t, importer.importTables(db, projs))
+	assert.Nil(t, importer.importFKs(db, projs))
+
+	customer := projs.GetOrCreate("customer")
+	assert.Equal(t, model.DatabaseType, customer.Type)
+	assert.Equal(t, []string{"customer"}, customer.Groups)
+	assert.Equal(t, 2, customer.Sizes["table"].Lines)
+	assert.Equal(t, 8192+16384, customer.Sizes["table"].Bytes)
+	assert.Equal(t, 16384, customer.Sizes["table"].Other["indexes"])
+
+	order := projs.GetOrCreate("customer_order")
+	assert.Equal(t, []string{"customer", "customer_order"}, order.Groups)
+	assert.NotNil(t, order.Dependencies["customer"])
+	assert.Empty(t, customer.Dependencies)
+}
+
+func TestImportDBQueryError(t *testing.T) {
+	t.Parallel()
+
+	db := sql.OpenDB(&fakeConnector{results: map[string]*fakeRows{}})
+	defer db.Close()
+
+	err := NewImporter(consoles.NewStdOutConsole(), nil).importTables(db, model.NewProjects())
+	assert.ErrorContains(t, err, "error querying database tables")
+}
+
+// fakeConnector returns the rows of the first result whose key is contained in the query
+type fakeConnector struct {
+	results map[string]*fakeRows
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) { return &fakeConn{c}, nil }
+func (c *fakeConnector) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct {
+	connector *fakeConnector
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{c.connector, query}, nil
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+type fakeStmt struct {
+	connector *fakeConnector
+	query     string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	for key, rows := range s.connector.results {
+		if strings.Contains(s.query, key) {
+			return &fakeRows{columns: rows.columns, values: rows.values}, nil
+		}
+	}
+
+	return nil, errors.New("unexpected query")
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+	pos     int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.values) {
+		return io.EOF
+	}
+
+	copy(dest, r.values[r.pos])
+	r.pos++
+	return nil
+}
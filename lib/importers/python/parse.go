@@ -0,0 +1,371 @@
+package python
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/utils"
+)
+
+// pyPackage holds the information of all the packaging files found in a folder
+type pyPackage struct {
+	dir          string
+	files        []string
+	name         string
+	dependencies []*dependency
+	members      []string
+	workspace    *pyPackage
+}
+
+type dependency struct {
+	Name    string
+	Scope   string
+	Version string
+	Path    string
+	Member  bool
+}
+
+func (p *pyPackage) Name() string {
+	if p.name != "" {
+		return p.name
+	}
+
+	return normalizeName(filepath.Base(p.dir))
+}
+
+// HasManifest returns true if the package has a pyproject.toml or setup.cfg, and not only requirements files
+func (p *pyPackage) HasManifest() bool {
+	return lo.SomeBy(p.files, func(f string) bool { return utils.In(filepath.Base(f), "pyproject.toml", "setup.cfg") })
+}
+
+// ProjectFile returns the main packaging file
+func (p *pyPackage) ProjectFile() string {
+	for _, name := range []string{"pyproject.toml", "setup.cfg"} {
+		for _, f := range p.files {
+			if filepath.Base(f) == name {
+				return f
+			}
+		}
+	}
+
+	return p.files[0]
+}
+
+func (p *pyPackage) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	p.files = append(p.files, path)
+	sort.Strings(p.files)
+
+	name := filepath.Base(path)
+	switch {
+	case name == "pyproject.toml":
+		return p.parsePyproject(data)
+	case name == "setup.cfg":
+		p.parseSetupCfg(string(data))
+	default:
+		scope := "main"
+		if strings.Contains(name, "dev") || strings.Contains(name, "test") {
+			scope = "dev"
+		}
+		p.parseRequirements(string(data), scope)
+	}
+
+	return nil
+}
+
+func (p *pyPackage) parsePyproject(data []byte) error {
+	var content struct {
+		Project struct {
+			Name                 string              `toml:"name"`
+			Dependencies         []string            `toml:"dependencies"`
+			OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+		} `toml:"project"`
+		DependencyGroups map[string][]any `toml:"dependency-groups"`
+		Tool             struct {
+			Poetry struct {
+				Name            string         `toml:"name"`
+				Dependencies    map[string]any `toml:"dependencies"`
+				DevDependencies map[string]any `toml:"dev-dependencies"`
+				Group           map[string]struct {
+					Dependencies map[string]any `toml:"dependencies"`
+				} `toml:"group"`
+			} `toml:"poetry"`
+			Uv struct {
+				Sources   map[string]map[string]any `toml:"sources"`
+				Workspace struct {
+					Members []string `toml:"members"`
+				} `toml:"workspace"`
+			} `toml:"uv"`
+		} `toml:"tool"`
+	}
+
+	err := toml.Unmarshal(data, &content)
+	if err != nil {
+		return err
+	}
+
+	if content.Project.Name != "" {
+		p.name = normalizeName(content.Project.Name)
+	} else if content.Tool.Poetry.Name != "" {
+		p.name = normalizeName(content.Tool.Poetry.Name)
+	}
+
+	p.members = append(p.members, content.Tool.Uv.Workspace.Members...)
+
+	for _, r := range content.Project.Dependencies {
+		p.addRequirement(r, "main")
+	}
+	for _, rs := range sortedValues(content.Project.OptionalDependencies) {
+		for _, r := range rs {
+			p.addRequirement(r, "optional")
+		}
+	}
+	for _, rs := range sortedValues(content.DependencyGroups) {
+		for _, r := range rs {
+			// Can also be {include-group = "..."}
+			if s, ok := r.(string); ok {
+				p.addRequirement(s, "dev")
+			}
+		}
+	}
+
+	p.addPoetryDependencies(content.Tool.Poetry.Dependencies, "main")
+	p.addPoetryDependencies(content.Tool.Poetry.DevDependencies, "dev")
+	for _, g := range sortedValues(content.Tool.Poetry.Group) {
+		p.addPoetryDependencies(g.Dependencies, "dev")
+	}
+
+	for name, source := range content.Tool.Uv.Sources {
+		name = normalizeName(name)
+
+		for _, d := range p.dependencies {
+			if d.Name != name {
+				continue
+			}
+
+			if path, ok := source["path"].(string); ok {
+				d.Path = p.resolvePath(path)
+			}
+			if ws, ok := source["workspace"].(bool); ok && ws {
+				d.Member = true
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *pyPackage) addPoetryDependencies(deps map[string]any, scope string) {
+	names := make([]string, 0, len(deps))
+	for k := range deps {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if strings.EqualFold(name, "python") {
+			continue
+		}
+
+		dep := &dependency{
+			Name:  normalizeName(name),
+			Scope: scope,
+		}
+
+		switch v := deps[name].(type) {
+		case string:
+			dep.Version = v
+		case map[string]any:
+			dep.Version, _ = v["version"].(string)
+			if path, ok := v["path"].(string); ok {
+				dep.Path = p.resolvePath(path)
+			}
+		}
+
+		p.dependencies = append(p.dependencies, dep)
+	}
+}
+
+func (p *pyPackage) parseSetupCfg(content string) {
+	section := ""
+	key := ""
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";"):
+			continue
+
+		case strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]"):
+			section = strings.TrimSpace(trimmed[1 : len(trimmed)-1])
+			key = ""
+			continue
+
+		case line[0] == ' ' || line[0] == '\t':
+			// Continuation of the previous key
+
+		default:
+			parts := strings.SplitN(trimmed, "=", 2)
+			if len(parts) != 2 {
+				continue
+			}
+
+			key = strings.TrimSpace(parts[0])
+			trimmed = strings.TrimSpace(parts[1])
+		}
+
+		switch {
+		case section == "metadata" && key == "name" && trimmed != "":
+			p.name = normalizeName(trimmed)
+		case section == "options" && key == "install_requires":
+			p.addRequirement(trimmed, "main")
+		case section == "options.extras_require":
+			p.addRequirement(trimmed, "optional")
+		}
+	}
+}
+
+func (p *pyPackage) parseRequirements(content string, scope string) {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line), "\\"))
+		for _, sep := range []string{" #", " --"} {
+			if i := strings.Index(line, sep); i >= 0 {
+				line = strings.TrimSpace(line[:i])
+			}
+		}
+
+		editable := false
+		if strings.HasPrefix(line, "-e ") || strings.HasPrefix(line, "--editable ") {
+			line = strings.TrimSpace(line[strings.Index(line, " "):])
+			editable = true
+		}
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-"):
+			continue
+
+		case editable || strings.HasPrefix(line, ".") || strings.HasPrefix(line, "/"):
+			path := strings.TrimPrefix(line, "file:")
+			if strings.Contains(path, "://") {
+				continue
+			}
+			path = strings.SplitN(path, "[", 2)[0]
+
+			p.dependencies = append(p.dependencies, &dependency{
+				Scope: scope,
+				Path:  p.resolvePath(path),
+			})
+
+		default:
+			p.addRequirement(line, scope)
+		}
+	}
+}
+
+var requirementRE = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^]]*])?\s*\(?([^;@)]*)\)?`)
+
+// addRequirement parses a PEP 508 requirement, like "requests[security] >= 2.8.1, == 2.8.* ; python_version < '2.7'"
+func (p *pyPackage) addRequirement(req string, scope string) {
+	m := requirementRE.FindStringSubmatch(strings.TrimSpace(req))
+	if m == nil {
+		return
+	}
+
+	version := strings.ReplaceAll(strings.TrimSpace(m[2]), " ", "")
+	if strings.HasPrefix(version, "==") && !strings.ContainsAny(version, ",*") {
+		version = version[2:]
+	}
+
+	p.dependencies = append(p.dependencies, &dependency{
+		Name:    normalizeName(m[1]),
+		Scope:   scope,
+		Version: version,
+	})
+}
+
+func (p *pyPackage) resolvePath(path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+
+	return filepath.Clean(filepath.Join(p.dir, path))
+}
+
+// normalizeName normalizes a package name following PEP 503
+func normalizeName(name string) string {
+	return strings.ToLower(regexp.MustCompile(`[-_.]+`).ReplaceAllString(name, "-"))
+}
+
+type lock struct {
+	versions map[string]string
+}
+
+// loadLock loads poetry.lock or uv.lock, that use the same format for the packages list
+func loadLock(dir string) (*lock, error) {
+	for _, name := range []string{"uv.lock", "poetry.lock"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		return parseLock(data)
+	}
+
+	return nil, nil
+}
+
+func parseLock(data []byte) (*lock, error) {
+	var content struct {
+		Package []struct {
+			Name    string `toml:"name"`
+			Version string `toml:"version"`
+		} `toml:"package"`
+	}
+
+	err := toml.Unmarshal(data, &content)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &lock{
+		versions: map[string]string{},
+	}
+
+	for _, p := range content.Package {
+		result.versions[normalizeName(p.Name)] = p.Version
+	}
+
+	return result, nil
+}
+
+func (l *lock) Version(name string) string {
+	return l.versions[name]
+}
+
+func sortedValues[V any](m map[string]V) []V {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	result := make([]V, 0, len(m))
+	for _, k := range keys {
+		result = append(result, m[k])
+	}
+	return result
+}
@@ -0,0 +1,293 @@
+package python
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/importers/common"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages"
+	"github.com/pescuma/archer/lib/utils"
+)
+
+type Importer struct {
+	console consoles.Console
+	storage storages.Storage
+}
+
+type Options struct {
+	Groups           []string
+	RespectGitignore bool
+}
+
+func NewImporter(console consoles.Console, storage storages.Storage) *Importer {
+	return &Importer{
+		console: console,
+		storage: storage,
+	}
+}
+
+func (i *Importer) Import(dirs []string, opts *Options) error {
+	projsDB, err := i.storage.LoadProjects()
+	if err != nil {
+		return err
+	}
+
+	filesDB, err := i.storage.LoadFiles()
+	if err != nil {
+		return err
+	}
+
+	pkgs := map[string]*pyPackage{}
+
+	err = common.FindAndImportFiles(i.console, "python packaging files", dirs, isPackagingFile,
+		func(path string) error {
+			dir := filepath.Dir(path)
+			if isVirtualEnv(dir) {
+				return nil
+			}
+
+			pkg, ok := pkgs[dir]
+			if !ok {
+				pkg = &pyPackage{dir: dir}
+				pkgs[dir] = pkg
+			}
+
+			return pkg.Load(path)
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	attachRequirements(pkgs)
+	resolveWorkspaces(pkgs)
+
+	queue := lo.Keys(pkgs)
+	sort.Strings(queue)
+
+	i.console.Printf("Importing %v projects...\n", len(queue))
+
+	locks := map[string]*lock{}
+
+	return common.ImportFiles(queue, func(dir string) error {
+		return i.process(projsDB, filesDB, pkgs, locks, pkgs[dir], opts)
+	})
+}
+
+func isPackagingFile(name string) bool {
+	return name == "pyproject.toml" || name == "setup.cfg" ||
+		(strings.HasPrefix(name, "requirements") && strings.HasSuffix(name, ".txt"))
+}
+
+func isVirtualEnv(dir string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(dir), "/") {
+		if utils.In(part, "venv", "site-packages", "__pycache__") {
+			return true
+		}
+	}
+	return false
+}
+
+// attachRequirements moves the requirements files of dirs without a pyproject.toml or setup.cfg to the nearest
+// package above them. When there is none, they are ignored (for ex. requirements for docs or CI tools).
+func attachRequirements(pkgs map[string]*pyPackage) {
+	for dir, pkg := range pkgs {
+		if pkg.HasManifest() {
+			continue
+		}
+
+		delete(pkgs, dir)
+
+		if target := findParentPackage(pkgs, dir); target != nil {
+			target.files = append(target.files, pkg.files...)
+			sort.Strings(target.files)
+			target.dependencies = append(target.dependencies, pkg.dependencies...)
+		}
+	}
+}
+
+func findParentPackage(pkgs map[string]*pyPackage, dir string) *pyPackage {
+	for {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
+
+		pkg, ok := pkgs[dir]
+		if ok && pkg.HasManifest() {
+			return pkg
+		}
+	}
+}
+
+func resolveWorkspaces(pkgs map[string]*pyPackage) {
+	for _, ws := range pkgs {
+		if len(ws.members) == 0 {
+			continue
+		}
+
+		for dir, pkg := range pkgs {
+			if pkg == ws || pkg.workspace != nil {
+				continue
+			}
+
+			rel, err := filepath.Rel(ws.dir, dir)
+			if err != nil || strings.HasPrefix(rel, "..") {
+				continue
+			}
+			rel = filepath.ToSlash(rel)
+
+			for _, pattern := range ws.members {
+				if m, err := filepath.Match(strings.TrimPrefix(pattern, "./"), rel); err == nil && m {
+					pkg.workspace = ws
+					break
+				}
+			}
+		}
+	}
+}
+
+func (i *Importer) process(projsDB *model.Projects, filesDB *model.Files, pkgs map[string]*pyPackage, locks map[string]*lock,
+	pkg *pyPackage, opts *Options,
+) error {
+	proj := projsDB.GetOrCreate(pkg.Name())
+	proj.Groups = i.computeGroups(pkg, opts)
+	proj.Type = model.CodeType
+	proj.RootDir = pkg.dir
+	proj.ProjectFile = pkg.ProjectFile()
+	proj.Dependencies = make(map[string]*model.ProjectDependency)
+	proj.SeenAt(time.Now())
+
+	dir := proj.GetDirectory(".")
+	dir.Type = model.SourceDir
+	dir.SeenAt(time.Now())
+
+	for _, f := range pkg.files {
+		file := filesDB.GetOrCreate(f)
+		file.ProjectID = &proj.ID
+		file.ProjectDirectoryID = &dir.ID
+		file.SeenAt(time.Now())
+
+		if file.RepositoryID != nil {
+			proj.RepositoryID = file.RepositoryID
+		}
+	}
+
+	lockDir := pkg.dir
+	if pkg.workspace != nil {
+		lockDir = pkg.workspace.dir
+	}
+
+	l, ok := locks[lockDir]
+	if !ok {
+		var err error
+		l, err = loadLock(lockDir)
+		if err != nil {
+			return err
+		}
+
+		locks[lockDir] = l
+	}
+
+	for _, d := range pkg.dependencies {
+		depName := d.Name
+		local := d.Member
+
+		if d.Path != "" {
+			target, ok := pkgs[d.Path]
+			if !ok || target == pkg {
+				continue
+			}
+
+			depName = target.Name()
+			local = true
+
+		} else if pkg.workspace != nil {
+			local = lo.ContainsBy(lo.Values(pkgs), func(o *pyPackage) bool {
+				return (o.workspace == pkg.workspace || o == pkg.workspace) && o.Name() == depName
+			})
+		}
+
+		if depName == "" || depName == proj.Name {
+			continue
+		}
+
+		dp := projsDB.GetOrCreate(depName)
+
+		dep := proj.GetOrCreateDependency(dp)
+		if dep.GetData("scope") == "" {
+			dep.SetData("scope", d.Scope)
+		}
+
+		if local {
+			continue
+		}
+
+		version := ""
+		if l != nil {
+			version = l.Version(depName)
+		}
+		if version == "" {
+			version = d.Version
+		}
+
+		if version != "" {
+			dep.Versions.Insert(version)
+		}
+	}
+
+	filter, err := common.CreateFileFilter(proj.RootDir, opts.RespectGitignore,
+		func(path string) bool {
+			name := filepath.Base(path)
+			return isPackagingFile(name) || utils.In(filepath.Ext(name), ".py", ".pyi")
+		},
+		func(path string, isDir bool) bool {
+			name := filepath.Base(path)
+			if strings.HasPrefix(name, ".") || (isDir && utils.In(name, "venv", "node_modules", "__pycache__", "build", "dist")) {
+				return true
+			}
+
+			// Other packages own their files
+			if isDir && path != proj.RootDir {
+				_, ok := pkgs[path]
+				return ok
+			}
+
+			return false
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = common.MarkDeletedFilesAndUnmarkExistingOnes(filesDB, proj, dir, filter)
+	if err != nil {
+		return err
+	}
+
+	err = common.AddFiles(filesDB, proj, dir, filter)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *Importer) computeGroups(pkg *pyPackage, opts *Options) []string {
+	result := lo.Filter(opts.Groups, func(g string, _ int) bool { return g != "" })
+
+	if pkg.workspace != nil {
+		result = append(result, pkg.workspace.Name())
+	} else {
+		result = append(result, pkg.Name())
+	}
+
+	return result
+}
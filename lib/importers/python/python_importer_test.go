@@ -0,0 +1,119 @@
+package python
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/model"
+)
+
+func TestPackages(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "api", "pyproject.toml"), `
+[project]
+name = "My_API"
+dependencies = [
+    "requests[security] >=2.8.1, <3 ; python_version > '3.8'",
+    "pydantic==2.5.0",
+    "shared",
+]
+
+[project.optional-dependencies]
+test = ["pytest"]
+
+[tool.uv.sources]
+shared = { path = "../shared" }
+`)
+	writeFile(t, filepath.Join(dir, "api", "uv.lock"), `
+version = 1
+
+[[package]]
+name = "requests"
+version = "2.31.0"
+`)
+	writeFile(t, filepath.Join(dir, "shared", "setup.cfg"), `
+[metadata]
+name = shared
+
+[options]
+install_requires =
+    attrs>=23
+    six
+`)
+	writeFile(t, filepath.Join(dir, "scripts", "pyproject.toml"), `
+[project]
+name = "scripts"
+`)
+	writeFile(t, filepath.Join(dir, "scripts", "ci", "requirements-test.txt"), `
+pytest-cov
+`)
+	writeFile(t, filepath.Join(dir, "docs", "requirements.txt"), `
+sphinx
+`)
+	writeFile(t, filepath.Join(dir, "scripts", "requirements.txt"), `
+# tools
+-e ../shared
+click==8.1.7 --hash=sha256:abc
+`)
+	writeFile(t, filepath.Join(dir, "scripts", "requirements-dev.txt"), `
+black
+`)
+
+	pkgs := map[string]*pyPackage{}
+	for _, p := range []string{"api/pyproject.toml", "shared/setup.cfg", "scripts/pyproject.toml", "scripts/requirements.txt",
+		"scripts/requirements-dev.txt", "scripts/ci/requirements-test.txt", "docs/requirements.txt"} {
+		path := filepath.Join(dir, p)
+		pkg, ok := pkgs[filepath.Dir(path)]
+		if !ok {
+			pkg = &pyPackage{dir: filepath.Dir(path)}
+			pkgs[pkg.dir] = pkg
+		}
+		assert.Nil(t, pkg.Load(path))
+	}
+	attachRequirements(pkgs)
+	resolveWorkspaces(pkgs)
+
+	projsDB := model.NewProjects()
+	filesDB := model.NewFiles()
+	importer := NewImporter(consoles.NewStdOutConsole(), nil)
+	locks := map[string]*lock{}
+	for _, pkg := range pkgs {
+		assert.Nil(t, importer.process(projsDB, filesDB, pkgs, locks, pkg, &Options{}))
+	}
+
+	api := projsDB.GetOrCreate("my-api")
+	assert.Equal(t, model.CodeType, api.Type)
+	assert.Equal(t, []string{"2.31.0"}, api.Dependencies["requests"].Versions.Slice())
+	assert.Equal(t, []string{"2.5.0"}, api.Dependencies["pydantic"].Versions.Slice())
+	assert.Equal(t, "optional", api.Dependencies["pytest"].GetData("scope"))
+	assert.True(t, api.Dependencies["shared"].Target.IsCode())
+	assert.Equal(t, 0, api.Dependencies["shared"].Versions.Size())
+
+	shared := projsDB.GetOrCreate("shared")
+	assert.Equal(t, []string{">=23"}, shared.Dependencies["attrs"].Versions.Slice())
+	assert.NotNil(t, shared.Dependencies["six"])
+
+	scripts := projsDB.GetOrCreate("scripts")
+	assert.True(t, scripts.Dependencies["shared"].Target.IsCode())
+	assert.Equal(t, []string{"8.1.7"}, scripts.Dependencies["click"].Versions.Slice())
+	assert.Equal(t, "dev", scripts.Dependencies["black"].GetData("scope"))
+	assert.Equal(t, "dev", scripts.Dependencies["pytest-cov"].GetData("scope"))
+
+	names := lo.Map(projsDB.ListProjects(model.FilterAll), func(p *model.Project, _ int) string { return p.Name })
+	assert.NotContains(t, names, "ci")
+	assert.NotContains(t, names, "docs")
+	assert.NotContains(t, names, "sphinx")
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+}
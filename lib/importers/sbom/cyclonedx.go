@@ -0,0 +1,153 @@
+package sbom
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type cdxBOM struct {
+	BOMFormat    string           `json:"bomFormat"`
+	SpecVersion  string           `json:"specVersion"`
+	SerialNumber string           `json:"serialNumber,omitempty"`
+	Version      int              `json:"version"`
+	Metadata     *cdxMetadata     `json:"metadata,omitempty"`
+	Components   []*cdxComponent  `json:"components,omitempty"`
+	Dependencies []*cdxDependency `json:"dependencies,omitempty"`
+}
+
+type cdxMetadata struct {
+	Timestamp string        `json:"timestamp,omitempty"`
+	Tools     []*cdxTool    `json:"tools,omitempty"`
+	Component *cdxComponent `json:"component,omitempty"`
+}
+
+type cdxTool struct {
+	Name string `json:"name"`
+}
+
+type cdxComponent struct {
+	Type       string         `json:"type"`
+	BOMRef     string         `json:"bom-ref,omitempty"`
+	Group      string         `json:"group,omitempty"`
+	Name       string         `json:"name"`
+	Version    string         `json:"version,omitempty"`
+	PURL       string         `json:"purl,omitempty"`
+	Properties []*cdxProperty `json:"properties,omitempty"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cdxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+func WriteCycloneDX(w io.Writer, doc *Document) error {
+	bom := &cdxBOM{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + uuid.NewString(),
+		Version:      1,
+		Metadata: &cdxMetadata{
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Tools:     []*cdxTool{{Name: "archer"}},
+		},
+	}
+
+	for _, c := range doc.Components {
+		cc := &cdxComponent{
+			Type:    "library",
+			BOMRef:  c.Ref,
+			Name:    c.Name,
+			Version: c.Version,
+			PURL:    c.PURL(),
+		}
+		if c.Application {
+			cc.Type = "application"
+		}
+
+		if c.Ref == doc.Root {
+			if doc.Repository != nil {
+				cc.Properties = append(cc.Properties,
+					&cdxProperty{Name: "archer:repository:name", Value: doc.Repository.Name},
+					&cdxProperty{Name: "archer:repository:vcs", Value: doc.Repository.VCS},
+					&cdxProperty{Name: "archer:repository:dir", Value: doc.Repository.RootDir},
+				)
+			}
+
+			bom.Metadata.Component = cc
+		} else {
+			bom.Components = append(bom.Components, cc)
+		}
+
+		bom.Dependencies = append(bom.Dependencies, &cdxDependency{
+			Ref:       c.Ref,
+			DependsOn: append([]string{}, c.DependsOn...),
+		})
+	}
+
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(bom)
+}
+
+func parseCycloneDX(data []byte) (*Document, error) {
+	var bom cdxBOM
+	err := json.Unmarshal(data, &bom)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{}
+	byRef := map[string]*Component{}
+
+	add := func(cc *cdxComponent) *Component {
+		name, ecosystem := nameFromPURL(cc.PURL)
+		if name == "" {
+			name = cc.Name
+			if cc.Group != "" {
+				name = cc.Group + ":" + cc.Name
+			}
+		}
+
+		c := &Component{
+			Ref:         cc.BOMRef,
+			Name:        name,
+			Version:     cc.Version,
+			Ecosystem:   ecosystem,
+			Application: cc.Type == "application",
+		}
+		if c.Ref == "" {
+			c.Ref = name + "@" + c.Version
+		}
+
+		byRef[c.Ref] = c
+		doc.Components = append(doc.Components, c)
+		return c
+	}
+
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		root := add(bom.Metadata.Component)
+		root.Application = true
+		doc.Root = root.Ref
+		doc.Name = root.Name
+	}
+
+	for _, cc := range bom.Components {
+		add(cc)
+	}
+
+	for _, d := range bom.Dependencies {
+		if c, ok := byRef[d.Ref]; ok {
+			c.DependsOn = append(c.DependsOn, d.DependsOn...)
+		}
+	}
+
+	return doc, nil
+}
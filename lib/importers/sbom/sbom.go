@@ -0,0 +1,246 @@
+package sbom
+
+import (
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+// Document is the format independent representation of a SBOM
+type Document struct {
+	Name string
+	// Root is the ref of the component described by the document
+	Root       string
+	Repository *model.Repository
+	Components []*Component
+}
+
+type Component struct {
+	Ref       string
+	Name      string
+	Version   string
+	Ecosystem string
+	// Application is true for code projects
+	Application bool
+	DependsOn   []string
+}
+
+// PURL returns the package url of the component, or empty for code projects
+func (c *Component) PURL() string {
+	if c.Application {
+		return ""
+	}
+
+	var path string
+	switch c.Ecosystem {
+	case "maven":
+		path = strings.Replace(c.Name, ":", "/", 1)
+	case "npm":
+		path = strings.Replace(c.Name, "@", "%40", 1)
+	case "pypi":
+		path = strings.ToLower(c.Name)
+	case "":
+		return ""
+	default:
+		path = c.Name
+	}
+
+	result := "pkg:" + c.Ecosystem + "/" + path
+	if c.Version != "" {
+		version := c.Version
+		if c.Ecosystem == "golang" && !strings.HasPrefix(version, "v") {
+			version = "v" + version
+		}
+		result += "@" + url.PathEscape(version)
+	}
+	return result
+}
+
+// Build creates a document with the projects and all the projects they depend on, directly or indirectly
+func Build(name string, projects []*model.Project, repo *model.Repository) *Document {
+	doc := &Document{
+		Name:       name,
+		Repository: repo,
+	}
+
+	comps := map[string]*Component{}
+	getComp := func(p *model.Project, version string, ecosystem string) *Component {
+		ref := p.Name
+		if p.IsExternalDependency() && version != "" {
+			ref += "@" + version
+		}
+
+		c, ok := comps[ref]
+		if !ok {
+			c = &Component{
+				Ref:         ref,
+				Name:        p.Name,
+				Version:     version,
+				Ecosystem:   ecosystem,
+				Application: !p.IsExternalDependency(),
+			}
+			comps[ref] = c
+			doc.Components = append(doc.Components, c)
+		}
+		return c
+	}
+
+	addDep := func(c *Component, ref string) {
+		for _, d := range c.DependsOn {
+			if d == ref {
+				return
+			}
+		}
+		c.DependsOn = append(c.DependsOn, ref)
+	}
+
+	if len(projects) == 1 {
+		doc.Root = getComp(projects[0], "", "").Ref
+	} else {
+		root := &Component{
+			Ref:         name,
+			Name:        name,
+			Application: true,
+		}
+		comps[root.Ref] = root
+		doc.Components = append(doc.Components, root)
+		doc.Root = root.Ref
+
+		for _, p := range projects {
+			addDep(root, getComp(p, "", "").Ref)
+		}
+	}
+
+	ecosystems := map[*model.Project]string{}
+	projComps := map[*model.Project][]*Component{}
+	targetComps := map[*model.ProjectDependency][]*Component{}
+
+	// First find all projects and versions, then add the dependencies, so all versions of a library get them
+	var reachable []*model.Project
+	visited := map[*model.Project]bool{}
+	for _, p := range projects {
+		projComps[p] = []*Component{getComp(p, "", "")}
+		visited[p] = true
+		reachable = append(reachable, p)
+	}
+
+	for i := 0; i < len(reachable); i++ {
+		p := reachable[i]
+
+		for _, d := range p.ListDependencies(model.FilterAll) {
+			t := d.Target
+
+			if t.IsExternalDependency() {
+				if _, ok := ecosystems[t]; !ok {
+					ecosystems[t] = lo.CoalesceOrEmpty(ecosystems[p], ecosystemFromProjectFile(p.ProjectFile), ecosystemFromName(t.Name))
+				}
+
+				versions := d.Versions.Slice()
+				sort.Strings(versions)
+				if len(versions) == 0 {
+					versions = []string{""}
+				}
+
+				for _, v := range versions {
+					targetComps[d] = append(targetComps[d], getComp(t, v, ecosystems[t]))
+				}
+			} else {
+				targetComps[d] = append(targetComps[d], getComp(t, "", ""))
+			}
+
+			for _, tc := range targetComps[d] {
+				if !lo.Contains(projComps[t], tc) {
+					projComps[t] = append(projComps[t], tc)
+				}
+			}
+
+			if !visited[t] {
+				visited[t] = true
+				reachable = append(reachable, t)
+			}
+		}
+	}
+
+	for _, p := range reachable {
+		for _, d := range p.ListDependencies(model.FilterAll) {
+			for _, sc := range projComps[p] {
+				for _, tc := range targetComps[d] {
+					addDep(sc, tc.Ref)
+				}
+			}
+		}
+	}
+
+	return doc
+}
+
+func ecosystemFromProjectFile(path string) string {
+	name := filepath.Base(path)
+
+	switch {
+	case path == "":
+		return ""
+	case name == "pom.xml" || strings.HasPrefix(name, "build.gradle") || strings.HasPrefix(name, "settings.gradle"):
+		return "maven"
+	case name == "package.json":
+		return "npm"
+	case name == "go.mod":
+		return "golang"
+	case name == "Cargo.toml":
+		return "cargo"
+	case name == "pyproject.toml" || name == "setup.cfg" || name == "setup.py" || strings.HasPrefix(name, "requirements"):
+		return "pypi"
+	case strings.HasSuffix(name, ".csproj"):
+		return "nuget"
+	default:
+		return ""
+	}
+}
+
+// ecosystemFromName uses the naming used by the importers when the project file is unknown
+func ecosystemFromName(name string) string {
+	switch {
+	case strings.Contains(name, ":"):
+		return "maven"
+	case strings.Contains(name, "/") && strings.Contains(strings.Split(name, "/")[0], "."):
+		return "golang"
+	default:
+		return ""
+	}
+}
+
+// nameFromPURL returns the project name, using the same naming as the importers, and the ecosystem of a package url
+func nameFromPURL(purl string) (string, string) {
+	if !strings.HasPrefix(purl, "pkg:") {
+		return "", ""
+	}
+
+	purl = purl[4:]
+	if i := strings.IndexAny(purl, "?#"); i >= 0 {
+		purl = purl[:i]
+	}
+	if i := strings.LastIndex(purl, "@"); i > strings.LastIndex(purl, "/") {
+		purl = purl[:i]
+	}
+
+	ecosystem, path, ok := strings.Cut(purl, "/")
+	if !ok {
+		return "", ""
+	}
+
+	path, err := url.PathUnescape(path)
+	if err != nil {
+		return "", ""
+	}
+
+	if ecosystem == "maven" {
+		path = strings.Replace(path, "/", ":", 1)
+	}
+
+	return path, ecosystem
+}
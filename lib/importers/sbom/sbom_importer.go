@@ -0,0 +1,143 @@
+package sbom
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages"
+)
+
+type Importer struct {
+	console consoles.Console
+	storage storages.Storage
+}
+
+type Options struct {
+	Groups []string
+}
+
+func NewImporter(console consoles.Console, storage storages.Storage) *Importer {
+	return &Importer{
+		console: console,
+		storage: storage,
+	}
+}
+
+func (i *Importer) Import(path string, opts *Options) error {
+	projsDB, err := i.storage.LoadProjects()
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	doc, err := parse(data)
+	if err != nil {
+		return errors.Wrapf(err, "error parsing %v", path)
+	}
+
+	if doc.Name == "" {
+		doc.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	i.console.Printf("Importing %v components from %v...\n", len(doc.Components), path)
+
+	importDocument(projsDB, doc, path, opts)
+
+	return nil
+}
+
+func parse(data []byte) (*Document, error) {
+	var header struct {
+		BOMFormat   string `json:"bomFormat"`
+		SPDXVersion string `json:"spdxVersion"`
+	}
+	err := json.Unmarshal(data, &header)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case header.BOMFormat == "CycloneDX":
+		return parseCycloneDX(data)
+	case header.SPDXVersion != "":
+		return parseSPDX(data)
+	default:
+		return nil, errors.New("unknown SBOM format: only CycloneDX and SPDX JSON are supported")
+	}
+}
+
+func importDocument(projsDB *model.Projects, doc *Document, path string, opts *Options) {
+	if doc.Root == "" {
+		root := &Component{
+			Ref:         doc.Name,
+			Name:        doc.Name,
+			Application: true,
+		}
+		doc.Components = append([]*Component{root}, doc.Components...)
+		doc.Root = root.Ref
+	}
+
+	byRef := map[string]*Component{}
+	hasDependencies := false
+	for _, c := range doc.Components {
+		byRef[c.Ref] = c
+		hasDependencies = hasDependencies || len(c.DependsOn) > 0
+	}
+
+	if !hasDependencies {
+		root := byRef[doc.Root]
+		for _, c := range doc.Components {
+			if c != root {
+				root.DependsOn = append(root.DependsOn, c.Ref)
+			}
+		}
+	}
+
+	projs := map[*Component]*model.Project{}
+	for _, c := range doc.Components {
+		proj := projsDB.GetOrCreate(c.Name)
+
+		if c.Application && (proj.IsExternalDependency() || proj.ProjectFile == path) {
+			proj.Groups = opts.Groups
+			proj.Type = model.CodeType
+			proj.ProjectFile = path
+			proj.Dependencies = make(map[string]*model.ProjectDependency)
+			proj.SeenAt(time.Now())
+		}
+
+		projs[c] = proj
+	}
+
+	for _, c := range doc.Components {
+		// Projects imported from other files keep their own dependencies
+		if projs[c].ProjectFile != path {
+			continue
+		}
+
+		for _, ref := range c.DependsOn {
+			t, ok := byRef[ref]
+			if !ok || t == c || projs[t] == projs[c] {
+				continue
+			}
+
+			dep := projs[c].GetOrCreateDependency(projs[t])
+			if t.Version != "" {
+				dep.Versions.Insert(t.Version)
+			}
+			if t.Ecosystem != "" {
+				dep.SetData("ecosystem", t.Ecosystem)
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package sbom
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+func createProjects() *model.Projects {
+	projs := model.NewProjects()
+
+	add := func(from, to string, versions ...string) {
+		f := projs.GetOrCreate(from)
+		f.Type = model.CodeType
+		f.ProjectFile = "/src/" + from + "/pom.xml"
+		d := f.GetOrCreateDependency(projs.GetOrCreate(to))
+		for _, v := range versions {
+			d.Versions.Insert(v)
+		}
+	}
+
+	add("app", "core")
+	add("app", "com.google.guava:guava", "32.1.2-jre")
+	add("core", "com.google.guava:guava", "31.1-jre")
+	add("core", "org.slf4j:slf4j-api", "2.0.9")
+
+	return projs
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	projs := createProjects()
+
+	doc := Build("app", []*model.Project{projs.GetOrCreate("app")}, nil)
+
+	assert.Equal(t, "app", doc.Root)
+
+	byRef := map[string]*Component{}
+	for _, c := range doc.Components {
+		byRef[c.Ref] = c
+	}
+
+	assert.Equal(t, 5, len(doc.Components))
+	assert.Equal(t, []string{"core", "com.google.guava:guava@32.1.2-jre"}, byRef["app"].DependsOn)
+	assert.Equal(t, []string{"com.google.guava:guava@31.1-jre", "org.slf4j:slf4j-api@2.0.9"}, byRef["core"].DependsOn)
+	assert.Equal(t, "pkg:maven/com.google.guava/guava@31.1-jre", byRef["com.google.guava:guava@31.1-jre"].PURL())
+	assert.Equal(t, "", byRef["core"].PURL())
+}
+
+func TestPURL(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "pkg:npm/%40angular/core@16.0.0", (&Component{Name: "@angular/core", Version: "16.0.0", Ecosystem: "npm"}).PURL())
+	assert.Equal(t, "pkg:golang/github.com/pkg/errors@v0.9.1", (&Component{Name: "github.com/pkg/errors", Version: "0.9.1", Ecosystem: "golang"}).PURL())
+
+	name, ecosystem := nameFromPURL("pkg:npm/%40angular/core@16.0.0")
+	assert.Equal(t, "@angular/core", name)
+	assert.Equal(t, "npm", ecosystem)
+
+	name, _ = nameFromPURL("pkg:maven/com.google.guava/guava@31.1-jre?type=jar")
+	assert.Equal(t, "com.google.guava:guava", name)
+
+	name, _ = nameFromPURL("pkg:npm/@scope/name")
+	assert.Equal(t, "@scope/name", name)
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []string{"cyclonedx", "spdx"} {
+		projs := createProjects()
+		doc := Build("root", []*model.Project{projs.GetOrCreate("app"), projs.GetOrCreate("core")}, nil)
+
+		var buf bytes.Buffer
+		if format == "spdx" {
+			assert.Nil(t, WriteSPDX(&buf, doc))
+		} else {
+			assert.Nil(t, WriteCycloneDX(&buf, doc))
+		}
+
+		parsed, err := parse(buf.Bytes())
+		assert.Nil(t, err)
+
+		imported := model.NewProjects()
+		importDocument(imported, parsed, "/sbom.json", &Options{Groups: []string{"g"}})
+
+		root := imported.GetOrCreate("root")
+		assert.True(t, root.IsCode(), format)
+		assert.Equal(t, []string{"g"}, root.Groups, format)
+
+		core := imported.GetOrCreate("core")
+		assert.True(t, core.IsCode(), format)
+
+		guava := core.Dependencies["com.google.guava:guava"]
+		if assert.NotNil(t, guava, format) {
+			assert.Equal(t, []string{"31.1-jre"}, guava.Versions.Slice(), format)
+			assert.True(t, guava.Target.IsExternalDependency(), format)
+		}
+
+		assert.NotNil(t, imported.GetOrCreate("app").Dependencies["core"], format)
+	}
+}
+
+func TestImportDocumentKeepsOtherProjects(t *testing.T) {
+	t.Parallel()
+
+	projs := model.NewProjects()
+	core := projs.GetOrCreate("core")
+	core.Type = model.CodeType
+	core.ProjectFile = "/src/core/pom.xml"
+	core.GetOrCreateDependency(projs.GetOrCreate("org.slf4j:slf4j-api")).Versions.Insert("2.0.9")
+
+	doc := &Document{
+		Root: "app",
+		Components: []*Component{
+			{Ref: "app", Name: "app", Application: true, DependsOn: []string{"core", "left-pad"}},
+			{Ref: "core", Name: "core", Application: true, DependsOn: []string{"left-pad"}},
+			{Ref: "left-pad", Name: "left-pad", Version: "1.3.0", Ecosystem: "npm", DependsOn: []string{"core"}},
+		},
+	}
+
+	importDocument(projs, doc, "/sbom.json", &Options{})
+
+	app := projs.GetOrCreate("app")
+	assert.Equal(t, "/sbom.json", app.ProjectFile)
+	assert.Len(t, app.Dependencies, 2)
+	assert.Equal(t, "npm", app.Dependencies["left-pad"].GetData("ecosystem"))
+
+	assert.Equal(t, "/src/core/pom.xml", core.ProjectFile)
+	assert.Len(t, core.Dependencies, 1)
+	assert.NotNil(t, core.Dependencies["org.slf4j:slf4j-api"])
+
+	assert.Empty(t, projs.GetOrCreate("left-pad").Dependencies)
+}
@@ -0,0 +1,173 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type spdxDocument struct {
+	SPDXVersion       string              `json:"spdxVersion"`
+	DataLicense       string              `json:"dataLicense"`
+	SPDXID            string              `json:"SPDXID"`
+	Name              string              `json:"name"`
+	DocumentNamespace string              `json:"documentNamespace"`
+	CreationInfo      *spdxCreationInfo   `json:"creationInfo"`
+	Packages          []*spdxPackage      `json:"packages"`
+	Relationships     []*spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name             string             `json:"name"`
+	SPDXID           string             `json:"SPDXID"`
+	VersionInfo      string             `json:"versionInfo,omitempty"`
+	DownloadLocation string             `json:"downloadLocation"`
+	FilesAnalyzed    bool               `json:"filesAnalyzed"`
+	SourceInfo       string             `json:"sourceInfo,omitempty"`
+	PrimaryPurpose   string             `json:"primaryPackagePurpose,omitempty"`
+	ExternalRefs     []*spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+var spdxIDInvalidRE = regexp.MustCompile(`[^a-zA-Z0-9.-]+`)
+
+func WriteSPDX(w io.Writer, doc *Document) error {
+	out := &spdxDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              doc.Name,
+		DocumentNamespace: "https://github.com/pescuma/archer/spdx/" + spdxIDInvalidRE.ReplaceAllString(doc.Name, "-") + "-" + uuid.NewString(),
+		CreationInfo: &spdxCreationInfo{
+			Created:  time.Now().UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: archer"},
+		},
+	}
+
+	ids := map[string]string{}
+	for i, c := range doc.Components {
+		ids[c.Ref] = fmt.Sprintf("SPDXRef-%v-%v", i+1, strings.Trim(spdxIDInvalidRE.ReplaceAllString(c.Ref, "-"), "-"))
+	}
+
+	for _, c := range doc.Components {
+		p := &spdxPackage{
+			Name:             c.Name,
+			SPDXID:           ids[c.Ref],
+			VersionInfo:      c.Version,
+			DownloadLocation: "NOASSERTION",
+			PrimaryPurpose:   "LIBRARY",
+		}
+		if c.Application {
+			p.PrimaryPurpose = "APPLICATION"
+		}
+		if purl := c.PURL(); purl != "" {
+			p.ExternalRefs = append(p.ExternalRefs, &spdxExternalRef{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  purl,
+			})
+		}
+		if c.Ref == doc.Root && doc.Repository != nil {
+			p.SourceInfo = fmt.Sprintf("%v repository %v at %v", doc.Repository.VCS, doc.Repository.Name, doc.Repository.RootDir)
+		}
+
+		out.Packages = append(out.Packages, p)
+
+		if c.Ref == doc.Root {
+			out.Relationships = append(out.Relationships, &spdxRelationship{
+				SPDXElementID:      out.SPDXID,
+				RelationshipType:   "DESCRIBES",
+				RelatedSPDXElement: ids[c.Ref],
+			})
+		}
+
+		for _, d := range c.DependsOn {
+			out.Relationships = append(out.Relationships, &spdxRelationship{
+				SPDXElementID:      ids[c.Ref],
+				RelationshipType:   "DEPENDS_ON",
+				RelatedSPDXElement: ids[d],
+			})
+		}
+	}
+
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(out)
+}
+
+func parseSPDX(data []byte) (*Document, error) {
+	var in spdxDocument
+	err := json.Unmarshal(data, &in)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{
+		Name: in.Name,
+	}
+	byID := map[string]*Component{}
+
+	for _, p := range in.Packages {
+		var name, ecosystem string
+		for _, r := range p.ExternalRefs {
+			if r.ReferenceType == "purl" {
+				name, ecosystem = nameFromPURL(r.ReferenceLocator)
+			}
+		}
+		if name == "" {
+			name = p.Name
+		}
+
+		c := &Component{
+			Ref:         p.SPDXID,
+			Name:        name,
+			Version:     p.VersionInfo,
+			Ecosystem:   ecosystem,
+			Application: p.PrimaryPurpose == "APPLICATION",
+		}
+
+		byID[c.Ref] = c
+		doc.Components = append(doc.Components, c)
+	}
+
+	for _, r := range in.Relationships {
+		switch r.RelationshipType {
+		case "DESCRIBES":
+			if c, ok := byID[r.RelatedSPDXElement]; ok && doc.Root == "" {
+				c.Application = true
+				doc.Root = c.Ref
+			}
+		case "DEPENDS_ON":
+			if c, ok := byID[r.SPDXElementID]; ok {
+				c.DependsOn = append(c.DependsOn, r.RelatedSPDXElement)
+			}
+		case "DEPENDENCY_OF":
+			if c, ok := byID[r.RelatedSPDXElement]; ok {
+				c.DependsOn = append(c.DependsOn, r.SPDXElementID)
+			}
+		}
+	}
+
+	return doc, nil
+}
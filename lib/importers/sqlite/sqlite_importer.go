@@ -0,0 +1,206 @@
+package sqlite
+
+import (
+	"database/sql"
+	"path/filepath"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	_ "github.com/glebarez/go-sqlite"
+	"github.com/pkg/errors"
+
+	"github.com/pescuma/archer/lib/common"
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/storages"
+)
+
+type Importer struct {
+	console consoles.Console
+	storage storages.Storage
+}
+
+func NewImporter(console consoles.Console, storage storages.Storage) *Importer {
+	return &Importer{
+		console: console,
+		storage: storage,
+	}
+}
+
+func (i *Importer) Import(file string) error {
+	projects, err := i.storage.LoadProjects()
+	if err != nil {
+		return err
+	}
+
+	db, err := open(file)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = db.Close()
+	}()
+
+	return i.importDB(db, schemaName(file), projects)
+}
+
+func open(file string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", "file:"+file+"?mode=ro")
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening SQLite database %v", file)
+	}
+
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+
+	return db, nil
+}
+
+// schemaName uses the file name as the schema, because SQLite databases don't have one
+func schemaName(file string) string {
+	name := filepath.Base(file)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+func (i *Importer) importDB(db *sql.DB, schema string, projs *model.Projects) error {
+	tables, err := i.listTables(db)
+	if err != nil {
+		return err
+	}
+
+	err = i.importTables(db, schema, tables, projs)
+	if err != nil {
+		return err
+	}
+
+	err = i.importFKs(db, schema, tables, projs)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *Importer) listTables(db *sql.DB) ([]string, error) {
+	results, err := db.Query(`
+		select name
+		from sqlite_master
+		where type = 'table'
+		  and name not like 'sqlite_%'
+		order by name
+		`)
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying database tables")
+	}
+
+	defer func() {
+		_ = results.Close()
+	}()
+
+	var tables []string
+	for results.Next() {
+		var name string
+
+		err = results.Scan(&name)
+		if err != nil {
+			return nil, errors.Wrap(err, "error querying database tables")
+		}
+
+		tables = append(tables, name)
+	}
+
+	return tables, results.Err()
+}
+
+func (i *Importer) importTables(db *sql.DB, schema string, tables []string, projs *model.Projects) error {
+	var changedProjs []*model.Project
+
+	for _, table := range tables {
+		var rows int
+		err := db.QueryRow(`select count(*) from ` + quoteIdentifier(table)).Scan(&rows)
+		if err != nil {
+			return errors.Wrapf(err, "error counting rows of table %v", table)
+		}
+
+		dataSize := i.querySize(db, `select sum(pgsize) from dbstat where name = ?`, table)
+		indexSize := i.querySize(db, `
+			select sum(pgsize)
+			from dbstat
+			where name in (select name from sqlite_master where type = 'index' and tbl_name = ?)
+			`, table)
+
+		i.console.Printf("Importing table %v.%v (%v data, %v indexes)\n", schema, table,
+			humanize.Bytes(uint64(dataSize)), humanize.Bytes(uint64(indexSize)))
+
+		proj := projs.GetOrCreate(table)
+		proj.Groups = []string{schema}
+		proj.Type = model.DatabaseType
+
+		proj.AddSize("table", &model.Size{
+			Lines: rows,
+			Bytes: dataSize + indexSize,
+			Other: map[string]int{
+				"data":    dataSize,
+				"indexes": indexSize,
+			},
+		})
+
+		changedProjs = append(changedProjs, proj)
+	}
+
+	common.CreateTableNameParts(changedProjs)
+
+	return nil
+}
+
+// querySize returns 0 if the size is not available, because dbstat is an optional extension
+func (i *Importer) querySize(db *sql.DB, query string, args ...any) int {
+	var size sql.NullInt64
+
+	err := db.QueryRow(query, args...).Scan(&size)
+	if err != nil {
+		return 0
+	}
+
+	return int(size.Int64)
+}
+
+func (i *Importer) importFKs(db *sql.DB, schema string, tables []string, projs *model.Projects) error {
+	for _, table := range tables {
+		results, err := db.Query(`select distinct "table" from pragma_foreign_key_list(?)`, table)
+		if err != nil {
+			return errors.Wrap(err, "error querying database FKs")
+		}
+
+		var referenced []string
+		for results.Next() {
+			var name string
+
+			err = results.Scan(&name)
+			if err != nil {
+				_ = results.Close()
+				return errors.Wrap(err, "error querying database FKs")
+			}
+
+			referenced = append(referenced, name)
+		}
+
+		_ = results.Close()
+
+		for _, name := range referenced {
+			i.console.Printf("Importing dependency %v.%v => %v.%v\n", schema, table, schema, name)
+
+			proj := projs.GetOrCreate(table)
+
+			dep := projs.GetOrCreate(name)
+			proj.GetOrCreateDependency(dep)
+		}
+	}
+
+	return nil
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
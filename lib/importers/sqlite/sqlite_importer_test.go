@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/model"
+)
+
+func TestImportDB(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "shop.db")
+
+	rw, err := sql.Open("sqlite", file)
+	assert.Nil(t, err)
+	_, err = rw.Exec(`
+		create table customer (id integer primary key, name text);
+		create table customer_order (id integer primary key, customer_id integer references customer(id));
+		create table order_item (
+			id integer primary key,
+			order_id integer references customer_order(id),
+			other_order_id integer references customer_order(id)
+		);
+		create index order_item_order on order_item(order_id);
+		insert into customer (name) values ('a'), ('b');
+		`)
+	assert.Nil(t, err)
+	assert.Nil(t, rw.Close())
+
+	db, err := open(file)
+	assert.Nil(t, err)
+	defer db.Close()
+
+	projs := model.NewProjects()
+	importer := NewImporter(consoles.NewStdOutConsole(), nil)
+	assert.Nil(t, importer.importDB(db, schemaName(file), projs))
+
+	customer := projs.GetOrCreate("customer")
+	assert.Equal(t, model.DatabaseType, customer.Type)
+	assert.Equal(t, []string{"customer"}, customer.Groups)
+	assert.Equal(t, 2, customer.Sizes["table"].Lines)
+
+	order := projs.GetOrCreate("customer_order")
+	assert.NotNil(t, order.Dependencies["customer"])
+
+	item := projs.GetOrCreate("order_item")
+	assert.Equal(t, []string{"order", "order_item"}, item.Groups)
+	assert.Len(t, item.Dependencies, 1)
+	assert.NotNil(t, item.Dependencies["customer_order"])
+}
@@ -102,7 +102,7 @@ func ProcessFiles(paths []string,
 		_ = bar.Add(1)
 	}
 
-	if err := <-group.Err; err != nil {
+	if err := group.Error(); err != nil {
 		return err
 	}
 
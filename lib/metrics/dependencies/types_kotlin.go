@@ -0,0 +1,36 @@
+package dependencies
+
+import (
+	"github.com/antlr/antlr4/runtime/Go/antlr/v4"
+
+	"github.com/pescuma/archer/lib/languages/kotlin_parser"
+)
+
+// ComputeKotlinTypes returns the number of types (classes, interfaces and objects) and how many of them are abstract
+// (interfaces and abstract classes)
+func ComputeKotlinTypes(file kotlin_parser.IKotlinFileContext) (int, int) {
+	l := &typesTreeListener{}
+
+	antlr.NewParseTreeWalker().Walk(l, file)
+
+	return l.types, l.abstractTypes
+}
+
+type typesTreeListener struct {
+	kotlin_parser.BaseKotlinParserListener
+
+	types         int
+	abstractTypes int
+}
+
+func (l *typesTreeListener) EnterClassDeclaration(ctx *kotlin_parser.ClassDeclarationContext) {
+	l.types++
+
+	if ctx.INTERFACE() != nil || (ctx.Modifiers() != nil && isAbstract(ctx.Modifiers())) {
+		l.abstractTypes++
+	}
+}
+
+func (l *typesTreeListener) EnterObjectDeclaration(_ *kotlin_parser.ObjectDeclarationContext) {
+	l.types++
+}
@@ -0,0 +1,35 @@
+package dependencies
+
+import (
+	"testing"
+
+	"github.com/antlr/antlr4/runtime/Go/antlr/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/languages/kotlin_parser"
+)
+
+func computeTypes(contents string) (int, int) {
+	input := antlr.NewInputStream(contents)
+	lexer := kotlin_parser.NewKotlinLexer(input)
+	stream := antlr.NewCommonTokenStream(lexer, 0)
+
+	p := kotlin_parser.NewKotlinParser(stream)
+
+	return ComputeKotlinTypes(p.KotlinFile())
+}
+
+func TestTypes(t *testing.T) {
+	t.Parallel()
+
+	types, abstractTypes := computeTypes(`
+interface A
+abstract class B : A
+sealed class C : B()
+class D : C()
+object E
+`)
+
+	assert.Equal(t, 5, types)
+	assert.Equal(t, 2, abstractTypes)
+}
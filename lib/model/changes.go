@@ -22,6 +22,14 @@ func (c *Changes) LinesTotal() int {
 	return c.LinesModified + c.LinesAdded + c.LinesDeleted
 }
 
+func (c *Changes) Add(other *Changes) {
+	c.In6Months = add(c.In6Months, other.In6Months)
+	c.Total = add(c.Total, other.Total)
+	c.LinesModified = add(c.LinesModified, other.LinesModified)
+	c.LinesAdded = add(c.LinesAdded, other.LinesAdded)
+	c.LinesDeleted = add(c.LinesDeleted, other.LinesDeleted)
+}
+
 func (c *Changes) IsEmpty() bool {
 	return c.In6Months == -1 && c.Total == -1 && c.LinesModified == -1 && c.LinesAdded == -1 && c.LinesDeleted == -1
 }
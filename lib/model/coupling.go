@@ -0,0 +1,29 @@
+package model
+
+// Coupling has Robert Martin's package metrics. -1 means unknown.
+type Coupling struct {
+	// Afferent (Ca) is the number of projects that depend on this one
+	Afferent int
+	// Efferent (Ce) is the number of projects this one depends on
+	Efferent int
+	// Instability (I) is Ce / (Ca + Ce)
+	Instability float64
+	// Abstractness (A) is the ratio of abstract types
+	Abstractness float64
+	// Distance (D) is the distance from the main sequence: |A + I - 1|
+	Distance float64
+}
+
+func NewCoupling() *Coupling {
+	result := &Coupling{}
+	result.Clear()
+	return result
+}
+
+func (c *Coupling) Clear() {
+	c.Afferent = -1
+	c.Efferent = -1
+	c.Instability = -1
+	c.Abstractness = -1
+	c.Distance = -1
+}
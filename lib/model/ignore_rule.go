@@ -17,4 +17,5 @@ const (
 	UnknownRule IgnoreRuleType = iota
 	FileRule
 	CommitRule
+	PersonRule
 )
@@ -31,6 +31,15 @@ func (i *IgnoreRules) AddCommitRule(rule string) {
 	})
 }
 
+func (i *IgnoreRules) AddPersonRule(rule string) {
+	i.maxID++
+	i.rules = append(i.rules, &IgnoreRule{
+		ID:   i.maxID,
+		Type: PersonRule,
+		Rule: rule,
+	})
+}
+
 func (i *IgnoreRules) AddFileRule(rule string) {
 	i.maxID++
 	i.rules = append(i.rules, &IgnoreRule{
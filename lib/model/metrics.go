@@ -3,6 +3,8 @@ package model
 type Metrics struct {
 	GuiceDependencies    int
 	Abstracts            int
+	Types                int
+	AbstractTypes        int
 	CyclomaticComplexity int
 	CognitiveComplexity  int
 	FocusedComplexity    int
@@ -17,6 +19,8 @@ func NewMetrics() *Metrics {
 func (m *Metrics) Clear() {
 	m.GuiceDependencies = -1
 	m.Abstracts = -1
+	m.Types = -1
+	m.AbstractTypes = -1
 	m.CyclomaticComplexity = -1
 	m.CognitiveComplexity = -1
 	m.FocusedComplexity = -1
@@ -25,6 +29,8 @@ func (m *Metrics) Clear() {
 func (m *Metrics) Add(other *Metrics) {
 	m.GuiceDependencies = add(m.GuiceDependencies, other.GuiceDependencies)
 	m.Abstracts = add(m.Abstracts, other.Abstracts)
+	m.Types = add(m.Types, other.Types)
+	m.AbstractTypes = add(m.AbstractTypes, other.AbstractTypes)
 	m.CyclomaticComplexity = add(m.CyclomaticComplexity, other.CyclomaticComplexity)
 	m.CognitiveComplexity = add(m.CognitiveComplexity, other.CognitiveComplexity)
 	m.FocusedComplexity = add(m.FocusedComplexity, other.FocusedComplexity)
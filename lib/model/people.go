@@ -7,6 +7,7 @@ import (
 type People struct {
 	personMaxID ID
 	peopleByID  map[ID]*Person
+	replacedBy  map[ID]ID
 
 	productAreaMaxID   ID
 	productAreasByName map[string]*ProductArea
@@ -16,6 +17,7 @@ type People struct {
 func NewPeople() *People {
 	return &People{
 		peopleByID:         map[ID]*Person{},
+		replacedBy:         map[ID]ID{},
 		productAreasByName: map[string]*ProductArea{},
 		productAreasByID:   map[ID]*ProductArea{},
 	}
@@ -41,6 +43,23 @@ func (ps *People) ListPeople() []*Person {
 	return lo.Values(ps.peopleByID)
 }
 
+// ReplacePerson removes the person. When stored, everything that references it is moved to the target.
+func (ps *People) ReplacePerson(person *Person, target *Person) {
+	delete(ps.peopleByID, person.ID)
+
+	for id, t := range ps.replacedBy {
+		if t == person.ID {
+			ps.replacedBy[id] = target.ID
+		}
+	}
+	ps.replacedBy[person.ID] = target.ID
+}
+
+// ListReplacedPeople returns the IDs of the removed people and the IDs of the people that replaced them
+func (ps *People) ListReplacedPeople() map[ID]ID {
+	return ps.replacedBy
+}
+
 func (ps *People) GetOrCreateProductArea(name string) *ProductArea {
 	return ps.GetOrCreateProductAreaEx(name, nil)
 
@@ -16,6 +16,7 @@ type Person struct {
 	Blame     *Blame
 	Changes   *Changes
 	Data      map[string]string
+	Ignore    bool
 	FirstSeen time.Time
 	LastSeen  time.Time
 }
@@ -35,6 +36,10 @@ func (p *Person) AddName(name string) {
 	p.names[name] = true
 }
 
+func (p *Person) RemoveName(name string) {
+	delete(p.names, name)
+}
+
 func (p *Person) ListNames() []string {
 	result := lo.Keys(p.names)
 	sort.Slice(result, func(i, j int) bool {
@@ -47,6 +52,10 @@ func (p *Person) AddEmail(email string) {
 	p.emails[email] = true
 }
 
+func (p *Person) RemoveEmail(email string) {
+	delete(p.emails, email)
+}
+
 func (p *Person) ListEmails() []string {
 	result := lo.Keys(p.emails)
 	sort.Slice(result, func(i, j int) bool {
@@ -6,6 +6,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/samber/lo"
+
 	"github.com/pescuma/archer/lib/utils"
 )
 
@@ -22,10 +24,12 @@ type Project struct {
 
 	Dirs         map[string]*ProjectDirectory
 	Dependencies map[string]*ProjectDependency
+	Columns      map[string]*ProjectColumn
 	Sizes        map[string]*Size
 	Size         *Size
 	Changes      *Changes
 	Metrics      *Metrics
+	Coupling     *Coupling
 	Data         map[string]string
 	FirstSeen    time.Time
 	LastSeen     time.Time
@@ -39,10 +43,12 @@ func NewProject(id ID, name string, ps *Projects) *Project {
 		ID:           id,
 		Dirs:         map[string]*ProjectDirectory{},
 		Dependencies: map[string]*ProjectDependency{},
+		Columns:      map[string]*ProjectColumn{},
 		Sizes:        map[string]*Size{},
 		Size:         NewSize(),
 		Changes:      NewChanges(),
 		Metrics:      NewMetrics(),
+		Coupling:     NewCoupling(),
 		Data:         map[string]string{},
 		projects:     ps,
 	}
@@ -119,6 +125,68 @@ func (p *Project) GetDirectoryEx(id *ID, relativePath string) *ProjectDirectory
 	return result
 }
 
+func (p *Project) GetColumn(name string) *ProjectColumn {
+	return p.GetColumnEx(nil, name)
+}
+
+func (p *Project) GetColumnEx(id *ID, name string) *ProjectColumn {
+	result, ok := p.Columns[name]
+
+	if !ok {
+		result = NewProjectColumn(createID(&p.projects.columnMaxID, id), name)
+		p.Columns[name] = result
+	}
+
+	return result
+}
+
+func (p *Project) ListColumns() []*ProjectColumn {
+	result := lo.Values(p.Columns)
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// ClearDatabaseColumns removes the information imported from the database, to allow a fresh import
+func (p *Project) ClearDatabaseColumns() {
+	for k, c := range p.Columns {
+		if c.InCode {
+			c.clearDatabase()
+		} else {
+			delete(p.Columns, k)
+		}
+	}
+}
+
+// ClearCodeColumns removes the information imported from the code, to allow a fresh import
+func (p *Project) ClearCodeColumns() {
+	for k, c := range p.Columns {
+		if c.InDatabase {
+			c.clearCode()
+		} else {
+			delete(p.Columns, k)
+		}
+	}
+}
+
+func (p *Project) ListColumnIssues() map[*ProjectColumn][]string {
+	hasDatabase := lo.SomeBy(lo.Values(p.Columns), func(c *ProjectColumn) bool { return c.InDatabase })
+	hasCode := lo.SomeBy(lo.Values(p.Columns), func(c *ProjectColumn) bool { return c.InCode })
+
+	result := map[*ProjectColumn][]string{}
+	for _, c := range p.Columns {
+		issues := c.ListIssues(hasDatabase, hasCode)
+		if len(issues) > 0 {
+			result[c] = issues
+		}
+	}
+
+	return result
+}
+
 func (p *Project) SimpleName() string {
 	return p.LevelSimpleName(0)
 }
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/pescuma/archer/lib/utils"
+)
+
+// ProjectColumn is a column of a database project. It can be found in the database, in the code (ORM mappings) or
+// in both.
+type ProjectColumn struct {
+	ID   ID
+	Name string
+
+	InDatabase bool
+	Type       string
+	Nullable   bool
+	PrimaryKey bool
+	ForeignKey string   // Name of the referenced table
+	Indexes    []string // Indexes that start with the column
+
+	InCode       bool
+	CodeType     string
+	CodeNullable bool
+
+	Data map[string]string
+}
+
+func NewProjectColumn(id ID, name string) *ProjectColumn {
+	return &ProjectColumn{
+		ID:   id,
+		Name: name,
+		Data: map[string]string{},
+	}
+}
+
+func (c *ProjectColumn) IsForeignKeyWithoutIndex() bool {
+	return c.InDatabase && c.ForeignKey != "" && !c.PrimaryKey && len(c.Indexes) == 0
+}
+
+func (c *ProjectColumn) clearDatabase() {
+	c.InDatabase = false
+	c.Type = ""
+	c.Nullable = false
+	c.PrimaryKey = false
+	c.ForeignKey = ""
+	c.Indexes = nil
+}
+
+func (c *ProjectColumn) clearCode() {
+	c.InCode = false
+	c.CodeType = ""
+	c.CodeNullable = false
+}
+
+// ListIssues returns the problems found in the column: FKs without indexes and differences between the database
+// and the code. hasDatabase and hasCode tell if the table was imported from each source.
+func (c *ProjectColumn) ListIssues(hasDatabase bool, hasCode bool) []string {
+	var result []string
+
+	if c.IsForeignKeyWithoutIndex() {
+		result = append(result, fmt.Sprintf("FK to %v without index", c.ForeignKey))
+	}
+
+	switch {
+	case hasCode && c.InDatabase && !c.InCode:
+		result = append(result, "only in database")
+	case hasDatabase && c.InCode && !c.InDatabase:
+		result = append(result, "only in code")
+	case c.InDatabase && c.InCode && c.Nullable != c.CodeNullable:
+		result = append(result, fmt.Sprintf("nullable only in %v", utils.IIf(c.Nullable, "database", "code")))
+	}
+
+	return result
+}
@@ -10,17 +10,28 @@ type Projects struct {
 	byName       map[string]*Project
 	byID         map[ID]*Project
 
+	groupCoupling map[GroupLevel]*Coupling
+
 	dependencyMaxID ID
 	directoryMaxID  ID
+	columnMaxID     ID
 }
 
 func NewProjects() *Projects {
 	return &Projects{
 		byName: map[string]*Project{},
 		byID:   map[ID]*Project{},
+
+		groupCoupling: map[GroupLevel]*Coupling{},
 	}
 }
 
+// GroupLevel identifies a group of projects, named as in Project.LevelSimpleName
+type GroupLevel struct {
+	Level int
+	Name  string
+}
+
 func (ps *Projects) GetOrCreate(name string) *Project {
 	return ps.GetOrCreateEx(name, nil)
 }
@@ -77,3 +88,20 @@ func sortProjects(result []*Project) {
 		return strings.TrimLeft(pi.Name, ":") < strings.TrimLeft(pj.Name, ":")
 	})
 }
+
+// GetGroupCoupling returns the coupling metrics of a group of projects, or nil if they were not computed
+func (ps *Projects) GetGroupCoupling(level int, name string) *Coupling {
+	return ps.groupCoupling[GroupLevel{level, name}]
+}
+
+func (ps *Projects) SetGroupCoupling(level int, name string, c *Coupling) {
+	ps.groupCoupling[GroupLevel{level, name}] = c
+}
+
+func (ps *Projects) ListGroupCouplings() map[GroupLevel]*Coupling {
+	return ps.groupCoupling
+}
+
+func (ps *Projects) ClearGroupCouplings() {
+	ps.groupCoupling = map[GroupLevel]*Coupling{}
+}
@@ -12,6 +12,7 @@ type Repositories struct {
 	byID      map[ID]*Repository
 
 	commitMaxID ID
+	tagMaxID    ID
 }
 
 func NewRepositories() *Repositories {
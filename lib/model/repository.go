@@ -1,6 +1,7 @@
 package model
 
 import (
+	"sort"
 	"time"
 
 	"github.com/samber/lo"
@@ -13,6 +14,8 @@ type Repository struct {
 	VCS     string
 
 	Branch string
+	// MainBranch is the name of the imported main branch, as used in the commit branches
+	MainBranch string
 
 	FilesTotal int
 	FilesHead  int
@@ -24,6 +27,7 @@ type Repository struct {
 
 	commitsByHash map[string]*RepositoryCommit
 	commitsByID   map[ID]*RepositoryCommit
+	tagsByName    map[string]*RepositoryTag
 
 	repositories *Repositories
 }
@@ -37,6 +41,7 @@ func NewRepository(id ID, rootDir string, repositories *Repositories) *Repositor
 		FilesHead:     -1,
 		commitsByHash: map[string]*RepositoryCommit{},
 		commitsByID:   map[ID]*RepositoryCommit{},
+		tagsByName:    map[string]*RepositoryTag{},
 		repositories:  repositories,
 	}
 }
@@ -78,6 +83,49 @@ func (r *Repository) CountCommits() int {
 	return len(r.commitsByHash)
 }
 
+func (r *Repository) GetOrCreateTag(name string) *RepositoryTag {
+	return r.GetOrCreateTagEx(name, nil)
+}
+
+func (r *Repository) GetOrCreateTagEx(name string, id *ID) *RepositoryTag {
+	result, ok := r.tagsByName[name]
+
+	if !ok {
+		result = NewRepositoryTag(createID(&r.repositories.tagMaxID, id), name)
+		r.tagsByName[name] = result
+	}
+
+	return result
+}
+
+func (r *Repository) GetTag(name string) *RepositoryTag {
+	return r.tagsByName[name]
+}
+
+func (r *Repository) DeleteTag(name string) {
+	delete(r.tagsByName, name)
+}
+
+// ListTags returns the tags sorted by date
+func (r *Repository) ListTags() []*RepositoryTag {
+	result := lo.Values(r.tagsByName)
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].Date.Equal(result[j].Date) {
+			return result[i].Date.Before(result[j].Date)
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// InMainBranch returns true if the commit is part of the main branch. Commits imported only from other
+// branches are not.
+func (r *Repository) InMainBranch(c *RepositoryCommit) bool {
+	return r.MainBranch == "" || len(c.Branches) == 0 || lo.Contains(c.Branches, r.MainBranch)
+}
+
 func (r *Repository) SeenAt(ts ...time.Time) {
 	empty := time.Time{}
 
@@ -28,6 +28,9 @@ type RepositoryCommit struct {
 
 	Ignore bool
 
+	// Branches lists the imported branches that contain this commit
+	Branches []string
+
 	Files map[ID]*RepositoryCommitFile
 }
 
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+)
+
+type RepositoryTag struct {
+	ID        ID
+	Name      string
+	CommitID  ID
+	Date      time.Time
+	Annotated bool
+	Message   string
+}
+
+func NewRepositoryTag(id ID, name string) *RepositoryTag {
+	return &RepositoryTag{
+		ID:   id,
+		Name: name,
+	}
+}
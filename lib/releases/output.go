@@ -0,0 +1,106 @@
+package releases
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+func WriteText(w io.Writer, rrs []*RepositoryReleases) error {
+	rrs = lo.Filter(rrs, func(rr *RepositoryReleases, _ int) bool { return len(rr.Releases) > 0 })
+
+	if len(rrs) == 0 {
+		_, err := fmt.Fprintf(w, "No releases found. Run 'archer import git history' to import the tags.\n")
+		return err
+	}
+
+	for _, rr := range rrs {
+		s := rr.Summary
+
+		_, _ = fmt.Fprintf(w, "%v: %v releases", s.Repository.Name, s.Releases)
+		if s.AverageInterval > 0 {
+			_, _ = fmt.Fprintf(w, ", one every %v", daysText(s.AverageInterval))
+		}
+		_, _ = fmt.Fprintf(w, ", %.1f commits and %.0f lines changed per release\n", s.AverageCommits, s.AverageLines)
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, r := range rr.Releases {
+			since := "first"
+			if r.Previous != nil {
+				since = daysText(r.SincePrevious) + " after " + r.Previous.Name
+			}
+
+			_, _ = fmt.Fprintf(tw, "   %v\t%v\t%v\t%v commits\t%v authors\t%v projects\t+%v -%v ~%v\n",
+				r.Tag.Name, r.Tag.Date.Format("2006-01-02"), since,
+				r.Commits, len(r.Authors), len(r.Projects),
+				r.LinesAdded, r.LinesDeleted, r.LinesModified)
+		}
+		_ = tw.Flush()
+	}
+
+	return nil
+}
+
+func daysText(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	if days == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%v days", days)
+}
+
+func ToJSON(rrs []*RepositoryReleases) []map[string]any {
+	result := make([]map[string]any, 0, len(rrs))
+
+	for _, rr := range rrs {
+		s := rr.Summary
+
+		releases := make([]map[string]any, 0, len(rr.Releases))
+		for _, r := range rr.Releases {
+			release := map[string]any{
+				"name":          r.Tag.Name,
+				"date":          r.Tag.Date,
+				"annotated":     r.Tag.Annotated,
+				"commitID":      r.Tag.CommitID,
+				"commits":       r.Commits,
+				"linesModified": r.LinesModified,
+				"linesAdded":    r.LinesAdded,
+				"linesDeleted":  r.LinesDeleted,
+				"authors":       len(r.Authors),
+				"authorIDs":     r.Authors,
+				"projects":      len(r.Projects),
+				"projectIDs":    r.Projects,
+			}
+			if r.Previous != nil {
+				release["previous"] = r.Previous.Name
+				release["daysSincePrevious"] = r.SincePrevious.Hours() / 24
+			}
+
+			releases = append(releases, release)
+		}
+
+		result = append(result, map[string]any{
+			"repo": map[string]any{
+				"id":   s.Repository.ID,
+				"name": s.Repository.Name,
+			},
+			"releases":            s.Releases,
+			"averageDaysInterval": s.AverageInterval.Hours() / 24,
+			"averageCommits":      s.AverageCommits,
+			"averageLines":        s.AverageLines,
+			"data":                releases,
+		})
+	}
+
+	return result
+}
+
+func WriteJSON(w io.Writer, rrs []*RepositoryReleases) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(ToJSON(rrs))
+}
@@ -0,0 +1,163 @@
+package releases
+
+import (
+	"sort"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+type Release struct {
+	Repository *model.Repository
+	Tag        *model.RepositoryTag
+	Previous   *model.RepositoryTag
+
+	// SincePrevious is the time between the previous release and this one, or 0 for the first release
+	SincePrevious time.Duration
+
+	Commits       int
+	LinesModified int
+	LinesAdded    int
+	LinesDeleted  int
+	Authors       []model.ID
+	Projects      []model.ID
+}
+
+type RepositoryReleases struct {
+	Summary  *Summary
+	Releases []*Release
+}
+
+type Summary struct {
+	Repository      *model.Repository
+	Releases        int
+	AverageInterval time.Duration
+	AverageCommits  float64
+	AverageLines    float64
+}
+
+// Compute computes the stats of each tag of the repository, using the commits that are reachable
+// from the tag and were not part of a previous (by date) tag. Ignored commits and commits of ignored people
+// (for ex. bots) are not counted.
+func Compute(repo *model.Repository, people *model.People, files *model.Files) []*Release {
+	var result []*Release
+
+	seen := map[model.ID]bool{}
+
+	var previous *model.RepositoryTag
+	for _, tag := range repo.ListTags() {
+		r := &Release{
+			Repository: repo,
+			Tag:        tag,
+			Previous:   previous,
+		}
+		if previous != nil {
+			r.SincePrevious = tag.Date.Sub(previous.Date)
+		}
+
+		authors := map[model.ID]bool{}
+		projects := map[model.ID]bool{}
+
+		queue := []model.ID{tag.CommitID}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			c := repo.GetCommitByID(id)
+			if c == nil {
+				continue
+			}
+
+			queue = append(queue, c.Parents...)
+
+			authorIDs := lo.Filter(c.AuthorIDs, func(a model.ID, _ int) bool {
+				p := people.GetPersonByID(a)
+				return p == nil || !p.Ignore
+			})
+
+			if c.Ignore || (len(c.AuthorIDs) > 0 && len(authorIDs) == 0) {
+				continue
+			}
+
+			r.Commits++
+			r.LinesModified += max(c.LinesModified, 0)
+			r.LinesAdded += max(c.LinesAdded, 0)
+			r.LinesDeleted += max(c.LinesDeleted, 0)
+
+			for _, a := range authorIDs {
+				authors[a] = true
+			}
+
+			for fileID := range c.Files {
+				f := files.GetByID(fileID)
+				if f != nil && f.ProjectID != nil {
+					projects[*f.ProjectID] = true
+				}
+			}
+		}
+
+		r.Authors = sortedIDs(authors)
+		r.Projects = sortedIDs(projects)
+
+		result = append(result, r)
+		previous = tag
+	}
+
+	return result
+}
+
+// ComputeAll computes the releases of all the repositories
+func ComputeAll(repos []*model.Repository, people *model.People, files *model.Files) []*RepositoryReleases {
+	result := make([]*RepositoryReleases, 0, len(repos))
+
+	for _, repo := range repos {
+		rs := Compute(repo, people, files)
+
+		result = append(result, &RepositoryReleases{
+			Summary:  Summarize(repo, rs),
+			Releases: rs,
+		})
+	}
+
+	return result
+}
+
+func Summarize(repo *model.Repository, rs []*Release) *Summary {
+	result := &Summary{
+		Repository: repo,
+		Releases:   len(rs),
+	}
+
+	if len(rs) == 0 {
+		return result
+	}
+
+	intervals := lo.Filter(rs, func(r *Release, _ int) bool { return r.Previous != nil })
+	if len(intervals) > 0 {
+		result.AverageInterval = lo.SumBy(intervals, func(r *Release) time.Duration { return r.SincePrevious }) /
+			time.Duration(len(intervals))
+	}
+
+	result.AverageCommits = float64(lo.SumBy(rs, func(r *Release) int { return r.Commits })) / float64(len(rs))
+	result.AverageLines = float64(lo.SumBy(rs, func(r *Release) int { return r.Lines() })) / float64(len(rs))
+
+	return result
+}
+
+// Lines returns the number of lines changed in the release
+func (r *Release) Lines() int {
+	return r.LinesModified + r.LinesAdded + r.LinesDeleted
+}
+
+func sortedIDs(m map[model.ID]bool) []model.ID {
+	result := lo.Keys(m)
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
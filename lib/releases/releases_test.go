@@ -0,0 +1,94 @@
+package releases
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+func TestCompute(t *testing.T) {
+	t.Parallel()
+
+	repos := model.NewRepositories()
+	repo := repos.GetOrCreate("/repo")
+	files := model.NewFiles()
+	projects := model.NewProjects()
+	people := model.NewPeople()
+
+	people.GetOrCreatePerson(nil)
+	people.GetOrCreatePerson(nil)
+	people.GetOrCreatePerson(nil)
+	bot := people.GetOrCreatePerson(nil)
+	bot.Ignore = true
+
+	api := projects.GetOrCreate("api")
+	impl := projects.GetOrCreate("impl")
+
+	apiFile := files.GetOrCreate("/repo/api/a.go")
+	apiFile.ProjectID = &api.ID
+	implFile := files.GetOrCreate("/repo/impl/b.go")
+	implFile.ProjectID = &impl.ID
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var previous *model.RepositoryCommit
+	commit := func(hash string, day int, author model.ID, file *model.File, lines int) *model.RepositoryCommit {
+		c := repo.GetOrCreateCommit(hash)
+		c.Date = start.AddDate(0, 0, day)
+		c.AuthorIDs = []model.ID{author}
+		c.LinesAdded = lines
+		c.LinesDeleted = 0
+		c.LinesModified = 0
+		c.Files[file.ID] = model.NewRepositoryCommitFile(file.ID)
+		if previous != nil {
+			c.Parents = []model.ID{previous.ID}
+		}
+		previous = c
+		return c
+	}
+	tag := func(name string, c *model.RepositoryCommit) {
+		t := repo.GetOrCreateTag(name)
+		t.CommitID = c.ID
+		t.Date = c.Date
+	}
+
+	commit("1", 0, 1, apiFile, 10)
+	tag("v1", commit("2", 1, 2, implFile, 20))
+	commit("3", 5, 1, apiFile, 5)
+	ignored := commit("4", 6, 3, apiFile, 100)
+	ignored.Ignore = true
+	commit("bump", 7, bot.ID, implFile, 50)
+	tag("v2", commit("5", 11, 1, apiFile, 1))
+
+	rs := Compute(repo, people, files)
+
+	if assert.Len(t, rs, 2) {
+		assert.Equal(t, "v1", rs[0].Tag.Name)
+		assert.Nil(t, rs[0].Previous)
+		assert.Equal(t, 2, rs[0].Commits)
+		assert.Equal(t, 30, rs[0].LinesAdded)
+		assert.Equal(t, []model.ID{1, 2}, rs[0].Authors)
+		assert.Equal(t, []model.ID{api.ID, impl.ID}, rs[0].Projects)
+
+		assert.Equal(t, "v2", rs[1].Tag.Name)
+		assert.Equal(t, "v1", rs[1].Previous.Name)
+		assert.Equal(t, 10*24*time.Hour, rs[1].SincePrevious)
+		assert.Equal(t, 2, rs[1].Commits)
+		assert.Equal(t, 6, rs[1].LinesAdded)
+		assert.Equal(t, []model.ID{1}, rs[1].Authors)
+		assert.Equal(t, []model.ID{api.ID}, rs[1].Projects)
+	}
+
+	s := Summarize(repo, rs)
+	assert.Equal(t, 2, s.Releases)
+	assert.Equal(t, 10*24*time.Hour, s.AverageInterval)
+	assert.Equal(t, 2.0, s.AverageCommits)
+	assert.Equal(t, 18.0, s.AverageLines)
+
+	assert.Equal(t, []string{"v1", "v2"},
+		lo.Map(repo.ListTags(), func(t *model.RepositoryTag, _ int) string { return t.Name }))
+}
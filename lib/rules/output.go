@@ -0,0 +1,137 @@
+package rules
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+func (v *Violation) PathText() string {
+	names := []string{v.Source.Name}
+	for _, d := range v.Path {
+		names = append(names, d.Target.Name)
+	}
+	return strings.Join(names, " -> ")
+}
+
+func (rs *Rules) WriteText(w io.Writer, results map[*Rule][]*Violation) error {
+	total := 0
+
+	for _, r := range rs.Rules {
+		vs := results[r]
+		total += len(vs)
+
+		if len(vs) == 0 {
+			_, _ = fmt.Fprintf(w, "OK     %v\n", r)
+			continue
+		}
+
+		_, _ = fmt.Fprintf(w, "FAILED %v (%v violations)\n", r, len(vs))
+		for _, v := range vs {
+			_, _ = fmt.Fprintf(w, "   %v\n", v.PathText())
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "\n%v rules checked, %v violations found\n", len(rs.Rules), total)
+	return err
+}
+
+func (rs *Rules) WriteJSON(w io.Writer, results map[*Rule][]*Violation) error {
+	type jsonViolation struct {
+		Source string   `json:"source"`
+		Target string   `json:"target"`
+		Path   []string `json:"path"`
+	}
+	type jsonRule struct {
+		Name       string          `json:"name"`
+		Deny       string          `json:"deny,omitempty"`
+		Only       string          `json:"only,omitempty"`
+		Violations []jsonViolation `json:"violations"`
+	}
+
+	out := make([]jsonRule, 0, len(rs.Rules))
+	for _, r := range rs.Rules {
+		out = append(out, jsonRule{
+			Name: r.String(),
+			Deny: r.Deny,
+			Only: r.Only,
+			Violations: lo.Map(results[r], func(v *Violation, _ int) jsonViolation {
+				return jsonViolation{
+					Source: v.Source.Name,
+					Target: v.Target.Name,
+					Path: append([]string{v.Source.Name}, lo.Map(v.Path, func(d *model.ProjectDependency, _ int) string {
+						return d.Target.Name
+					})...),
+				}
+			}),
+		})
+	}
+
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(map[string]any{"rules": out})
+}
+
+func (rs *Rules) WriteJUnit(w io.Writer, results map[*Rule][]*Violation) error {
+	type failure struct {
+		Message string `xml:"message,attr"`
+		Text    string `xml:",chardata"`
+	}
+	type testCase struct {
+		Name      string   `xml:"name,attr"`
+		ClassName string   `xml:"classname,attr"`
+		Failure   *failure `xml:"failure,omitempty"`
+	}
+	type testSuite struct {
+		XMLName  xml.Name   `xml:"testsuite"`
+		Name     string     `xml:"name,attr"`
+		Tests    int        `xml:"tests,attr"`
+		Failures int        `xml:"failures,attr"`
+		Cases    []testCase `xml:"testcase"`
+	}
+
+	suite := testSuite{
+		Name:  "archer",
+		Tests: len(rs.Rules),
+	}
+
+	for _, r := range rs.Rules {
+		tc := testCase{
+			Name:      r.String(),
+			ClassName: "archer.rules",
+		}
+
+		if vs := results[r]; len(vs) > 0 {
+			suite.Failures++
+			tc.Failure = &failure{
+				Message: fmt.Sprintf("%v violations", len(vs)),
+				Text: strings.Join(lo.Map(vs, func(v *Violation, _ int) string {
+					return v.PathText()
+				}), "\n"),
+			}
+		}
+
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+	err = e.Encode(suite)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,194 @@
+package rules
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pescuma/archer/lib/filters"
+	"github.com/pescuma/archer/lib/model"
+)
+
+// Rules is the content of the rules file. Each rule has one of:
+//
+//   - deny: "<source> -> <dest>": no path from a source to a dest project is allowed. The depth can be limited using
+//     the filter syntax, for ex "a -1-> b" only denies direct dependencies.
+//   - only: "<source> -> <dest>": dest projects can only be used directly by source projects (or by other dest
+//     projects).
+type Rules struct {
+	Rules []*Rule `yaml:"rules"`
+}
+
+type Rule struct {
+	Name string `yaml:"name"`
+	Deny string `yaml:"deny"`
+	Only string `yaml:"only"`
+}
+
+func (r *Rule) String() string {
+	switch {
+	case r.Name != "":
+		return r.Name
+	case r.Deny != "":
+		return "deny: " + r.Deny
+	default:
+		return "only: " + r.Only
+	}
+}
+
+func Load(file string) (*Rules, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(content)
+}
+
+func Parse(content []byte) (*Rules, error) {
+	var result Rules
+
+	err := yaml.Unmarshal(content, &result)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing rules")
+	}
+
+	for i, r := range result.Rules {
+		r.Deny = strings.TrimSpace(r.Deny)
+		r.Only = strings.TrimSpace(r.Only)
+
+		if (r.Deny == "") == (r.Only == "") {
+			return nil, errors.Errorf("rule %v (%v) should have exactly one of deny or only", i+1, r)
+		}
+	}
+
+	return &result, nil
+}
+
+type Violation struct {
+	Rule   *Rule
+	Source *model.Project
+	Target *model.Project
+	Path   []*model.ProjectDependency
+}
+
+// Check evaluates all rules and returns the violations found, grouped by rule
+func (rs *Rules) Check(projs *model.Projects) (map[*Rule][]*Violation, error) {
+	result := map[*Rule][]*Violation{}
+
+	for _, r := range rs.Rules {
+		var vs []*Violation
+		var err error
+
+		if r.Deny != "" {
+			vs, err = checkDeny(projs, r)
+		} else {
+			vs, err = checkOnly(projs, r)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "error checking rule %v", r)
+		}
+
+		result[r] = vs
+	}
+
+	return result, nil
+}
+
+func checkDeny(projs *model.Projects, r *Rule) ([]*Violation, error) {
+	dr, err := filters.ParseDependencyRule(r.Deny)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Violation
+
+	for _, src := range projs.ListProjects(model.FilterExcludeExternal) {
+		if !dr.Source(src) {
+			continue
+		}
+
+		for _, path := range shortestPaths(src, dr.MaxDepth) {
+			target := path[len(path)-1].Target
+			if !dr.Dest(target) {
+				continue
+			}
+
+			result = append(result, &Violation{
+				Rule:   r,
+				Source: src,
+				Target: target,
+				Path:   path,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+func checkOnly(projs *model.Projects, r *Rule) ([]*Violation, error) {
+	dr, err := filters.ParseDependencyRule(r.Only)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Violation
+
+	for _, src := range projs.ListProjects(model.FilterExcludeExternal) {
+		if dr.Source(src) || dr.Dest(src) {
+			continue
+		}
+
+		for _, dep := range src.ListDependencies(model.FilterAll) {
+			if !dr.Dest(dep.Target) {
+				continue
+			}
+
+			result = append(result, &Violation{
+				Rule:   r,
+				Source: src,
+				Target: dep.Target,
+				Path:   []*model.ProjectDependency{dep},
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// shortestPaths returns the shortest path from the source to each reachable project
+func shortestPaths(source *model.Project, maxDepth int) [][]*model.ProjectDependency {
+	var result [][]*model.ProjectDependency
+
+	visited := map[*model.Project]bool{source: true}
+	queue := [][]*model.ProjectDependency{nil}
+
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+
+		if len(path) >= maxDepth {
+			continue
+		}
+
+		p := source
+		if len(path) > 0 {
+			p = path[len(path)-1].Target
+		}
+
+		for _, dep := range p.ListDependencies(model.FilterAll) {
+			if visited[dep.Target] {
+				continue
+			}
+			visited[dep.Target] = true
+
+			next := append(append([]*model.ProjectDependency{}, path...), dep)
+			result = append(result, next)
+			queue = append(queue, next)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,122 @@
+package rules
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+func createProjects() *model.Projects {
+	projs := model.NewProjects()
+
+	add := func(from, to string) {
+		f := projs.GetOrCreate(from)
+		f.Type = model.CodeType
+		t := projs.GetOrCreate(to)
+		t.Type = model.CodeType
+		f.GetOrCreateDependency(t)
+	}
+
+	add("billing-api", "billing-model")
+	add("billing-model", "billing-impl")
+	add("billing-impl", "billing-repository")
+	add("billing-repository", "db-invoice")
+	add("db-invoice", "db-customer")
+	add("billing-impl", "db-customer")
+
+	return projs
+}
+
+func TestDeny(t *testing.T) {
+	t.Parallel()
+
+	rs, err := Parse([]byte(`
+rules:
+  - name: API must not depend on implementation
+    deny: "*-api -> *-impl"
+  - deny: "*-api -1-> *-impl"
+`))
+	assert.Nil(t, err)
+
+	results, err := rs.Check(createProjects())
+	assert.Nil(t, err)
+
+	vs := results[rs.Rules[0]]
+	if assert.Len(t, vs, 1) {
+		assert.Equal(t, "billing-api -> billing-model -> billing-impl", vs[0].PathText())
+	}
+
+	assert.Empty(t, results[rs.Rules[1]])
+}
+
+func TestOnly(t *testing.T) {
+	t.Parallel()
+
+	rs, err := Parse([]byte(`
+rules:
+  - only: "*-repository -> db-*"
+`))
+	assert.Nil(t, err)
+
+	results, err := rs.Check(createProjects())
+	assert.Nil(t, err)
+
+	vs := results[rs.Rules[0]]
+	if assert.Len(t, vs, 1) {
+		assert.Equal(t, "billing-impl -> db-customer", vs[0].PathText())
+	}
+}
+
+func TestInvalidRule(t *testing.T) {
+	t.Parallel()
+
+	_, err := Parse([]byte(`
+rules:
+  - name: both
+    deny: "a -> b"
+    only: "a -> b"
+`))
+	assert.NotNil(t, err)
+}
+
+func TestOutputs(t *testing.T) {
+	t.Parallel()
+
+	rs, err := Parse([]byte(`
+rules:
+  - name: no impl
+    deny: "*-api -> *-impl"
+  - name: repositories
+    only: "*-repository -> billing-repository"
+`))
+	assert.Nil(t, err)
+
+	results, err := rs.Check(createProjects())
+	assert.Nil(t, err)
+
+	var text bytes.Buffer
+	assert.Nil(t, rs.WriteText(&text, results))
+	assert.Equal(t, `FAILED no impl (1 violations)
+   billing-api -> billing-model -> billing-impl
+FAILED repositories (1 violations)
+   billing-impl -> billing-repository
+
+2 rules checked, 2 violations found
+`, text.String())
+
+	var json bytes.Buffer
+	assert.Nil(t, rs.WriteJSON(&json, results))
+	assert.Contains(t, json.String(), `"path": [
+            "billing-api",
+            "billing-model",
+            "billing-impl"
+          ]`)
+
+	var junit bytes.Buffer
+	assert.Nil(t, rs.WriteJUnit(&junit, results))
+	assert.Contains(t, junit.String(), `<testsuite name="archer" tests="2" failures="2">`)
+	assert.Contains(t, junit.String(), `<failure message="1 violations">billing-api -&gt; billing-model -&gt; billing-impl</failure>`)
+}
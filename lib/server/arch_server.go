@@ -1,22 +1,41 @@
 package server
 
 import (
+	"sort"
+
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 	"github.com/samber/lo"
 
 	"github.com/pescuma/archer/lib/model"
 )
 
+type ArchParams struct {
+	Filters
+	GroupBy string `form:"groupBy"`
+}
+
 func (s *server) initArch(r *gin.Engine) {
-	r.GET("/api/arch", getP[Filters](s.archList))
+	r.GET("/api/arch", getP[ArchParams](s.archList))
 }
 
-func (s *server) archList(params *Filters) (any, error) {
-	projs, err := s.listProjects(params)
+func (s *server) archList(params *ArchParams) (any, error) {
+	projs, err := s.listProjects(&params.Filters)
 	if err != nil {
 		return nil, err
 	}
 
+	switch params.GroupBy {
+	case "", "project":
+	case "component":
+		if s.components == nil {
+			return nil, errors.New("no components file configured in the server")
+		}
+		return s.archComponents(projs)
+	default:
+		return nil, errors.Errorf("unknown groupBy: %v", params.GroupBy)
+	}
+
 	var result []gin.H
 
 	projIDs := lo.Associate(projs, func(proj *model.Project) (model.ID, bool) {
@@ -35,3 +54,43 @@ func (s *server) archList(params *Filters) (any, error) {
 
 	return result, nil
 }
+
+func (s *server) archComponents(projs []*model.Project) ([]gin.H, error) {
+	var result []gin.H
+
+	cs, err := s.components.Aggregate(projs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range cs {
+		result = append(result, gin.H{
+			"id":    "component:" + c.Name,
+			"name":  c.Name,
+			"type":  "component",
+			"layer": c.Layer,
+			"projects": lo.Map(c.Projects, func(p *model.Project, _ int) model.ID {
+				return p.ID
+			}),
+			"size":    s.toSize(c.Size),
+			"changes": s.toChanges(c.Changes),
+			"metrics": s.toMetrics(c.Metrics),
+		})
+	}
+
+	for _, c := range cs {
+		deps := lo.Values(c.Dependencies)
+		sort.Slice(deps, func(i, j int) bool { return deps[i].Target.Name < deps[j].Target.Name })
+
+		for _, d := range deps {
+			result = append(result, gin.H{
+				"id":           "component:" + d.Source.Name + "->" + d.Target.Name,
+				"source":       "component:" + d.Source.Name,
+				"target":       "component:" + d.Target.Name,
+				"dependencies": len(d.Dependencies),
+			})
+		}
+	}
+
+	return result, nil
+}
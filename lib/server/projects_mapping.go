@@ -195,6 +195,16 @@ func (s *server) sortProjects(col []*model.Project, field string, asc *bool) err
 		return sortBy(col, func(r *model.Project) int { return r.Metrics.CognitiveComplexity }, *asc)
 	case "metrics.focusedComplexity":
 		return sortBy(col, func(r *model.Project) int { return r.Metrics.FocusedComplexity }, *asc)
+	case "coupling.afferent":
+		return sortBy(col, func(r *model.Project) int { return r.Coupling.Afferent }, *asc)
+	case "coupling.efferent":
+		return sortBy(col, func(r *model.Project) int { return r.Coupling.Efferent }, *asc)
+	case "coupling.instability":
+		return sortBy(col, func(r *model.Project) float64 { return r.Coupling.Instability }, *asc)
+	case "coupling.abstractness":
+		return sortBy(col, func(r *model.Project) float64 { return r.Coupling.Abstractness }, *asc)
+	case "coupling.distance":
+		return sortBy(col, func(r *model.Project) float64 { return r.Coupling.Distance }, *asc)
 	case "firstSeen":
 		return sortBy(col, func(r *model.Project) int64 { return r.FirstSeen.UnixMilli() }, *asc)
 	case "lastSeen":
@@ -216,14 +226,60 @@ func (s *server) toProject(p *model.Project) gin.H {
 		"sizes": lo.MapValues(p.Sizes, func(value *model.Size, key string) gin.H {
 			return s.toSize(value)
 		}),
-		"size":      s.toSize(p.Size),
-		"changes":   s.toChanges(p.Changes),
-		"metrics":   s.toMetrics(p.Metrics),
-		"firstSeen": encodeDate(p.FirstSeen),
-		"lastSeen":  encodeDate(p.LastSeen),
+		"size":           s.toSize(p.Size),
+		"changes":        s.toChanges(p.Changes),
+		"metrics":        s.toMetrics(p.Metrics),
+		"coupling":       s.toCoupling(p.Coupling),
+		"groupsCoupling": s.toGroupsCoupling(p),
+		"columns":        s.toColumns(p),
+		"firstSeen":      encodeDate(p.FirstSeen),
+		"lastSeen":       encodeDate(p.LastSeen),
 	}
 }
 
+func (s *server) toGroupsCoupling(p *model.Project) []gin.H {
+	result := make([]gin.H, 0)
+	for level := 1; level <= len(p.Groups); level++ {
+		name := p.LevelSimpleName(level)
+
+		cp := s.projects.GetGroupCoupling(level, name)
+		if cp == nil {
+			continue
+		}
+
+		result = append(result, gin.H{
+			"level":    level,
+			"name":     name,
+			"coupling": s.toCoupling(cp),
+		})
+	}
+	return result
+}
+
+func (s *server) toColumns(p *model.Project) []gin.H {
+	issues := p.ListColumnIssues()
+
+	result := make([]gin.H, 0, len(p.Columns))
+	for _, c := range p.ListColumns() {
+		result = append(result, gin.H{
+			"id":           c.ID,
+			"name":         c.Name,
+			"inDatabase":   c.InDatabase,
+			"type":         c.Type,
+			"nullable":     c.Nullable,
+			"primaryKey":   c.PrimaryKey,
+			"foreignKey":   c.ForeignKey,
+			"indexes":      c.Indexes,
+			"inCode":       c.InCode,
+			"codeType":     c.CodeType,
+			"codeNullable": c.CodeNullable,
+			"issues":       issues[c],
+		})
+	}
+
+	return result
+}
+
 func (s *server) toProjectReference(id *model.ID) gin.H {
 	if id == nil {
 		return nil
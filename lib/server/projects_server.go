@@ -4,7 +4,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/samber/lo"
 
+	"github.com/pescuma/archer/lib/importers/osv"
 	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/versions"
 )
 
 func (s *server) initProjects(r *gin.Engine) {
@@ -12,6 +14,8 @@ func (s *server) initProjects(r *gin.Engine) {
 	r.GET("/api/projects/:id", get(s.projectGet))
 	r.GET("/api/stats/count/projects", getP[StatsParams](s.statsCountProjects))
 	r.GET("/api/stats/seen/projects", getP[StatsParams](s.statsProjectsSeen))
+	r.GET("/api/libraries/versions", get(s.librariesVersions))
+	r.GET("/api/vulns", getP[Filters](s.vulnsList))
 }
 
 func (s *server) projectsList(params *ListParams) (any, error) {
@@ -77,3 +81,37 @@ func (s *server) statsProjectsSeen(params *StatsParams) (any, error) {
 
 	return result, nil
 }
+
+func (s *server) librariesVersions() (any, error) {
+	cs := versions.FindConflicts(s.projects.ListProjects(model.FilterAll))
+
+	return versions.ToJSON(cs, s.repos), nil
+}
+
+func (s *server) vulnsList(params *Filters) (any, error) {
+	projs, err := s.listProjects(params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]gin.H, 0)
+	for _, v := range osv.FindVulnerablePaths(projs) {
+		result = append(result, gin.H{
+			"path": lo.Map(v.Path, func(p *model.Project, _ int) gin.H {
+				return gin.H{
+					"id":   p.ID,
+					"name": p.Name,
+				}
+			}),
+			"versions": v.Dependency.Versions.Slice(),
+			"vulns": lo.Map(v.Vulns, func(i *osv.Vuln, _ int) gin.H {
+				return gin.H{
+					"id":          i.ID,
+					"description": i.Description,
+				}
+			}),
+		})
+	}
+
+	return result, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/samber/lo"
 
 	"github.com/pescuma/archer/lib/model"
+	"github.com/pescuma/archer/lib/releases"
 )
 
 type CommitPatchParams struct {
@@ -19,6 +20,7 @@ func (s *server) initRepos(r *gin.Engine) {
 	r.GET("/api/repos", getP[ListParams](s.reposList))
 	r.GET("/api/repos/:id", get(s.repoGet))
 	r.GET("/api/commits", getP[ListParams](s.commitsList))
+	r.GET("/api/releases", getP[Filters](s.releasesList))
 	r.PATCH("/api/repos/:repoID/commits/:commitID", patchP[CommitPatchParams](s.commitPatch))
 	r.GET("/api/stats/count/repos", getP[StatsParams](s.statsCountRepos))
 	r.GET("/api/stats/seen/repos", getP[StatsParams](s.statsSeenRepos))
@@ -64,6 +66,15 @@ func (s *server) reposList(params *ListParams) (any, error) {
 	}, nil
 }
 
+func (s *server) releasesList(params *Filters) (any, error) {
+	repos, err := s.listRepos(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return releases.ToJSON(releases.ComputeAll(repos, s.people, s.files)), nil
+}
+
 func (s *server) repoGet() (any, error) {
 	return nil, nil
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/pescuma/archer/frontend"
+	"github.com/pescuma/archer/lib/components"
 	"github.com/pescuma/archer/lib/consoles"
 	"github.com/pescuma/archer/lib/model"
 	"github.com/pescuma/archer/lib/storages"
@@ -15,6 +16,8 @@ import (
 
 type Options struct {
 	Port uint
+	// Components is the file with the logical components, used to group projects
+	Components string
 }
 
 func Run(console consoles.Console, storage storages.Storage, opts *Options) error {
@@ -43,6 +46,7 @@ type server struct {
 	repos           *model.Repositories
 	commits         map[model.ID]*model.RepositoryCommit
 	stats           *model.MonthlyStats
+	components      *components.Config
 }
 
 func newServer(opts *Options) *server {
@@ -100,6 +104,13 @@ func (s *server) load(storage storages.Storage) error {
 		return err
 	}
 
+	if s.opts.Components != "" {
+		s.components, err = components.Load(s.opts.Components, s.projects)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -149,6 +149,10 @@ func encodeMetric(v int) *int {
 	return utils.IIf(v == -1, nil, &v)
 }
 
+func encodeRatio(v float64) *float64 {
+	return utils.IIf(v < 0, nil, &v)
+}
+
 func encodeDate(v time.Time) *time.Time {
 	empty := time.Time{}
 	return utils.IIf(v == empty, nil, &v)
@@ -182,10 +186,22 @@ func (s *server) toBlame(i *model.Blame) gin.H {
 	}
 }
 
+func (s *server) toCoupling(c *model.Coupling) gin.H {
+	return gin.H{
+		"afferent":     encodeMetric(c.Afferent),
+		"efferent":     encodeMetric(c.Efferent),
+		"instability":  encodeRatio(c.Instability),
+		"abstractness": encodeRatio(c.Abstractness),
+		"distance":     encodeRatio(c.Distance),
+	}
+}
+
 func (s *server) toMetrics(i *model.Metrics) gin.H {
 	return gin.H{
 		"guiceDependencies":    i.GuiceDependencies,
 		"abstracts":            i.Abstracts,
+		"types":                encodeMetric(i.Types),
+		"abstractTypes":        encodeMetric(i.AbstractTypes),
 		"cyclomaticComplexity": i.CyclomaticComplexity,
 		"cognitiveComplexity":  i.CognitiveComplexity,
 		"focusedComplexity":    i.FocusedComplexity,
@@ -1,6 +1,7 @@
 package orm
 
 import (
+	"math"
 	"strings"
 
 	"github.com/pescuma/archer/lib/model"
@@ -18,6 +19,22 @@ func decodeMetric(v *int) int {
 	}
 }
 
+func encodeRatio(v float64) *float32 {
+	if v < 0 {
+		return nil
+	}
+	r := float32(math.Round(v*1000) / 1000)
+	return &r
+}
+
+func decodeRatio(v *float32) float64 {
+	if v == nil {
+		return -1
+	} else {
+		return float64(*v)
+	}
+}
+
 func encodeMap[K comparable, V any](m map[K]V) map[K]V {
 	if len(m) == 0 {
 		return nil
@@ -39,6 +39,8 @@ type gormStorage struct {
 	sqlProjs            map[string]*sqlProject
 	sqlProjDeps         map[string]*sqlProjectDependency
 	sqlProjDirs         map[string]*sqlProjectDirectory
+	sqlProjColumns      map[string]*sqlProjectColumn
+	sqlGroupCouplings   map[string]*sqlGroupCoupling
 	sqlFiles            map[string]*sqlFile
 	sqlPeople           map[string]*sqlPerson
 	sqlPersonRepos      map[string]*sqlPersonRepository
@@ -48,6 +50,7 @@ type gormStorage struct {
 	sqlRepoCommits      map[string]*sqlRepositoryCommit
 	sqlRepoCommitFiles  map[string]*sqlRepositoryCommitFile
 	sqlRepoCommitPeople map[string]*sqlRepositoryCommitPerson
+	sqlRepoTags         map[string]*sqlRepositoryTag
 	monthLines          map[string]*sqlMonthLines
 	sqlIgnoreRules      map[string]*sqlIgnoreRule
 }
@@ -73,13 +76,14 @@ func NewGormStorage(d gorm.Dialector, console consoles.Console) (storages.Storag
 
 	err = db.AutoMigrate(
 		&sqlConfig{},
-		&sqlProject{}, &sqlProjectDependency{}, &sqlProjectDirectory{},
+		&sqlProject{}, &sqlProjectDependency{}, &sqlProjectDirectory{}, &sqlProjectColumn{}, &sqlGroupCoupling{},
 		&sqlFile{},
 		&sqlPerson{}, &sqlPersonRepository{}, &sqlPersonFile{}, &sqlProductArea{},
 		&sqlRepository{},
 		&sqlRepositoryCommit{},
 		&sqlRepositoryCommitFile{}, &sqlRepositoryCommitFileDetails{},
 		&sqlRepositoryCommitPerson{},
+		&sqlRepositoryTag{},
 		&sqlMonthLines{},
 		&sqlFileLine{},
 		&sqlIgnoreRule{},
@@ -145,6 +149,22 @@ func (s *gormStorage) LoadProjects() (*model.Projects, error) {
 
 	s.sqlProjDirs = createCache(dirs)
 
+	var columns []*sqlProjectColumn
+	err = s.db.Find(&columns).Error
+	if err != nil {
+		return nil, err
+	}
+
+	s.sqlProjColumns = createCache(columns)
+
+	var groupCouplings []*sqlGroupCoupling
+	err = s.db.Find(&groupCouplings).Error
+	if err != nil {
+		return nil, err
+	}
+
+	s.sqlGroupCouplings = createCache(groupCouplings)
+
 	for _, sp := range projs {
 		p := result.GetOrCreateEx(sp.ProjectName, &sp.ID)
 		p.Groups = sp.Groups
@@ -160,6 +180,7 @@ func (s *gormStorage) LoadProjects() (*model.Projects, error) {
 		p.Size = sp.Size.ToModel()
 		p.Changes = sp.Changes.ToModel()
 		p.Metrics = sp.Metrics.ToModel()
+		p.Coupling = sp.Coupling.ToModel()
 		p.Data = decodeMap(sp.Data)
 		p.FirstSeen = sp.FirstSeen
 		p.LastSeen = sp.LastSeen
@@ -187,6 +208,26 @@ func (s *gormStorage) LoadProjects() (*model.Projects, error) {
 		d.LastSeen = sd.LastSeen
 	}
 
+	for _, sc := range columns {
+		p := result.GetByID(sc.ProjectID)
+
+		c := p.GetColumnEx(&sc.ID, sc.Name)
+		c.InDatabase = sc.InDatabase
+		c.Type = sc.Type
+		c.Nullable = sc.Nullable
+		c.PrimaryKey = sc.PrimaryKey
+		c.ForeignKey = sc.ForeignKey
+		c.Indexes = sc.Indexes
+		c.InCode = sc.InCode
+		c.CodeType = sc.CodeType
+		c.CodeNullable = sc.CodeNullable
+		c.Data = decodeMap(sc.Data)
+	}
+
+	for _, sg := range groupCouplings {
+		result.SetGroupCoupling(sg.Level, sg.Name, sg.Coupling.ToModel())
+	}
+
 	s.projects = result
 	return result, nil
 }
@@ -196,7 +237,57 @@ func (s *gormStorage) WriteProjects() error {
 		return nil
 	}
 
-	return s.writeProjects(s.projects.ListProjects(model.FilterAll))
+	err := s.writeProjects(s.projects.ListProjects(model.FilterAll))
+	if err != nil {
+		return err
+	}
+
+	return s.writeGroupCouplings(s.projects.ListGroupCouplings())
+}
+
+func (s *gormStorage) writeGroupCouplings(couplings map[model.GroupLevel]*model.Coupling) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var sqlGroupCouplings []*sqlGroupCoupling
+	seen := map[string]bool{}
+	for g, c := range couplings {
+		sg := newSqlGroupCoupling(g, c)
+		seen[sg.CacheKey()] = true
+		if prepareChange(&s.sqlGroupCouplings, sg) {
+			sqlGroupCouplings = append(sqlGroupCouplings, sg)
+		}
+	}
+
+	var deleted []*sqlGroupCoupling
+	for k, sg := range s.sqlGroupCouplings {
+		if !seen[k] {
+			deleted = append(deleted, sg)
+			delete(s.sqlGroupCouplings, k)
+		}
+	}
+
+	now := time.Now().Local()
+	db := s.db.Session(&gorm.Session{
+		NowFunc:         func() time.Time { return now },
+		CreateBatchSize: 300,
+	})
+
+	err := db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&sqlGroupCouplings).Error
+	if err != nil {
+		return err
+	}
+
+	addList(&s.sqlGroupCouplings, sqlGroupCouplings)
+
+	for _, sg := range deleted {
+		err = db.Delete(sg).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (s *gormStorage) WriteProject(proj *model.Project) error {
@@ -233,6 +324,29 @@ func (s *gormStorage) writeProjects(projs []*model.Project) error {
 		}
 	}
 
+	var sqlColumns []*sqlProjectColumn
+	var deletedColumns []model.ID
+	for _, p := range projs {
+		for _, c := range p.Columns {
+			sc := newSqlProjectColumn(c, p)
+			if prepareChange(&s.sqlProjColumns, sc) {
+				sqlColumns = append(sqlColumns, sc)
+			}
+		}
+	}
+	projsByID := lo.KeyBy(projs, func(p *model.Project) model.ID { return p.ID })
+	for k, sc := range s.sqlProjColumns {
+		p, ok := projsByID[sc.ProjectID]
+		if !ok {
+			continue
+		}
+
+		if c, ok := p.Columns[sc.Name]; !ok || c.ID != sc.ID {
+			deletedColumns = append(deletedColumns, sc.ID)
+			delete(s.sqlProjColumns, k)
+		}
+	}
+
 	now := time.Now().Local()
 	db := s.db.Session(&gorm.Session{
 		NowFunc:         func() time.Time { return now },
@@ -260,6 +374,20 @@ func (s *gormStorage) writeProjects(projs []*model.Project) error {
 
 	addList(&s.sqlProjDirs, sqlDirs)
 
+	err = db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&sqlColumns).Error
+	if err != nil {
+		return err
+	}
+
+	addList(&s.sqlProjColumns, sqlColumns)
+
+	if len(deletedColumns) > 0 {
+		err = db.Delete(&sqlProjectColumn{}, deletedColumns).Error
+		if err != nil {
+			return err
+		}
+	}
+
 	// TODO delete
 
 	return nil
@@ -456,6 +584,7 @@ func (s *gormStorage) LoadPeople() (*model.People, error) {
 		p.Blame = sp.Blame.ToModel()
 		p.Changes = sp.Changes.ToModel()
 		p.Data = decodeMap(sp.Data)
+		p.Ignore = sp.Ignore
 		p.FirstSeen = sp.FirstSeen
 		p.LastSeen = sp.LastSeen
 	}
@@ -503,11 +632,101 @@ func (s *gormStorage) WritePeople() error {
 
 	addList(&s.sqlAreas, sqlAreas)
 
+	for old, target := range s.people.ListReplacedPeople() {
+		err = s.replacePerson(db, old, target)
+		if err != nil {
+			return err
+		}
+	}
+
 	// TODO delete
 
 	return nil
 }
 
+// replacePerson moves the rows that reference the old person to the target one, and deletes the old person
+func (s *gormStorage) replacePerson(db *gorm.DB, old model.ID, target model.ID) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, u := range []struct {
+			table  any
+			column string
+		}{
+			{&sqlFileLine{}, "author_id"},
+			{&sqlFileLine{}, "committer_id"},
+			{&sqlMonthLines{}, "author_id"},
+			{&sqlMonthLines{}, "committer_id"},
+		} {
+			err := tx.Model(u.table).Where(u.column+" = ?", old).Update(u.column, target).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		// Rows that would duplicate an existing one of the target are deleted
+		for _, u := range []struct {
+			table any
+			key   string
+		}{
+			{&sqlRepositoryCommitPerson{}, "commit_id, role"},
+			{&sqlPersonRepository{}, "repository_id"},
+			{&sqlPersonFile{}, "file_id"},
+		} {
+			stmt := &gorm.Statement{DB: tx}
+			err := stmt.Parse(u.table)
+			if err != nil {
+				return err
+			}
+			table := stmt.Schema.Table
+
+			err = tx.Exec(fmt.Sprintf("update %v set person_id = ? where person_id = ? and (%v) not in "+
+				"(select %v from %v where person_id = ?)", table, u.key, u.key, table), target, old, target).Error
+			if err != nil {
+				return err
+			}
+
+			err = tx.Where("person_id = ?", old).Delete(u.table).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		err := tx.Delete(&sqlPerson{}, old).Error
+		if err != nil {
+			return err
+		}
+
+		s.forgetPerson(old)
+
+		return nil
+	})
+}
+
+// forgetPerson removes the cached rows that referenced a replaced person, so they are written again if needed
+func (s *gormStorage) forgetPerson(id model.ID) {
+	delete(s.sqlPeople, id.String())
+
+	for k, v := range s.sqlRepoCommitPeople {
+		if v.PersonID == id {
+			delete(s.sqlRepoCommitPeople, k)
+		}
+	}
+	for k, v := range s.sqlPersonRepos {
+		if v.PersonID == id {
+			delete(s.sqlPersonRepos, k)
+		}
+	}
+	for k, v := range s.sqlPersonFiles {
+		if v.PersonID == id {
+			delete(s.sqlPersonFiles, k)
+		}
+	}
+	for k, v := range s.monthLines {
+		if v.AuthorID == id || v.CommitterID == id {
+			delete(s.monthLines, k)
+		}
+	}
+}
+
 func (s *gormStorage) LoadPeopleRelations() (*model.PeopleRelations, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
@@ -629,11 +848,20 @@ func (s *gormStorage) LoadRepositories() (*model.Repositories, error) {
 
 	s.sqlRepoCommitPeople = createCache(scps)
 
+	var tags []*sqlRepositoryTag
+	err = s.db.Find(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	s.sqlRepoTags = createCache(tags)
+
 	for _, sr := range repos {
 		r := result.GetOrCreateEx(sr.RootDir, &sr.ID)
 		r.Name = sr.Name
 		r.VCS = sr.VCS
 		r.Branch = sr.Branch
+		r.MainBranch = sr.MainBranch
 		r.Data = decodeMap(sr.Data)
 		r.FirstSeen = sr.FirstSeen
 		r.LastSeen = sr.LastSeen
@@ -652,6 +880,7 @@ func (s *gormStorage) LoadRepositories() (*model.Repositories, error) {
 		c.Date = sc.Date
 		c.DateAuthored = sc.DateAuthored
 		c.Ignore = sc.Ignore
+		c.Branches = sc.Branches
 		c.FilesModified = decodeMetric(sc.FilesModified)
 		c.FilesCreated = decodeMetric(sc.FilesCreated)
 		c.FilesDeleted = decodeMetric(sc.FilesDeleted)
@@ -691,6 +920,16 @@ func (s *gormStorage) LoadRepositories() (*model.Repositories, error) {
 		}
 	}
 
+	for _, st := range tags {
+		repo := result.GetByID(st.RepositoryID)
+
+		t := repo.GetOrCreateTagEx(st.Name, &st.ID)
+		t.CommitID = st.CommitID
+		t.Date = st.Date
+		t.Annotated = st.Annotated
+		t.Message = st.Message
+	}
+
 	s.repos = result
 	return result, nil
 }
@@ -720,7 +959,28 @@ func (s *gormStorage) writeRepositories(repos []*model.Repository) error {
 	var sqlCommits []*sqlRepositoryCommit
 	var sqlCommitFiles []*sqlRepositoryCommitFile
 	var sqlCommitPeople []*sqlRepositoryCommitPerson
+	var sqlTags []*sqlRepositoryTag
+	var deletedTags []model.ID
+	reposByID := lo.KeyBy(repos, func(r *model.Repository) model.ID { return r.ID })
+	for k, st := range s.sqlRepoTags {
+		repo, ok := reposByID[st.RepositoryID]
+		if !ok {
+			continue
+		}
+
+		if t := repo.GetTag(st.Name); t == nil || t.ID != st.ID {
+			deletedTags = append(deletedTags, st.ID)
+			delete(s.sqlRepoTags, k)
+		}
+	}
 	for _, repo := range repos {
+		for _, t := range repo.ListTags() {
+			st := newSqlRepositoryTag(repo, t)
+			if prepareChange(&s.sqlRepoTags, st) {
+				sqlTags = append(sqlTags, st)
+			}
+		}
+
 		for _, c := range repo.ListCommits() {
 			sc := newSqlRepositoryCommit(repo, c)
 			if prepareChange(&s.sqlRepoCommits, sc) {
@@ -782,6 +1042,20 @@ func (s *gormStorage) writeRepositories(repos []*model.Repository) error {
 
 	addList(&s.sqlRepoCommitPeople, sqlCommitPeople)
 
+	err = db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&sqlTags).Error
+	if err != nil {
+		return err
+	}
+
+	addList(&s.sqlRepoTags, sqlTags)
+
+	if len(deletedTags) > 0 {
+		err = db.Delete(&sqlRepositoryTag{}, deletedTags).Error
+		if err != nil {
+			return err
+		}
+	}
+
 	// TODO delete
 
 	return nil
@@ -0,0 +1,234 @@
+package orm
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/consoles"
+	"github.com/pescuma/archer/lib/model"
+)
+
+func TestProjectColumns(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "archer.db")
+
+	s, err := NewGormStorage(WithSqlite(file), consoles.NewStdOutConsole())
+	assert.Nil(t, err)
+
+	projs, err := s.LoadProjects()
+	assert.Nil(t, err)
+
+	p := projs.GetOrCreate("order")
+	p.Type = model.DatabaseType
+
+	c := p.GetColumn("customer_id")
+	c.InDatabase = true
+	c.Type = "bigint"
+	c.ForeignKey = "customer"
+	c.Indexes = []string{"order_customer_idx"}
+
+	c = p.GetColumn("note")
+	c.InDatabase = true
+	c.Type = "text"
+	c.Nullable = true
+
+	assert.Nil(t, s.WriteProjects())
+
+	p.ClearDatabaseColumns()
+	c = p.GetColumn("customer_id")
+	c.InDatabase = true
+	c.Type = "bigint"
+	c.ForeignKey = "customer"
+
+	assert.Nil(t, s.WriteProjects())
+	assert.Nil(t, s.Close())
+
+	s, err = NewGormStorage(WithSqlite(file), consoles.NewStdOutConsole())
+	assert.Nil(t, err)
+
+	projs, err = s.LoadProjects()
+	assert.Nil(t, err)
+
+	cs := projs.GetOrCreate("order").ListColumns()
+	if assert.Len(t, cs, 1) {
+		assert.Equal(t, "customer_id", cs[0].Name)
+		assert.Equal(t, "bigint", cs[0].Type)
+		assert.Equal(t, "customer", cs[0].ForeignKey)
+		assert.Empty(t, cs[0].Indexes)
+		assert.True(t, cs[0].IsForeignKeyWithoutIndex())
+	}
+
+	assert.Nil(t, s.Close())
+}
+
+func TestRepositoryTags(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "archer.db")
+
+	s, err := NewGormStorage(WithSqlite(file), consoles.NewStdOutConsole())
+	assert.Nil(t, err)
+
+	repos, err := s.LoadRepositories()
+	assert.Nil(t, err)
+
+	repo := repos.GetOrCreate("/repo")
+	c := repo.GetOrCreateCommit("abc")
+
+	tag := repo.GetOrCreateTag("v1.0.0")
+	tag.CommitID = c.ID
+	tag.Date = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tag.Annotated = true
+	tag.Message = "First release"
+
+	assert.Nil(t, s.WriteRepositories())
+	assert.Nil(t, s.Close())
+
+	s, err = NewGormStorage(WithSqlite(file), consoles.NewStdOutConsole())
+	assert.Nil(t, err)
+
+	repos, err = s.LoadRepositories()
+	assert.Nil(t, err)
+
+	tags := repos.Get("/repo").ListTags()
+	if assert.Len(t, tags, 1) {
+		assert.Equal(t, "v1.0.0", tags[0].Name)
+		assert.Equal(t, repos.Get("/repo").GetCommit("abc").ID, tags[0].CommitID)
+		assert.True(t, tags[0].Date.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+		assert.True(t, tags[0].Annotated)
+		assert.Equal(t, "First release", tags[0].Message)
+	}
+
+	repos.Get("/repo").DeleteTag("v1.0.0")
+	repos.Get("/repo").GetOrCreateTag("v1.0.1").CommitID = repos.Get("/repo").GetCommit("abc").ID
+
+	assert.Nil(t, s.WriteRepository(repos.Get("/repo")))
+	assert.Nil(t, s.Close())
+
+	s, err = NewGormStorage(WithSqlite(file), consoles.NewStdOutConsole())
+	assert.Nil(t, err)
+
+	repos, err = s.LoadRepositories()
+	assert.Nil(t, err)
+
+	tags = repos.Get("/repo").ListTags()
+	if assert.Len(t, tags, 1) {
+		assert.Equal(t, "v1.0.1", tags[0].Name)
+	}
+
+	assert.Nil(t, s.Close())
+}
+
+func TestGroupCouplings(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "archer.db")
+
+	s, err := NewGormStorage(WithSqlite(file), consoles.NewStdOutConsole())
+	assert.Nil(t, err)
+
+	projs, err := s.LoadProjects()
+	assert.Nil(t, err)
+
+	c := model.NewCoupling()
+	c.Afferent = 2
+	c.Efferent = 1
+	c.Instability = 1. / 3
+	projs.SetGroupCoupling(1, "billing", c)
+	projs.SetGroupCoupling(1, "accounts", model.NewCoupling())
+
+	assert.Nil(t, s.WriteProjects())
+
+	projs.ClearGroupCouplings()
+	projs.SetGroupCoupling(1, "billing", c)
+
+	assert.Nil(t, s.WriteProjects())
+	assert.Nil(t, s.Close())
+
+	s, err = NewGormStorage(WithSqlite(file), consoles.NewStdOutConsole())
+	assert.Nil(t, err)
+
+	projs, err = s.LoadProjects()
+	assert.Nil(t, err)
+
+	assert.Len(t, projs.ListGroupCouplings(), 1)
+	if gc := projs.GetGroupCoupling(1, "billing"); assert.NotNil(t, gc) {
+		assert.Equal(t, 2, gc.Afferent)
+		assert.Equal(t, 1, gc.Efferent)
+		assert.InDelta(t, 0.333, gc.Instability, 0.01)
+		assert.Equal(t, -1., gc.Abstractness)
+	}
+	assert.Nil(t, projs.GetGroupCoupling(0, "billing"))
+
+	assert.Nil(t, s.Close())
+}
+
+func TestReplacePerson(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "archer.db")
+
+	s, err := NewGormStorage(WithSqlite(file), consoles.NewStdOutConsole())
+	assert.Nil(t, err)
+
+	people, err := s.LoadPeople()
+	assert.Nil(t, err)
+	relations, err := s.LoadPeopleRelations()
+	assert.Nil(t, err)
+	repos, err := s.LoadRepositories()
+	assert.Nil(t, err)
+
+	joe := people.GetOrCreatePerson(nil)
+	joe.Name = "Joe"
+	other := people.GetOrCreatePerson(nil)
+	other.Name = "joe"
+
+	repo := repos.GetOrCreate("/src")
+	repo.Name = "src"
+	c1 := repo.GetOrCreateCommit("1")
+	c1.CommitterID = other.ID
+	c1.AuthorIDs = []model.ID{other.ID}
+	c2 := repo.GetOrCreateCommit("2")
+	c2.CommitterID = joe.ID
+	c2.AuthorIDs = []model.ID{joe.ID, other.ID}
+
+	relations.GetOrCreatePersonRepo(joe.ID, repo.ID)
+	relations.GetOrCreatePersonRepo(other.ID, repo.ID)
+
+	assert.Nil(t, s.WritePeople())
+	assert.Nil(t, s.WritePeopleRelations())
+	assert.Nil(t, s.WriteRepositories())
+
+	people.ReplacePerson(other, joe)
+
+	assert.Nil(t, s.WritePeople())
+	assert.Nil(t, s.Close())
+
+	s, err = NewGormStorage(WithSqlite(file), consoles.NewStdOutConsole())
+	assert.Nil(t, err)
+
+	people, err = s.LoadPeople()
+	assert.Nil(t, err)
+	relations, err = s.LoadPeopleRelations()
+	assert.Nil(t, err)
+	repos, err = s.LoadRepositories()
+	assert.Nil(t, err)
+
+	assert.Len(t, people.ListPeople(), 1)
+	assert.Nil(t, people.GetPersonByID(other.ID))
+
+	repo = repos.Get("/src")
+	c1 = repo.GetOrCreateCommit("1")
+	assert.Equal(t, joe.ID, c1.CommitterID)
+	assert.Equal(t, []model.ID{joe.ID}, c1.AuthorIDs)
+	c2 = repo.GetOrCreateCommit("2")
+	assert.Equal(t, []model.ID{joe.ID}, c2.AuthorIDs)
+
+	assert.Len(t, relations.ListRepositories(), 1)
+
+	assert.Nil(t, s.Close())
+}
@@ -0,0 +1,29 @@
+package orm
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+type sqlGroupCoupling struct {
+	Level    int          `gorm:"primaryKey;autoIncrement:false"`
+	Name     string       `gorm:"primaryKey"`
+	Coupling *sqlCoupling `gorm:"embedded;embeddedPrefix:coupling_"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func newSqlGroupCoupling(g model.GroupLevel, c *model.Coupling) *sqlGroupCoupling {
+	return &sqlGroupCoupling{
+		Level:    g.Level,
+		Name:     g.Name,
+		Coupling: newSqlCoupling(c),
+	}
+}
+
+func (s *sqlGroupCoupling) CacheKey() string {
+	return compositeKey(strconv.Itoa(s.Level), s.Name)
+}
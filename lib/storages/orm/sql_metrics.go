@@ -5,6 +5,8 @@ import "github.com/pescuma/archer/lib/model"
 type sqlMetrics struct {
 	DependenciesGuice    *int
 	Abstracts            *int
+	Types                *int
+	TypesAbstract        *int
 	ComplexityCyclomatic *int
 	ComplexityCognitive  *int
 	ComplexityFocus      *int
@@ -14,16 +16,48 @@ func newSqlMetrics(m *model.Metrics) *sqlMetrics {
 	return &sqlMetrics{
 		DependenciesGuice:    encodeMetric(m.GuiceDependencies),
 		Abstracts:            encodeMetric(m.Abstracts),
+		Types:                encodeMetric(m.Types),
+		TypesAbstract:        encodeMetric(m.AbstractTypes),
 		ComplexityCyclomatic: encodeMetric(m.CyclomaticComplexity),
 		ComplexityCognitive:  encodeMetric(m.CognitiveComplexity),
 		ComplexityFocus:      encodeMetric(m.FocusedComplexity),
 	}
 }
 
+type sqlCoupling struct {
+	Afferent     *int
+	Efferent     *int
+	Instability  *float32
+	Abstractness *float32
+	Distance     *float32
+}
+
+func newSqlCoupling(c *model.Coupling) *sqlCoupling {
+	return &sqlCoupling{
+		Afferent:     encodeMetric(c.Afferent),
+		Efferent:     encodeMetric(c.Efferent),
+		Instability:  encodeRatio(c.Instability),
+		Abstractness: encodeRatio(c.Abstractness),
+		Distance:     encodeRatio(c.Distance),
+	}
+}
+
+func (s *sqlCoupling) ToModel() *model.Coupling {
+	return &model.Coupling{
+		Afferent:     decodeMetric(s.Afferent),
+		Efferent:     decodeMetric(s.Efferent),
+		Instability:  decodeRatio(s.Instability),
+		Abstractness: decodeRatio(s.Abstractness),
+		Distance:     decodeRatio(s.Distance),
+	}
+}
+
 func (s *sqlMetrics) toModel() *model.Metrics {
 	return &model.Metrics{
 		GuiceDependencies:    decodeMetric(s.DependenciesGuice),
 		Abstracts:            decodeMetric(s.Abstracts),
+		Types:                decodeMetric(s.Types),
+		AbstractTypes:        decodeMetric(s.TypesAbstract),
 		CyclomaticComplexity: decodeMetric(s.ComplexityCyclomatic),
 		CognitiveComplexity:  decodeMetric(s.ComplexityCognitive),
 		FocusedComplexity:    decodeMetric(s.ComplexityFocus),
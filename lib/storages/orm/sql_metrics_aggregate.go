@@ -9,6 +9,9 @@ import (
 type sqlMetricsAggregate struct {
 	DependenciesGuiceTotal    *int
 	DependenciesGuiceAvg      *float32
+	AbstractsTotal            *int
+	TypesTotal                *int
+	TypesAbstractTotal        *int
 	ComplexityCyclomaticTotal *int
 	ComplexityCyclomaticAvg   *float32
 	ComplexityCognitiveTotal  *int
@@ -21,6 +24,9 @@ func newSqlMetricsAggregate(m *model.Metrics, s *model.Size) *sqlMetricsAggregat
 	return &sqlMetricsAggregate{
 		DependenciesGuiceTotal:    encodeMetric(m.GuiceDependencies),
 		DependenciesGuiceAvg:      encodeMetricAggregate(m.GuiceDependencies, s.Files),
+		AbstractsTotal:            encodeMetric(m.Abstracts),
+		TypesTotal:                encodeMetric(m.Types),
+		TypesAbstractTotal:        encodeMetric(m.AbstractTypes),
 		ComplexityCyclomaticTotal: encodeMetric(m.CyclomaticComplexity),
 		ComplexityCyclomaticAvg:   encodeMetricAggregate(m.CyclomaticComplexity, s.Files),
 		ComplexityCognitiveTotal:  encodeMetric(m.CognitiveComplexity),
@@ -33,6 +39,9 @@ func newSqlMetricsAggregate(m *model.Metrics, s *model.Size) *sqlMetricsAggregat
 func (s *sqlMetricsAggregate) ToModel() *model.Metrics {
 	return &model.Metrics{
 		GuiceDependencies:    decodeMetric(s.DependenciesGuiceTotal),
+		Abstracts:            decodeMetric(s.AbstractsTotal),
+		Types:                decodeMetric(s.TypesTotal),
+		AbstractTypes:        decodeMetric(s.TypesAbstractTotal),
 		CyclomaticComplexity: decodeMetric(s.ComplexityCyclomaticTotal),
 		CognitiveComplexity:  decodeMetric(s.ComplexityCognitiveTotal),
 		FocusedComplexity:    decodeMetric(s.ComplexityFocusTotal),
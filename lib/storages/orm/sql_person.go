@@ -15,6 +15,7 @@ type sqlPerson struct {
 	Changes   *sqlChanges       `gorm:"embedded;embeddedPrefix:changes_"`
 	Blame     *sqlBlame         `gorm:"embedded;embeddedPrefix:blame_"`
 	Data      map[string]string `gorm:"serializer:json"`
+	Ignore    bool
 	FirstSeen time.Time
 	LastSeen  time.Time
 
@@ -34,6 +35,7 @@ func newSqlPerson(p *model.Person) *sqlPerson {
 		Changes:   newSqlChanges(p.Changes),
 		Blame:     newSqlBlame(p.Blame),
 		Data:      encodeMap(p.Data),
+		Ignore:    p.Ignore,
 		FirstSeen: p.FirstSeen,
 		LastSeen:  p.LastSeen,
 	}
@@ -22,6 +22,7 @@ type sqlProject struct {
 	Size      *sqlSize             `gorm:"embedded;embeddedPrefix:size_"`
 	Changes   *sqlChanges          `gorm:"embedded;embeddedPrefix:changes_"`
 	Metrics   *sqlMetricsAggregate `gorm:"embedded"`
+	Coupling  *sqlCoupling         `gorm:"embedded;embeddedPrefix:coupling_"`
 	Data      map[string]string    `gorm:"serializer:json"`
 	FirstSeen time.Time
 	LastSeen  time.Time
@@ -32,6 +33,7 @@ type sqlProject struct {
 	DependencySources []sqlProjectDependency `gorm:"foreignKey:SourceID"`
 	DependencyTargets []sqlProjectDependency `gorm:"foreignKey:TargetID"`
 	Dirs              []sqlProjectDirectory  `gorm:"foreignKey:ProjectID"`
+	Columns           []sqlProjectColumn     `gorm:"foreignKey:ProjectID"`
 	Files             []sqlFile              `gorm:"foreignKey:ProjectID"`
 }
 
@@ -49,6 +51,7 @@ func newSqlProject(p *model.Project) *sqlProject {
 		Size:         newSqlSize(p.Size),
 		Changes:      newSqlChanges(p.Changes),
 		Metrics:      newSqlMetricsAggregate(p.Metrics, p.Size),
+		Coupling:     newSqlCoupling(p.Coupling),
 		Data:         encodeMap(p.Data),
 		FirstSeen:    p.FirstSeen,
 		LastSeen:     p.LastSeen,
@@ -0,0 +1,51 @@
+package orm
+
+import (
+	"time"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+type sqlProjectColumn struct {
+	ID        model.ID
+	ProjectID model.ID `gorm:"index"`
+	Name      string
+
+	InDatabase bool
+	Type       string
+	Nullable   bool
+	PrimaryKey bool
+	ForeignKey string
+	Indexes    []string `gorm:"serializer:json"`
+
+	InCode       bool
+	CodeType     string
+	CodeNullable bool
+
+	Data map[string]string `gorm:"serializer:json"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func newSqlProjectColumn(c *model.ProjectColumn, p *model.Project) *sqlProjectColumn {
+	return &sqlProjectColumn{
+		ID:           c.ID,
+		ProjectID:    p.ID,
+		Name:         c.Name,
+		InDatabase:   c.InDatabase,
+		Type:         c.Type,
+		Nullable:     c.Nullable,
+		PrimaryKey:   c.PrimaryKey,
+		ForeignKey:   c.ForeignKey,
+		Indexes:      c.Indexes,
+		InCode:       c.InCode,
+		CodeType:     c.CodeType,
+		CodeNullable: c.CodeNullable,
+		Data:         encodeMap(c.Data),
+	}
+}
+
+func (s *sqlProjectColumn) CacheKey() string {
+	return s.ID.String()
+}
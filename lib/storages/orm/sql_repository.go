@@ -13,6 +13,8 @@ type sqlRepository struct {
 	VCS     string
 	Branch  string
 
+	MainBranch string
+
 	CommitsTotal int
 	FilesTotal   *int
 	FilesHead    *int
@@ -36,6 +38,7 @@ func newSqlRepository(r *model.Repository) *sqlRepository {
 		RootDir:      r.RootDir,
 		VCS:          r.VCS,
 		Branch:       r.Branch,
+		MainBranch:   r.MainBranch,
 		Data:         encodeMap(r.Data),
 		FirstSeen:    r.FirstSeen,
 		LastSeen:     r.LastSeen,
@@ -16,6 +16,7 @@ type sqlRepositoryCommit struct {
 	Date         time.Time  `gorm:"index"`
 	DateAuthored time.Time
 	Ignore       bool
+	Branches     []string `gorm:"serializer:json"`
 
 	FilesModified *int
 	FilesCreated  *int
@@ -43,6 +44,7 @@ func newSqlRepositoryCommit(r *model.Repository, c *model.RepositoryCommit) *sql
 		Date:          c.Date,
 		DateAuthored:  c.DateAuthored,
 		Ignore:        c.Ignore,
+		Branches:      c.Branches,
 		FilesModified: encodeMetric(c.FilesModified),
 		FilesCreated:  encodeMetric(c.FilesCreated),
 		FilesDeleted:  encodeMetric(c.FilesDeleted),
@@ -0,0 +1,36 @@
+package orm
+
+import (
+	"time"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+type sqlRepositoryTag struct {
+	ID           model.ID
+	RepositoryID model.ID `gorm:"index"`
+	Name         string
+	CommitID     model.ID `gorm:"index"`
+	Date         time.Time
+	Annotated    bool
+	Message      string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func newSqlRepositoryTag(r *model.Repository, t *model.RepositoryTag) *sqlRepositoryTag {
+	return &sqlRepositoryTag{
+		ID:           t.ID,
+		RepositoryID: r.ID,
+		Name:         t.Name,
+		CommitID:     t.CommitID,
+		Date:         t.Date,
+		Annotated:    t.Annotated,
+		Message:      t.Message,
+	}
+}
+
+func (s *sqlRepositoryTag) CacheKey() string {
+	return s.ID.String()
+}
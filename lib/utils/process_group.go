@@ -16,7 +16,12 @@ func ParallelFor[T, O any](col []T, proc func(T) (O, error), opts ...ParallelOpt
 
 	go func() {
 		for _, w := range col {
-			group.Input <- w
+			select {
+			case <-group.abort:
+				group.FinishedInput()
+				return
+			case group.Input <- w:
+			}
 		}
 
 		group.FinishedInput()
@@ -26,18 +31,24 @@ func ParallelFor[T, O any](col []T, proc func(T) (O, error), opts ...ParallelOpt
 }
 
 type ProcessGroup[I, O any] struct {
-	proc  func(I) (O, error)
-	abort chan struct{}
-	wg    sync.WaitGroup
+	proc      func(I) (O, error)
+	abort     chan struct{}
+	abortOnce sync.Once
+	err       error
+	wg        sync.WaitGroup
 
 	Input  chan I
 	Output chan O
-	Err    chan error
+}
+
+// DefaultRoutines returns the number of goroutines used by default to process things in parallel
+func DefaultRoutines() int {
+	return Max(Min(runtime.GOMAXPROCS(-1), runtime.NumCPU()/2)-1, 1)
 }
 
 func NewProcessGroup[I, O any](proc func(I) (O, error), opts ...ParallelOptions) *ProcessGroup[I, O] {
 	o := ParallelOptions{
-		Routines:     Max(Min(runtime.GOMAXPROCS(-1), runtime.NumCPU()/2)-1, 1),
+		Routines:     DefaultRoutines(),
 		InputFactor:  2,
 		OutputFactor: 2,
 	}
@@ -59,7 +70,6 @@ func NewProcessGroup[I, O any](proc func(I) (O, error), opts ...ParallelOptions)
 
 		Input:  make(chan I, o.InputFactor*o.Routines),
 		Output: make(chan O, o.OutputFactor*o.Routines),
-		Err:    make(chan error),
 	}
 
 	for i := 0; i < o.Routines; i++ {
@@ -70,7 +80,6 @@ func NewProcessGroup[I, O any](proc func(I) (O, error), opts ...ParallelOptions)
 	go func() {
 		group.wg.Wait()
 		close(group.Output)
-		close(group.Err)
 	}()
 
 	return &group
@@ -104,9 +113,13 @@ func (g *ProcessGroup[I, O]) FinishedInput() {
 	close(g.Input)
 }
 
+// Abort stops the processors. Only the first error is kept. It can also be called after the processors finished,
+// while consuming the output.
 func (g *ProcessGroup[I, O]) Abort(err error) {
-	g.Err <- err
-	close(g.abort)
+	g.abortOnce.Do(func() {
+		g.err = err
+		close(g.abort)
+	})
 }
 
 func (g *ProcessGroup[I, O]) Aborted() bool {
@@ -118,14 +131,19 @@ func (g *ProcessGroup[I, O]) Aborted() bool {
 	}
 }
 
+// Error waits for the processors to finish and returns the error that aborted the group, if any
 func (g *ProcessGroup[I, O]) Error() error {
-	return <-g.Err
+	g.wg.Wait()
+
+	if !g.Aborted() {
+		return nil
+	}
+	return g.err
 }
 
 func (g *ProcessGroup[I, O]) Close() {
 	close(g.abort)
 	close(g.Input)
 	close(g.Output)
-	close(g.Err)
 	g.wg.Wait()
 }
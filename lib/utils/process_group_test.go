@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"errors"
+	"sort"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallelFor(t *testing.T) {
+	t.Parallel()
+
+	group := ParallelFor([]int{1, 2, 3, 4, 5}, func(i int) (int, error) {
+		return i * 2, nil
+	}, ParallelOptions{Routines: 3})
+
+	var result []int
+	for o := range group.Output {
+		result = append(result, o)
+	}
+
+	sort.Ints(result)
+	assert.Equal(t, []int{2, 4, 6, 8, 10}, result)
+	assert.Nil(t, group.Error())
+}
+
+func TestParallelForAbortOnError(t *testing.T) {
+	t.Parallel()
+
+	input := make([]int, 1000)
+	for i := range input {
+		input[i] = i
+	}
+
+	var processed atomic.Int32
+	group := ParallelFor(input, func(i int) (int, error) {
+		processed.Add(1)
+		if i == 10 {
+			return 0, errors.New("failed")
+		}
+		return i, nil
+	}, ParallelOptions{Routines: 2})
+
+	for range group.Output {
+	}
+
+	assert.EqualError(t, group.Error(), "failed")
+	assert.True(t, group.Aborted())
+	assert.Less(t, int(processed.Load()), len(input))
+}
+
+func TestProcessGroupAbortKeepsFirstError(t *testing.T) {
+	t.Parallel()
+
+	group := ParallelFor([]int{1, 2, 3}, func(i int) (int, error) {
+		return i, nil
+	}, ParallelOptions{Routines: 1})
+
+	group.Abort(errors.New("first"))
+	group.Abort(errors.New("second"))
+
+	for range group.Output {
+	}
+
+	assert.EqualError(t, group.Error(), "first")
+}
+
+func TestProcessGroupAbortWhileConsumingOutput(t *testing.T) {
+	t.Parallel()
+
+	group := ParallelFor([]int{1, 2, 3}, func(i int) (int, error) {
+		return i, nil
+	}, ParallelOptions{Routines: 1})
+
+	var result []int
+	for o := range group.Output {
+		if o == 2 {
+			group.Abort(errors.New("failed"))
+		}
+		if group.Aborted() {
+			continue
+		}
+		result = append(result, o)
+	}
+
+	assert.Equal(t, []int{1}, result)
+	assert.EqualError(t, group.Error(), "failed")
+}
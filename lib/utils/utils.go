@@ -215,7 +215,7 @@ func ListFilesRecursive(rootDir string, matcher func(name string) bool) ([]strin
 		case err != nil:
 			return nil
 
-		case entry.IsDir() && strings.HasPrefix(entry.Name(), "."):
+		case entry.IsDir() && (strings.HasPrefix(entry.Name(), ".") || entry.Name() == "node_modules"):
 			return filepath.SkipDir
 
 		case !entry.IsDir() && matcher(entry.Name()):
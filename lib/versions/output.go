@@ -0,0 +1,107 @@
+package versions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+func WriteText(w io.Writer, cs []*Conflict, repos *model.Repositories) error {
+	if len(cs) == 0 {
+		_, err := fmt.Fprintf(w, "No libraries with more than one version found\n")
+		return err
+	}
+
+	for _, c := range cs {
+		_, _ = fmt.Fprintf(w, "%v (%v versions", c.Library.Name, len(c.Versions))
+		if c.Newest != "" {
+			_, _ = fmt.Fprintf(w, ", newest %v", c.Newest)
+		}
+		_, _ = fmt.Fprintf(w, ")\n")
+
+		for _, v := range c.Versions {
+			_, _ = fmt.Fprintf(w, "   %v", v.Version)
+			switch {
+			case v.VersionsBehind < 0:
+				_, _ = fmt.Fprintf(w, " (unknown order)")
+			case v.VersionsBehind > 0:
+				_, _ = fmt.Fprintf(w, " (%v behind, %v versions)", v.Behind, v.VersionsBehind)
+			}
+			_, _ = fmt.Fprintln(w)
+
+			for _, p := range v.Consumers {
+				_, _ = fmt.Fprintf(w, "      %v\n", consumerText(p, repos))
+			}
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "\n%v libraries with more than one version found\n", len(cs))
+	return err
+}
+
+func consumerText(p *model.Project, repos *model.Repositories) string {
+	var where []string
+	if g := p.FullGroup(); g != "" {
+		where = append(where, "root "+g)
+	}
+	if r := repositoryName(p, repos); r != "" {
+		where = append(where, "repo "+r)
+	}
+
+	if len(where) == 0 {
+		return p.Name
+	}
+	return fmt.Sprintf("%v [%v]", p.Name, strings.Join(where, ", "))
+}
+
+func repositoryName(p *model.Project, repos *model.Repositories) string {
+	if p.RepositoryID == nil || repos == nil {
+		return ""
+	}
+
+	r := repos.GetByID(*p.RepositoryID)
+	if r == nil {
+		return ""
+	}
+
+	return r.Name
+}
+
+// ToJSON returns a representation of the conflicts that can be serialized to JSON
+func ToJSON(cs []*Conflict, repos *model.Repositories) []map[string]any {
+	return lo.Map(cs, func(c *Conflict, _ int) map[string]any {
+		return map[string]any{
+			"library": map[string]any{
+				"id":   c.Library.ID,
+				"name": c.Library.Name,
+			},
+			"newest": c.Newest,
+			"versions": lo.Map(c.Versions, func(v *Version, _ int) map[string]any {
+				return map[string]any{
+					"version":        v.Version,
+					"behind":         v.Behind,
+					"versionsBehind": lo.Ternary(v.VersionsBehind < 0, nil, &v.VersionsBehind),
+					"consumers": lo.Map(v.Consumers, func(p *model.Project, _ int) map[string]any {
+						return map[string]any{
+							"id":         p.ID,
+							"name":       p.Name,
+							"root":       p.FullGroup(),
+							"repository": repositoryName(p, repos),
+						}
+					}),
+				}
+			}),
+		}
+	})
+}
+
+func WriteJSON(w io.Writer, cs []*Conflict, repos *model.Repositories) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(ToJSON(cs, repos))
+}
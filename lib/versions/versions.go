@@ -0,0 +1,160 @@
+package versions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+// Conflict is a library used with more than one version
+type Conflict struct {
+	Library *model.Project
+	// Newest is the newest version seen, or empty if no version could be parsed
+	Newest   string
+	Versions []*Version
+}
+
+type Version struct {
+	Version string
+	// Behind is how far this version is from the newest one, as a text, or empty if it is the newest or unknown
+	Behind string
+	// VersionsBehind is the number of seen versions that are newer than this one, or -1 if unknown
+	VersionsBehind int
+	Consumers      []*model.Project
+
+	parsed *semver.Version
+}
+
+// FindConflicts lists the libraries that are used with more than one version, ordered by name.
+// Versions are ordered from newest to oldest, with versions that are not semver in the end.
+func FindConflicts(ps []*model.Project) []*Conflict {
+	byLibrary := map[*model.Project]map[string]*Version{}
+
+	for _, p := range ps {
+		for _, d := range p.ListDependencies(model.FilterAll) {
+			if !d.Target.IsExternalDependency() {
+				continue
+			}
+
+			vs, ok := byLibrary[d.Target]
+			if !ok {
+				vs = map[string]*Version{}
+				byLibrary[d.Target] = vs
+			}
+
+			for _, v := range d.Versions.Slice() {
+				if v == "" {
+					continue
+				}
+
+				version, ok := vs[v]
+				if !ok {
+					version = newVersion(v)
+					vs[v] = version
+				}
+
+				version.Consumers = append(version.Consumers, p)
+			}
+		}
+	}
+
+	var result []*Conflict
+	for lib, vs := range byLibrary {
+		if len(vs) < 2 {
+			continue
+		}
+
+		c := &Conflict{
+			Library: lib,
+		}
+
+		for _, v := range vs {
+			sort.Slice(v.Consumers, func(i, j int) bool { return v.Consumers[i].Name < v.Consumers[j].Name })
+			c.Versions = append(c.Versions, v)
+		}
+
+		sort.Slice(c.Versions, func(i, j int) bool { return lessVersion(c.Versions[j], c.Versions[i]) })
+
+		c.computeBehind()
+
+		result = append(result, c)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Library.Name < result[j].Library.Name })
+
+	return result
+}
+
+func newVersion(v string) *Version {
+	result := &Version{
+		Version:        v,
+		VersionsBehind: -1,
+	}
+
+	parsed, err := semver.NewVersion(strings.TrimLeft(v, "^~=>v "))
+	if err == nil {
+		result.parsed = parsed
+	}
+
+	return result
+}
+
+// lessVersion orders by semver, with unparsed versions considered older than all others
+func lessVersion(a, b *Version) bool {
+	switch {
+	case a.parsed == nil && b.parsed == nil:
+		return a.Version > b.Version
+	case a.parsed == nil:
+		return true
+	case b.parsed == nil:
+		return false
+	case !a.parsed.Equal(b.parsed):
+		return a.parsed.LessThan(b.parsed)
+	default:
+		return a.Version < b.Version
+	}
+}
+
+func (c *Conflict) computeBehind() {
+	newest := c.Versions[0].parsed
+	if newest == nil {
+		return
+	}
+
+	c.Newest = c.Versions[0].Version
+
+	newer := 0
+	var prev *semver.Version
+	for _, v := range c.Versions {
+		if v.parsed == nil {
+			continue
+		}
+
+		if prev != nil && !prev.Equal(v.parsed) {
+			newer++
+		}
+		prev = v.parsed
+
+		v.VersionsBehind = newer
+		v.Behind = behindText(newest, v.parsed)
+	}
+}
+
+func behindText(newest, v *semver.Version) string {
+	switch {
+	case newest.Major() != v.Major():
+		return fmt.Sprintf("%v major", newest.Major()-v.Major())
+	case newest.Minor() != v.Minor():
+		return fmt.Sprintf("%v minor", newest.Minor()-v.Minor())
+	case newest.Patch() != v.Patch():
+		return fmt.Sprintf("%v patch", newest.Patch()-v.Patch())
+	case !newest.Equal(v):
+		return "prerelease"
+	default:
+		return ""
+	}
+}
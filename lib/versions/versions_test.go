@@ -0,0 +1,56 @@
+package versions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pescuma/archer/lib/model"
+)
+
+func TestFindConflicts(t *testing.T) {
+	t.Parallel()
+
+	projs := model.NewProjects()
+
+	add := func(from, to string, versions ...string) {
+		f := projs.GetOrCreate(from)
+		f.Type = model.CodeType
+		d := f.GetOrCreateDependency(projs.GetOrCreate(to))
+		for _, v := range versions {
+			d.Versions.Insert(v)
+		}
+	}
+
+	add("a", "guava", "31.1")
+	add("b", "guava", "v32.0.1")
+	add("c", "guava", "30.0.0")
+	add("d", "guava", "${guava.version}")
+	add("a", "junit", "4.13")
+	add("b", "junit", "4.13")
+	add("a", "b")
+
+	cs := FindConflicts(projs.ListProjects(model.FilterAll))
+
+	assert.Equal(t, 1, len(cs))
+
+	c := cs[0]
+	assert.Equal(t, "guava", c.Library.Name)
+	assert.Equal(t, "v32.0.1", c.Newest)
+
+	assert.Equal(t, []string{"v32.0.1", "31.1", "30.0.0", "${guava.version}"},
+		[]string{c.Versions[0].Version, c.Versions[1].Version, c.Versions[2].Version, c.Versions[3].Version})
+	assert.Equal(t, []int{0, 1, 2, -1},
+		[]int{c.Versions[0].VersionsBehind, c.Versions[1].VersionsBehind, c.Versions[2].VersionsBehind, c.Versions[3].VersionsBehind})
+	assert.Equal(t, "", c.Versions[0].Behind)
+	assert.Equal(t, "1 major", c.Versions[1].Behind)
+	assert.Equal(t, "a", c.Versions[1].Consumers[0].Name)
+}
+
+func TestBehindText(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "2 minor", behindText(newVersion("1.5.0").parsed, newVersion("^1.3.9").parsed))
+	assert.Equal(t, "1 patch", behindText(newVersion("1.5.1").parsed, newVersion("1.5.0").parsed))
+	assert.Equal(t, "prerelease", behindText(newVersion("1.5.0").parsed, newVersion("1.5.0-rc1").parsed))
+}
@@ -12,16 +12,28 @@ import (
 	"github.com/pescuma/archer/lib/consoles"
 	"github.com/pescuma/archer/lib/ignore_rules"
 	"github.com/pescuma/archer/lib/importers/blame"
+	"github.com/pescuma/archer/lib/importers/cargo"
 	"github.com/pescuma/archer/lib/importers/csproj"
+	"github.com/pescuma/archer/lib/importers/cycles"
 	"github.com/pescuma/archer/lib/importers/git"
 	"github.com/pescuma/archer/lib/importers/gomod"
 	"github.com/pescuma/archer/lib/importers/gradle"
 	"github.com/pescuma/archer/lib/importers/hibernate"
 	"github.com/pescuma/archer/lib/importers/history"
+	"github.com/pescuma/archer/lib/importers/imports"
+	"github.com/pescuma/archer/lib/importers/levels"
 	"github.com/pescuma/archer/lib/importers/loc"
+	"github.com/pescuma/archer/lib/importers/maven"
 	"github.com/pescuma/archer/lib/importers/metrics"
+	"github.com/pescuma/archer/lib/importers/migrations"
 	"github.com/pescuma/archer/lib/importers/mysql"
+	"github.com/pescuma/archer/lib/importers/npm"
+	"github.com/pescuma/archer/lib/importers/osv"
 	"github.com/pescuma/archer/lib/importers/owners"
+	"github.com/pescuma/archer/lib/importers/postgres"
+	"github.com/pescuma/archer/lib/importers/python"
+	"github.com/pescuma/archer/lib/importers/sbom"
+	"github.com/pescuma/archer/lib/importers/sqlite"
 	"github.com/pescuma/archer/lib/model"
 	"github.com/pescuma/archer/lib/storages"
 	"github.com/pescuma/archer/lib/storages/orm"
@@ -139,9 +151,29 @@ func (w *Workspace) ImportGoMod(dirs []string, opts *gomod.Options) error {
 	return importer.Import(dirs, opts)
 }
 
-func (w *Workspace) ImportGradle(dir string) error {
+func (w *Workspace) ImportMaven(dirs []string, opts *maven.Options) error {
+	importer := maven.NewImporter(w.console, w.storage)
+	return importer.Import(dirs, opts)
+}
+
+func (w *Workspace) ImportNpm(dirs []string, opts *npm.Options) error {
+	importer := npm.NewImporter(w.console, w.storage)
+	return importer.Import(dirs, opts)
+}
+
+func (w *Workspace) ImportCargo(dirs []string, opts *cargo.Options) error {
+	importer := cargo.NewImporter(w.console, w.storage)
+	return importer.Import(dirs, opts)
+}
+
+func (w *Workspace) ImportPython(dirs []string, opts *python.Options) error {
+	importer := python.NewImporter(w.console, w.storage)
+	return importer.Import(dirs, opts)
+}
+
+func (w *Workspace) ImportGradle(dir string, opts *gradle.Options) error {
 	importer := gradle.NewImporter(w.console, w.storage)
-	return importer.Import(dir)
+	return importer.Import(dir, opts)
 }
 
 func (w *Workspace) ImportGitRepos(dirs []string, opts *git.ReposOptions) error {
@@ -199,16 +231,56 @@ func (w *Workspace) ComputeMetrics() error {
 	return computer.Compute()
 }
 
+func (w *Workspace) ComputeCycles(opts *cycles.Options) error {
+	computer := cycles.NewComputer(w.console, w.storage)
+	return computer.Compute(opts)
+}
+
+func (w *Workspace) ComputeImports() error {
+	computer := imports.NewComputer(w.console, w.storage)
+	return computer.Compute()
+}
+
+func (w *Workspace) ComputeLevels() error {
+	computer := levels.NewComputer(w.console, w.storage)
+	return computer.Compute()
+}
+
 func (w *Workspace) ImportMySql(connectionString string) error {
 	importer := mysql.NewImporter(w.console, w.storage)
 	return importer.Import(connectionString)
 }
 
+func (w *Workspace) ImportMigrations(dirs []string, opts *migrations.Options) error {
+	importer := migrations.NewImporter(w.console, w.storage)
+	return importer.Import(dirs, opts)
+}
+
+func (w *Workspace) ImportPostgres(connectionString string) error {
+	importer := postgres.NewImporter(w.console, w.storage)
+	return importer.Import(connectionString)
+}
+
+func (w *Workspace) ImportSqlite(file string) error {
+	importer := sqlite.NewImporter(w.console, w.storage)
+	return importer.Import(file)
+}
+
+func (w *Workspace) ImportOsv(path string) error {
+	importer := osv.NewImporter(w.console, w.storage)
+	return importer.Import(path)
+}
+
 func (w *Workspace) ImportOwners(filter []string, opts *owners.Options) error {
 	importer := owners.NewImporter(w.console, w.storage)
 	return importer.Import(filter, opts)
 }
 
+func (w *Workspace) ImportSbom(file string, opts *sbom.Options) error {
+	importer := sbom.NewImporter(w.console, w.storage)
+	return importer.Import(file, opts)
+}
+
 func (w *Workspace) IgnoreAddCommitRule(rule string) error {
 	ignored, err := ignore_rules.New(w.console, w.storage)
 	if err != nil {
@@ -227,6 +299,50 @@ func (w *Workspace) IgnoreAddFileRule(rule string) error {
 	return ignored.AddFileRule(rule)
 }
 
+func (w *Workspace) IgnoreAddPersonRule(rule string) error {
+	ignored, err := ignore_rules.New(w.console, w.storage)
+	if err != nil {
+		return err
+	}
+
+	return ignored.AddPersonRule(rule)
+}
+
+func (w *Workspace) FindLikelyBots() ([]*ignore_rules.LikelyBot, error) {
+	people, err := w.storage.LoadPeople()
+	if err != nil {
+		return nil, err
+	}
+
+	repos, err := w.storage.LoadRepositories()
+	if err != nil {
+		return nil, err
+	}
+
+	return ignore_rules.FindLikelyBots(people, repos), nil
+}
+
+func (w *Workspace) ListPeople(filter string) ([]*model.Person, error) {
+	editor := git.NewPeopleEditor(w.console, w.storage)
+	return editor.List(filter)
+}
+
+func (w *Workspace) MergePeople(target string, others []string) error {
+	editor := git.NewPeopleEditor(w.console, w.storage)
+	return editor.Merge(target, others)
+}
+
+func (w *Workspace) SplitPerson(person string, emails []string, name string) error {
+	editor := git.NewPeopleEditor(w.console, w.storage)
+	_, err := editor.Split(person, emails, name)
+	return err
+}
+
+func (w *Workspace) RenamePerson(person string, name string) error {
+	editor := git.NewPeopleEditor(w.console, w.storage)
+	return editor.Rename(person, name)
+}
+
 func (w *Workspace) RunGit(args ...string) error {
 	repos, err := w.storage.LoadRepositories()
 	if err != nil {